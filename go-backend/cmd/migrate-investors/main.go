@@ -0,0 +1,152 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/config"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/investors"
+	"communitycentresplatform/go-backend/internal/lifecycle"
+)
+
+// One-off migration: turns the free-text investor names on service
+// provisions into investor directory entries. Spellings that normalize to
+// the same name share one entry; each service is linked to its investor and
+// backfilled as a deal introduced by the service's hub. Services already
+// linked are skipped, so the migration can be re-run safely.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report changes without writing them")
+	flag.Parse()
+
+	_ = godotenv.Load()
+	cfg := config.Load()
+
+	database, err := db.Connect(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	if err := db.AutoMigrate(database.DB); err != nil {
+		log.Fatalf("auto-migrate failed: %v", err)
+	}
+
+	var services []db.ServiceProvision
+	err = database.DB.
+		Where("investor_id IS NULL AND investor_name IS NOT NULL AND TRIM(investor_name) <> ''").
+		Order("created_at ASC").
+		Find(&services).Error
+	if err != nil {
+		log.Fatalf("failed to list service provisions: %v", err)
+	}
+
+	candidates := investors.Extract(services)
+	created, linked, deals := 0, 0, 0
+
+	for _, cand := range candidates {
+		existing, err := db.FindInvestorBySlug(database.DB, cand.Slug)
+		if err != nil {
+			log.Fatalf("failed to look up investor %q: %v", cand.Name, err)
+		}
+
+		status := "existing"
+		if existing == nil {
+			status = "new"
+		}
+		spellings := map[string]bool{}
+		for _, s := range cand.Services {
+			spellings[strings.TrimSpace(*s.InvestorName)] = true
+		}
+		names := make([]string, 0, len(spellings))
+		for name := range spellings {
+			names = append(names, name)
+		}
+		fmt.Printf("%s (%s): %d service(s) from %s\n", cand.Name, status, len(cand.Services), strings.Join(names, " | "))
+
+		if *dryRun {
+			if existing == nil {
+				created++
+			}
+			linked += len(cand.Services)
+			deals += len(cand.Services)
+			continue
+		}
+
+		err = database.DB.Transaction(func(tx *gorm.DB) error {
+			investor := existing
+			if investor == nil {
+				investor = &db.Investor{
+					Name:        cand.Name,
+					Slug:        cand.Slug,
+					Type:        db.InvestorOther,
+					Description: cand.Details,
+					Currency:    "USD",
+					Active:      true,
+				}
+				if err := db.SaveInvestor(tx, investor); err != nil {
+					return err
+				}
+				created++
+			}
+
+			for _, s := range cand.Services {
+				err := tx.Model(&db.ServiceProvision{}).Where("id = ?", s.ID).Updates(map[string]interface{}{
+					"investor_id":   investor.ID,
+					"investor_name": investor.Name,
+				}).Error
+				if err != nil {
+					return err
+				}
+				linked++
+
+				var count int64
+				if err := tx.Model(&db.Deal{}).Where("service_provision_id = ?", s.ID).Count(&count).Error; err != nil {
+					return err
+				}
+				if count > 0 {
+					continue
+				}
+				serviceID := s.ID
+				deal := db.Deal{
+					InvestorID:         investor.ID,
+					EntrepreneurID:     s.EntrepreneurID,
+					HubID:              s.HubID,
+					Stage:              investors.StageForService(s.Status),
+					Notes:              s.InvestorDetails,
+					ServiceProvisionID: &serviceID,
+					CreatedAt:          s.CreatedAt,
+				}
+				if lifecycle.Deals.Terminal(deal.Stage) {
+					deal.ClosedAt = s.CompletionDate
+					if deal.ClosedAt == nil {
+						deal.ClosedAt = &s.UpdatedAt
+					}
+				}
+				if err := db.CreateDeal(tx, &deal); err != nil {
+					return err
+				}
+				reason := "extracted from service provision"
+				if err := lifecycle.RecordInitial(tx, db.StatusEntityDeal, deal.ID, string(deal.Stage), nil, &reason); err != nil {
+					return err
+				}
+				deals++
+			}
+			return nil
+		})
+		if err != nil {
+			log.Fatalf("failed to migrate investor %q: %v", cand.Name, err)
+		}
+	}
+
+	verb := "Created"
+	if *dryRun {
+		verb = "Would create"
+	}
+	fmt.Printf("\n%s %d investor(s), linking %d service(s) and backfilling %d deal(s) from %d candidate name(s)\n",
+		verb, created, linked, deals, len(candidates))
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/joho/godotenv"
+
+	"communitycentresplatform/go-backend/internal/config"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/taxonomy"
+)
+
+// One-off migration: rewrites the free-text services and resources of every
+// center onto taxonomy entry names and reports values with no matching entry.
+// Unmapped values are left in place so no data is lost; add synonyms for them
+// and re-run.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report changes without writing them")
+	flag.Parse()
+
+	_ = godotenv.Load()
+	cfg := config.Load()
+
+	database, err := db.Connect(cfg.DatabaseURL)
+	if err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	if err := db.AutoMigrate(database.DB); err != nil {
+		log.Fatalf("auto-migrate failed: %v", err)
+	}
+
+	idx, err := taxonomy.Load(database.DB)
+	if err != nil {
+		log.Fatalf("failed to load taxonomy: %v", err)
+	}
+	if idx.Empty(db.TaxonomyService) && idx.Empty(db.TaxonomyResource) {
+		log.Fatalf("taxonomy is empty; create entries via /api/taxonomy before migrating")
+	}
+
+	centers, err := db.ListCenters(database.DB, db.CenterFilters{})
+	if err != nil {
+		log.Fatalf("failed to list centers: %v", err)
+	}
+
+	unmapped := map[db.TaxonomyKind]map[string]int{
+		db.TaxonomyService:  {},
+		db.TaxonomyResource: {},
+	}
+	updated := 0
+
+	for _, center := range centers {
+		// A kind with no entries yet is left alone rather than reported unmapped
+		services, unknownServices := center.Services, []string(nil)
+		if !idx.Empty(db.TaxonomyService) {
+			services, unknownServices = idx.Canonicalize(db.TaxonomyService, center.Services)
+		}
+		resources, unknownResources := center.Resources, []string(nil)
+		if !idx.Empty(db.TaxonomyResource) {
+			resources, unknownResources = idx.Canonicalize(db.TaxonomyResource, center.Resources)
+		}
+		for _, v := range unknownServices {
+			unmapped[db.TaxonomyService][v]++
+		}
+		for _, v := range unknownResources {
+			unmapped[db.TaxonomyResource][v]++
+		}
+
+		if equal(services, center.Services) && equal(resources, center.Resources) {
+			continue
+		}
+
+		fmt.Printf("%s:\n  services:  %s -> %s\n  resources: %s -> %s\n",
+			center.Name,
+			strings.Join(center.Services, ", "), strings.Join(services, ", "),
+			strings.Join(center.Resources, ", "), strings.Join(resources, ", "))
+		updated++
+
+		if *dryRun {
+			continue
+		}
+		if err := database.DB.Model(&db.CommunityCenter{}).Where("id = ?", center.ID).Updates(map[string]interface{}{
+			"services":  db.StringArray(services),
+			"resources": db.StringArray(resources),
+		}).Error; err != nil {
+			log.Fatalf("failed to update center %s: %v", center.ID, err)
+		}
+	}
+
+	verb := "Updated"
+	if *dryRun {
+		verb = "Would update"
+	}
+	fmt.Printf("\n%s %d of %d centers\n", verb, updated, len(centers))
+
+	for _, kind := range []db.TaxonomyKind{db.TaxonomyService, db.TaxonomyResource} {
+		values := unmapped[kind]
+		if len(values) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(values))
+		for v := range values {
+			keys = append(keys, v)
+		}
+		sort.Strings(keys)
+		fmt.Printf("\nUnmapped %s values:\n", strings.ToLower(string(kind)))
+		for _, v := range keys {
+			fmt.Printf("  %-40s %d center(s)\n", v, values[v])
+		}
+	}
+}
+
+func equal(a []string, b db.StringArray) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -306,6 +306,24 @@ func main() {
 		}
 	}
 
+	// Create the default impact metrics funders ask for
+	metricTypes := []db.MetricType{
+		{Key: "jobs_created", Name: "Jobs Created", ValueType: db.MetricInteger, Aggregation: db.MetricSum, Unit: "jobs"},
+		{Key: "revenue_band", Name: "Monthly Revenue", ValueType: db.MetricBand, Aggregation: db.MetricLatest, Unit: "UGX",
+			Bands: db.StringArray{"Pre-revenue", "Under 1M", "1M-5M", "5M-20M", "Over 20M"}},
+		{Key: "funding_raised", Name: "Funding Raised", ValueType: db.MetricDecimal, Aggregation: db.MetricSum, Unit: "UGX"},
+		{Key: "products_launched", Name: "Products Launched", ValueType: db.MetricInteger, Aggregation: db.MetricSum, Unit: "products"},
+	}
+	for _, data := range metricTypes {
+		var metricType db.MetricType
+		data.Active = true
+		if err := database.DB.Where("key = ?", data.Key).FirstOrCreate(&metricType, data).Error; err != nil {
+			log.Printf("failed to create metric type %s: %v", data.Key, err)
+			continue
+		}
+		fmt.Printf("Created metric type: %s\n", metricType.Name)
+	}
+
 	fmt.Println("\nDatabase seeded successfully!")
 	fmt.Println("\nLogin credentials:")
 	fmt.Println("Admin: admin@kampalacenters.org / admin123")
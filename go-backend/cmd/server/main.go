@@ -17,6 +17,7 @@ import (
     "communitycentresplatform/go-backend/internal/config"
     "communitycentresplatform/go-backend/internal/db"
     "communitycentresplatform/go-backend/internal/events"
+    "communitycentresplatform/go-backend/internal/storage"
 )
 
 func main() {
@@ -43,6 +44,23 @@ func main() {
     // SSE broker
     broker := events.NewBroker()
     r.Use(httpx.BrokerMiddleware(broker))
+    // file storage for uploads
+    blob, err := storage.New(storage.Config{
+        Driver:   cfg.StorageDriver,
+        LocalDir: cfg.StorageDir,
+        S3: storage.S3Config{
+            Endpoint:        cfg.S3Endpoint,
+            Region:          cfg.S3Region,
+            Bucket:          cfg.S3Bucket,
+            AccessKeyID:     cfg.S3AccessKeyID,
+            SecretAccessKey: cfg.S3SecretKey,
+            PathStyle:       cfg.S3PathStyle,
+        },
+    })
+    if err != nil {
+        log.Fatalf("failed to set up storage: %v", err)
+    }
+    r.Use(httpx.StorageMiddleware(blob))
     httpx.RegisterRoutes(r, httpx.Deps{FrontendURL: cfg.FrontendURL, DB: database.DB, JWTSecret: cfg.JWTSecret})
 
     srv := &http.Server{
@@ -0,0 +1,110 @@
+package applications
+
+import (
+	"testing"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+const formSchema = `{
+	"type": "object",
+	"properties": {
+		"pitch": {"type": "string", "title": "Pitch", "minLength": 10, "maxLength": 200},
+		"sector": {"type": "string", "enum": ["agri", "fintech", "health"]},
+		"employees": {"type": "integer", "minimum": 0},
+		"email": {"type": "string", "format": "email"},
+		"founders": {"type": "array", "items": {"type": "string"}, "minItems": 1},
+		"registered": {"type": "boolean"}
+	},
+	"required": ["pitch", "sector"]
+}`
+
+func TestParseSchema(t *testing.T) {
+	if _, err := ParseSchema([]byte(formSchema)); err != nil {
+		t.Fatalf("expected schema to parse: %v", err)
+	}
+	bad := []string{
+		`{"type": "array"}`,
+		`{"type": "object", "properties": {}}`,
+		`{"type": "object", "properties": {"a": {"type": "file"}}}`,
+		`{"type": "object", "properties": {"a": {"type": "array"}}}`,
+		`{"type": "object", "properties": {"a": {"type": "string"}}, "required": ["b"]}`,
+	}
+	for _, raw := range bad {
+		if _, err := ParseSchema([]byte(raw)); err == nil {
+			t.Fatalf("expected %s to be rejected", raw)
+		}
+	}
+}
+
+func TestValidateAnswers(t *testing.T) {
+	s, _ := ParseSchema([]byte(formSchema))
+
+	good := `{"pitch": "Solar dryers for farmers", "sector": "agri", "employees": 4,
+		"email": "a@b.co", "founders": ["Amina"], "registered": true}`
+	if _, errs := s.Validate([]byte(good)); errs != nil {
+		t.Fatalf("expected answers to be valid: %+v", errs)
+	}
+
+	bad := `{"pitch": "short", "employees": 2.5, "email": "nope", "founders": [], "extra": 1}`
+	_, errs := s.Validate([]byte(bad))
+	fields := map[string]bool{}
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, f := range []string{"pitch", "sector", "employees", "email", "founders", "extra"} {
+		if !fields[f] {
+			t.Fatalf("expected an error for %q, got %+v", f, errs)
+		}
+	}
+}
+
+func TestRubricScore(t *testing.T) {
+	r, err := ParseRubric([]byte(`{"criteria": [
+		{"key": "team", "label": "Team", "weight": 2, "max": 5},
+		{"key": "market", "label": "Market"}
+	]}`))
+	if err != nil {
+		t.Fatalf("parse rubric: %v", err)
+	}
+	total, err := r.Score(map[string]float64{"team": 5, "market": 2.5})
+	if err != nil || total != 83.33 {
+		t.Fatalf("unexpected total %v (%v)", total, err)
+	}
+	if _, err := r.Score(map[string]float64{"team": 6, "market": 1}); err == nil {
+		t.Fatalf("expected out-of-range score to be rejected")
+	}
+	if _, err := r.Score(map[string]float64{"team": 1}); err == nil {
+		t.Fatalf("expected missing criterion to be rejected")
+	}
+	if _, err := ParseRubric([]byte(`{"criteria": [{"key": "a"}, {"key": "a"}]}`)); err == nil {
+		t.Fatalf("expected duplicate keys to be rejected")
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	stages, err := NormalizeStages([]string{"screening", "pitch interview"})
+	if err != nil || stages[1] != "PITCH_INTERVIEW" {
+		t.Fatalf("unexpected stages %v (%v)", stages, err)
+	}
+	if _, err := NormalizeStages([]string{"a", "A"}); err == nil {
+		t.Fatalf("expected duplicate stages to be rejected")
+	}
+	if next, err := NextStage(stages, "SCREENING"); err != nil || next != "PITCH_INTERVIEW" {
+		t.Fatalf("unexpected next stage %q (%v)", next, err)
+	}
+	if _, err := NextStage(stages, "PITCH_INTERVIEW"); err != ErrLastStage {
+		t.Fatalf("expected final stage error, got %v", err)
+	}
+	if CheckDecision(db.ApplicationAccepted, db.ApplicationRejected) != ErrDecisionFinal {
+		t.Fatalf("expected decided application to be final")
+	}
+
+	summary := Summarize(stages, []db.ApplicationReview{
+		{Stage: "SCREENING", Total: 80, Recommendation: "ADVANCE"},
+		{Stage: "SCREENING", Total: 60, Recommendation: "HOLD"},
+	})
+	if summary[0].Reviews != 2 || summary[0].AverageScore != 70 || summary[1].Reviews != 0 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+}
@@ -0,0 +1,106 @@
+package applications
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// DefaultStages is used when a form does not define its own pipeline
+var DefaultStages = []string{"REVIEW"}
+
+var (
+	ErrInvalidStages     = errors.New("stages must be unique, non-empty names")
+	ErrDecisionFinal     = errors.New("application has already been decided")
+	ErrLastStage         = errors.New("application is already at the final stage")
+	ErrInvalidDecision   = errors.New("decision must be ACCEPTED or REJECTED")
+	recommendationValues = map[string]bool{"ADVANCE": true, "HOLD": true, "REJECT": true}
+)
+
+// NormalizeStages upper-cases and validates a pipeline definition
+func NormalizeStages(stages []string) ([]string, error) {
+	if len(stages) == 0 {
+		return append([]string{}, DefaultStages...), nil
+	}
+	out := make([]string, 0, len(stages))
+	seen := map[string]bool{}
+	for _, s := range stages {
+		s = strings.ToUpper(strings.Join(strings.Fields(s), "_"))
+		if s == "" || seen[s] {
+			return nil, ErrInvalidStages
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// NextStage returns the stage after current
+func NextStage(stages []string, current string) (string, error) {
+	for i, s := range stages {
+		if s == current {
+			if i == len(stages)-1 {
+				return "", ErrLastStage
+			}
+			return stages[i+1], nil
+		}
+	}
+	// an unknown stage (e.g. after the pipeline was edited) restarts at the first
+	return stages[0], nil
+}
+
+// Open reports whether an application can still be reviewed or decided
+func Open(status db.ApplicationStatus) bool {
+	return status == db.ApplicationSubmitted || status == db.ApplicationInReview
+}
+
+// CheckDecision validates a final decision on an application
+func CheckDecision(status db.ApplicationStatus, decision db.ApplicationStatus) error {
+	if decision != db.ApplicationAccepted && decision != db.ApplicationRejected {
+		return ErrInvalidDecision
+	}
+	if !Open(status) {
+		return ErrDecisionFinal
+	}
+	return nil
+}
+
+// ValidRecommendation reports whether a reviewer recommendation is known;
+// the recommendation is optional
+func ValidRecommendation(r string) bool {
+	return r == "" || recommendationValues[r]
+}
+
+// StageSummary aggregates reviews for one stage
+type StageSummary struct {
+	Stage           string         `json:"stage"`
+	Reviews         int            `json:"reviews"`
+	AverageScore    float64        `json:"averageScore"`
+	Recommendations map[string]int `json:"recommendations"`
+}
+
+// Summarize aggregates reviews per stage in pipeline order
+func Summarize(stages []string, reviews []db.ApplicationReview) []StageSummary {
+	out := make([]StageSummary, 0, len(stages))
+	for _, stage := range stages {
+		sum := StageSummary{Stage: stage, Recommendations: map[string]int{}}
+		var total float64
+		for _, r := range reviews {
+			if r.Stage != stage {
+				continue
+			}
+			sum.Reviews++
+			total += r.Total
+			if r.Recommendation != "" {
+				sum.Recommendations[r.Recommendation]++
+			}
+		}
+		if sum.Reviews > 0 {
+			sum.AverageScore = math.Round(total/float64(sum.Reviews)*100) / 100
+		}
+		out = append(out, sum)
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package applications
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Criterion is one rubric line; scores run from 0 to Max and are weighted
+type Criterion struct {
+	Key    string  `json:"key"`
+	Label  string  `json:"label"`
+	Weight float64 `json:"weight"`
+	Max    float64 `json:"max"`
+}
+
+// Rubric is the scoring guide reviewers fill in
+type Rubric struct {
+	Criteria []Criterion `json:"criteria"`
+}
+
+var ErrInvalidRubric = errors.New("invalid rubric")
+
+// ParseRubric decodes a rubric; an empty value is an empty rubric. Weights
+// default to 1 and maximum scores to 5.
+func ParseRubric(raw []byte) (*Rubric, error) {
+	r := &Rubric{}
+	if len(raw) == 0 || string(raw) == "null" {
+		return r, nil
+	}
+	if err := json.Unmarshal(raw, r); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRubric, err)
+	}
+	seen := map[string]bool{}
+	for i := range r.Criteria {
+		c := &r.Criteria[i]
+		if c.Key == "" || seen[c.Key] {
+			return nil, fmt.Errorf("%w: criteria need unique keys", ErrInvalidRubric)
+		}
+		seen[c.Key] = true
+		if c.Weight == 0 {
+			c.Weight = 1
+		}
+		if c.Max == 0 {
+			c.Max = 5
+		}
+		if c.Weight < 0 || c.Max < 0 {
+			return nil, fmt.Errorf("%w: weight and max must be positive (%q)", ErrInvalidRubric, c.Key)
+		}
+	}
+	return r, nil
+}
+
+// Score checks that every criterion is scored within range and returns the
+// weighted total out of 100, rounded to two decimals
+func (r *Rubric) Score(scores map[string]float64) (float64, error) {
+	for key := range scores {
+		if !r.has(key) {
+			return 0, fmt.Errorf("unknown criterion %q", key)
+		}
+	}
+	if len(r.Criteria) == 0 {
+		return 0, nil
+	}
+
+	var weighted, weights float64
+	for _, c := range r.Criteria {
+		s, ok := scores[c.Key]
+		if !ok {
+			return 0, fmt.Errorf("criterion %q must be scored", c.Key)
+		}
+		if s < 0 || s > c.Max {
+			return 0, fmt.Errorf("score for %q must be between 0 and %g", c.Key, c.Max)
+		}
+		weighted += c.Weight * s / c.Max
+		weights += c.Weight
+	}
+	return math.Round(weighted/weights*100*100) / 100, nil
+}
+
+func (r *Rubric) has(key string) bool {
+	for _, c := range r.Criteria {
+		if c.Key == key {
+			return true
+		}
+	}
+	return false
+}
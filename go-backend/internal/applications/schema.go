@@ -0,0 +1,224 @@
+package applications
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// Schema is the subset of JSON Schema used to describe application forms:
+// an object of typed questions with optional constraints
+type Schema struct {
+	Type        string               `json:"type"`
+	Title       string               `json:"title,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Properties  map[string]*Property `json:"properties"`
+	Required    []string             `json:"required,omitempty"`
+}
+
+// Property is a single question
+type Property struct {
+	Type        string    `json:"type"` // string, number, integer, boolean or array
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Enum        []string  `json:"enum,omitempty"`
+	Format      string    `json:"format,omitempty"` // email, uri or date for strings
+	MinLength   *int      `json:"minLength,omitempty"`
+	MaxLength   *int      `json:"maxLength,omitempty"`
+	Minimum     *float64  `json:"minimum,omitempty"`
+	Maximum     *float64  `json:"maximum,omitempty"`
+	Items       *Property `json:"items,omitempty"` // Element type for arrays
+	MinItems    *int      `json:"minItems,omitempty"`
+	MaxItems    *int      `json:"maxItems,omitempty"`
+}
+
+// FieldError describes why an answer was rejected
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+var ErrInvalidSchema = errors.New("invalid form schema")
+
+// ParseSchema decodes a form schema and checks it only uses supported features
+func ParseSchema(raw []byte) (*Schema, error) {
+	var s Schema
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	if s.Type != "object" {
+		return nil, fmt.Errorf("%w: top-level type must be object", ErrInvalidSchema)
+	}
+	if len(s.Properties) == 0 {
+		return nil, fmt.Errorf("%w: at least one question is required", ErrInvalidSchema)
+	}
+	for name, p := range s.Properties {
+		if err := p.check(name, false); err != nil {
+			return nil, err
+		}
+	}
+	for _, name := range s.Required {
+		if _, ok := s.Properties[name]; !ok {
+			return nil, fmt.Errorf("%w: required question %q is not defined", ErrInvalidSchema, name)
+		}
+	}
+	return &s, nil
+}
+
+func (p *Property) check(name string, item bool) error {
+	if p == nil {
+		return fmt.Errorf("%w: question %q is empty", ErrInvalidSchema, name)
+	}
+	switch p.Type {
+	case "string", "number", "integer", "boolean":
+	case "array":
+		if item {
+			return fmt.Errorf("%w: nested arrays are not supported (%q)", ErrInvalidSchema, name)
+		}
+		if p.Items == nil {
+			return fmt.Errorf("%w: array question %q needs items", ErrInvalidSchema, name)
+		}
+		return p.Items.check(name, true)
+	default:
+		return fmt.Errorf("%w: question %q has unsupported type %q", ErrInvalidSchema, name, p.Type)
+	}
+	switch p.Format {
+	case "", "email", "uri", "date":
+	default:
+		return fmt.Errorf("%w: question %q has unsupported format %q", ErrInvalidSchema, name, p.Format)
+	}
+	return nil
+}
+
+// Validate checks answers against the schema. Unknown questions are
+// rejected. It returns the decoded answers when there are no errors.
+func (s *Schema) Validate(raw []byte) (map[string]interface{}, []FieldError) {
+	var answers map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&answers); err != nil || answers == nil {
+		return nil, []FieldError{{Field: "", Message: "answers must be a JSON object"}}
+	}
+
+	var errs []FieldError
+	for _, name := range s.Required {
+		if v, ok := answers[name]; !ok || v == nil || v == "" {
+			errs = append(errs, FieldError{Field: name, Message: "is required"})
+		}
+	}
+	for name, v := range answers {
+		p, ok := s.Properties[name]
+		if !ok {
+			errs = append(errs, FieldError{Field: name, Message: "is not a question on this form"})
+			continue
+		}
+		if v == nil {
+			continue
+		}
+		if msg := p.validate(v); msg != "" {
+			errs = append(errs, FieldError{Field: name, Message: msg})
+		}
+	}
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+		return nil, errs
+	}
+	return answers, nil
+}
+
+func (p *Property) validate(v interface{}) string {
+	switch p.Type {
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return "must be text"
+		}
+		n := utf8.RuneCountInString(str)
+		if p.MinLength != nil && n < *p.MinLength {
+			return fmt.Sprintf("must be at least %d characters", *p.MinLength)
+		}
+		if p.MaxLength != nil && n > *p.MaxLength {
+			return fmt.Sprintf("must be at most %d characters", *p.MaxLength)
+		}
+		if len(p.Enum) > 0 && !contains(p.Enum, str) {
+			return "is not one of the allowed options"
+		}
+		return checkFormat(p.Format, str)
+	case "number", "integer":
+		num, ok := v.(json.Number)
+		if !ok {
+			return "must be a number"
+		}
+		f, err := num.Float64()
+		if err != nil {
+			return "must be a number"
+		}
+		if p.Type == "integer" {
+			if _, err := num.Int64(); err != nil {
+				return "must be a whole number"
+			}
+		}
+		if p.Minimum != nil && f < *p.Minimum {
+			return fmt.Sprintf("must be at least %g", *p.Minimum)
+		}
+		if p.Maximum != nil && f > *p.Maximum {
+			return fmt.Sprintf("must be at most %g", *p.Maximum)
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return "must be true or false"
+		}
+	case "array":
+		items, ok := v.([]interface{})
+		if !ok {
+			return "must be a list"
+		}
+		if p.MinItems != nil && len(items) < *p.MinItems {
+			return fmt.Sprintf("must have at least %d items", *p.MinItems)
+		}
+		if p.MaxItems != nil && len(items) > *p.MaxItems {
+			return fmt.Sprintf("must have at most %d items", *p.MaxItems)
+		}
+		for i, item := range items {
+			if msg := p.Items.validate(item); msg != "" {
+				return fmt.Sprintf("item %d %s", i+1, msg)
+			}
+		}
+	}
+	return ""
+}
+
+func checkFormat(format, s string) string {
+	switch format {
+	case "email":
+		if _, err := mail.ParseAddress(s); err != nil {
+			return "must be an email address"
+		}
+	case "uri":
+		if u, err := url.Parse(s); err != nil || u.Scheme == "" || u.Host == "" {
+			return "must be a URL"
+		}
+	case "date":
+		if _, err := time.Parse("2006-01-02", s); err != nil {
+			return "must be a date (YYYY-MM-DD)"
+		}
+	}
+	return ""
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package booking
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/hours"
+)
+
+const (
+	// MinDuration and MaxDuration bound a single reservation
+	MinDuration = 15 * time.Minute
+	MaxDuration = 12 * time.Hour
+	// MaxAdvance limits how far ahead a slot can be reserved
+	MaxAdvance = 180 * 24 * time.Hour
+)
+
+var (
+	ErrSlotOrder       = errors.New("booking must end after it starts")
+	ErrSlotInPast      = errors.New("booking cannot start in the past")
+	ErrSlotTooShort    = fmt.Errorf("booking must last at least %s", MinDuration)
+	ErrSlotTooLong     = fmt.Errorf("booking cannot last more than %s", MaxDuration)
+	ErrSlotTooFar      = errors.New("booking is too far in the future")
+	ErrOutsideHours    = errors.New("booking falls outside the center's opening hours")
+	ErrOverCapacity    = errors.New("attendees exceed the resource capacity")
+	ErrTransitionStale = errors.New("booking can no longer be changed")
+)
+
+// ValidateSlot checks a requested time slot. The schedule may be nil; when it
+// has weekly hours the slot must fall within a single opening window.
+func ValidateSlot(start, end, now time.Time, schedule *hours.Schedule) error {
+	switch {
+	case !end.After(start):
+		return ErrSlotOrder
+	case start.Before(now):
+		return ErrSlotInPast
+	case end.Sub(start) < MinDuration:
+		return ErrSlotTooShort
+	case end.Sub(start) > MaxDuration:
+		return ErrSlotTooLong
+	case start.Sub(now) > MaxAdvance:
+		return ErrSlotTooFar
+	}
+	if schedule != nil && schedule.HasWeeklyHours() && !schedule.Covers(start, end) {
+		return ErrOutsideHours
+	}
+	return nil
+}
+
+// ValidateAttendees checks a head count against the resource capacity
+func ValidateAttendees(resource *db.BookableResource, attendees *int) error {
+	if attendees == nil || resource.Capacity == nil {
+		return nil
+	}
+	if *attendees > *resource.Capacity {
+		return ErrOverCapacity
+	}
+	return nil
+}
+
+// InitialStatus is the status a new booking of the resource starts in
+func InitialStatus(resource *db.BookableResource) db.BookingStatus {
+	if resource.RequiresApproval {
+		return db.BookingPending
+	}
+	return db.BookingConfirmed
+}
+
+// transitions lists the allowed status changes
+var transitions = map[db.BookingStatus][]db.BookingStatus{
+	db.BookingPending:   {db.BookingConfirmed, db.BookingRejected, db.BookingCancelled},
+	db.BookingConfirmed: {db.BookingCancelled},
+}
+
+// CheckTransition reports whether a booking may move between two statuses
+func CheckTransition(from, to db.BookingStatus) error {
+	for _, allowed := range transitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return ErrTransitionStale
+}
@@ -0,0 +1,63 @@
+package booking
+
+import (
+	"testing"
+	"time"
+
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/hours"
+)
+
+func TestValidateSlot(t *testing.T) {
+	now := time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC) // Monday
+	at := func(hour, minute int) time.Time { return time.Date(2024, 6, 3, hour, minute, 0, 0, time.UTC) }
+
+	cases := []struct {
+		name       string
+		start, end time.Time
+		want       error
+	}{
+		{"valid", at(10, 0), at(11, 0), nil},
+		{"inverted", at(11, 0), at(10, 0), ErrSlotOrder},
+		{"past", at(7, 0), at(9, 0), ErrSlotInPast},
+		{"too short", at(10, 0), at(10, 5), ErrSlotTooShort},
+		{"too long", at(9, 0), at(9, 0).Add(13 * time.Hour), ErrSlotTooLong},
+		{"too far", now.AddDate(1, 0, 0), now.AddDate(1, 0, 0).Add(time.Hour), ErrSlotTooFar},
+	}
+	for _, tc := range cases {
+		if err := ValidateSlot(tc.start, tc.end, now, nil); err != tc.want {
+			t.Fatalf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	schedule, _ := hours.NewSchedule("UTC")
+	iv, _ := hours.NewInterval("09:00", "17:00")
+	schedule.Add(time.Monday, iv)
+	if err := ValidateSlot(at(16, 0), at(18, 0), now, schedule); err != ErrOutsideHours {
+		t.Fatalf("expected slot past closing to be rejected, got %v", err)
+	}
+	if err := ValidateSlot(at(9, 0), at(17, 0), now, schedule); err != nil {
+		t.Fatalf("expected slot within hours to be accepted, got %v", err)
+	}
+}
+
+func TestTransitionsAndCapacity(t *testing.T) {
+	if err := CheckTransition(db.BookingPending, db.BookingConfirmed); err != nil {
+		t.Fatalf("expected approval to be allowed: %v", err)
+	}
+	if err := CheckTransition(db.BookingCancelled, db.BookingConfirmed); err == nil {
+		t.Fatalf("expected cancelled booking to be final")
+	}
+	if err := CheckTransition(db.BookingConfirmed, db.BookingRejected); err == nil {
+		t.Fatalf("expected confirmed booking not to be rejectable")
+	}
+
+	capacity, attendees := 4, 6
+	resource := &db.BookableResource{Capacity: &capacity, RequiresApproval: true}
+	if ValidateAttendees(resource, &attendees) != ErrOverCapacity {
+		t.Fatalf("expected capacity check to fail")
+	}
+	if InitialStatus(resource) != db.BookingPending {
+		t.Fatalf("expected approval-required resources to start pending")
+	}
+}
@@ -0,0 +1,114 @@
+package cohort
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// Phase describes where a cohort is in its lifecycle
+type Phase string
+
+const (
+	PhasePlanned      Phase = "PLANNED"
+	PhaseApplications Phase = "APPLICATIONS_OPEN"
+	PhaseInProgress   Phase = "IN_PROGRESS"
+	PhaseEnded        Phase = "ENDED"     // Past the end date but not closed yet
+	PhaseCompleted    Phase = "COMPLETED" // Closed by the hub
+)
+
+var (
+	ErrApplicationsClosed = errors.New("applications for this cohort are closed")
+	ErrCohortFull         = errors.New("this cohort is full")
+	ErrCohortCompleted    = errors.New("this cohort has already been completed")
+)
+
+// CurrentPhase works out the cohort's phase at now
+func CurrentPhase(c *db.Cohort, now time.Time) Phase {
+	switch {
+	case c.CompletedAt != nil:
+		return PhaseCompleted
+	case ApplicationsOpen(c, now):
+		return PhaseApplications
+	case now.Before(c.StartDate):
+		return PhasePlanned
+	case now.Before(c.EndDate.AddDate(0, 0, 1)): // the end date is inclusive
+		return PhaseInProgress
+	default:
+		return PhaseEnded
+	}
+}
+
+// ApplicationsOpen reports whether entrepreneurs may apply at now. Without
+// an explicit window, applications are open until the cohort starts.
+func ApplicationsOpen(c *db.Cohort, now time.Time) bool {
+	if c.CompletedAt != nil {
+		return false
+	}
+	if c.ApplicationOpensAt != nil && now.Before(*c.ApplicationOpensAt) {
+		return false
+	}
+	if c.ApplicationClosesAt != nil {
+		return now.Before(*c.ApplicationClosesAt)
+	}
+	return now.Before(c.StartDate)
+}
+
+// CheckAdmission decides whether one more enrollment can join the cohort.
+// Hub managers may enroll outside the application window but never beyond
+// capacity.
+func CheckAdmission(c *db.Cohort, enrolled int64, now time.Time, manager bool) error {
+	if c.CompletedAt != nil {
+		return ErrCohortCompleted
+	}
+	if !manager && !ApplicationsOpen(c, now) {
+		return ErrApplicationsClosed
+	}
+	if c.Capacity != nil && enrolled >= int64(*c.Capacity) {
+		return ErrCohortFull
+	}
+	return nil
+}
+
+// Progress summarizes an enrollment's milestone completion
+type Progress struct {
+	Completed         int     `json:"completed"`
+	Total             int     `json:"total"`
+	RequiredCompleted int     `json:"requiredCompleted"`
+	Required          int     `json:"required"`
+	Percent           float64 `json:"percent"`
+	Done              bool    `json:"done"` // Every required milestone is complete
+}
+
+// Compute works out progress from the cohort milestones and the IDs of the
+// milestones the enrollment has completed. A cohort without required
+// milestones is never done by milestones alone; it completes when closed.
+func Compute(milestones []db.CohortMilestone, completed map[uuid.UUID]bool) Progress {
+	var p Progress
+	for _, m := range milestones {
+		p.Total++
+		if m.Required {
+			p.Required++
+		}
+		if completed[m.ID] {
+			p.Completed++
+			if m.Required {
+				p.RequiredCompleted++
+			}
+		}
+	}
+	if p.Total > 0 {
+		p.Percent = float64(p.Completed) * 100 / float64(p.Total)
+	}
+	p.Done = p.Required > 0 && p.RequiredCompleted == p.Required
+	return p
+}
+
+// CompletesOnClose reports whether an enrollment is completed when its
+// cohort is closed: it must be active and have every required milestone
+func CompletesOnClose(e *db.HubEnrollment, p Progress) bool {
+	return e.Status == db.EnrollmentActive && (p.Done || p.Required == 0)
+}
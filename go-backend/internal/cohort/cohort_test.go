@@ -0,0 +1,80 @@
+package cohort
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func TestPhaseAndAdmission(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 0, 0, 0, 0, time.UTC) }
+	opens, closes := day(1), day(10)
+	capacity := 2
+	c := &db.Cohort{
+		StartDate:           day(15),
+		EndDate:             day(20),
+		Capacity:            &capacity,
+		ApplicationOpensAt:  &opens,
+		ApplicationClosesAt: &closes,
+	}
+
+	phases := map[time.Time]Phase{
+		day(1).Add(-time.Hour): PhasePlanned,
+		day(5):                 PhaseApplications,
+		day(12):                PhasePlanned,
+		day(20).Add(time.Hour): PhaseInProgress,
+		day(21).Add(time.Hour): PhaseEnded,
+	}
+	for at, want := range phases {
+		if got := CurrentPhase(c, at); got != want {
+			t.Fatalf("phase at %s = %s, want %s", at, got, want)
+		}
+	}
+
+	if err := CheckAdmission(c, 0, day(12), false); err != ErrApplicationsClosed {
+		t.Fatalf("expected closed window to block applicants, got %v", err)
+	}
+	if err := CheckAdmission(c, 0, day(12), true); err != nil {
+		t.Fatalf("expected managers to bypass the window, got %v", err)
+	}
+	if err := CheckAdmission(c, 2, day(5), true); err != ErrCohortFull {
+		t.Fatalf("expected capacity to be enforced, got %v", err)
+	}
+
+	now := day(22)
+	c.CompletedAt = &now
+	if CurrentPhase(c, now) != PhaseCompleted || CheckAdmission(c, 0, now, true) != ErrCohortCompleted {
+		t.Fatalf("expected completed cohort to be closed")
+	}
+}
+
+func TestComputeProgress(t *testing.T) {
+	a := db.CohortMilestone{ID: uuid.New(), Required: true}
+	b := db.CohortMilestone{ID: uuid.New(), Required: true}
+	optional := db.CohortMilestone{ID: uuid.New()}
+	milestones := []db.CohortMilestone{a, b, optional}
+
+	p := Compute(milestones, map[uuid.UUID]bool{a.ID: true, optional.ID: true})
+	if p.Done || p.Completed != 2 || p.Required != 2 || p.RequiredCompleted != 1 {
+		t.Fatalf("unexpected progress: %+v", p)
+	}
+
+	p = Compute(milestones, map[uuid.UUID]bool{a.ID: true, b.ID: true})
+	if !p.Done {
+		t.Fatalf("expected required milestones to complete the enrollment: %+v", p)
+	}
+
+	if Compute(nil, nil).Done {
+		t.Fatalf("a cohort without milestones should not complete by milestones")
+	}
+	active := &db.HubEnrollment{Status: db.EnrollmentActive}
+	if !CompletesOnClose(active, Compute(nil, nil)) {
+		t.Fatalf("expected active enrollment to complete when a milestone-free cohort closes")
+	}
+	if CompletesOnClose(&db.HubEnrollment{Status: db.EnrollmentPending}, p) {
+		t.Fatalf("pending enrollments never complete")
+	}
+}
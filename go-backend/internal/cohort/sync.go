@@ -0,0 +1,101 @@
+package cohort
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/lifecycle"
+)
+
+// Reasons recorded in the status history for automatic completion
+var (
+	reasonMilestones   = "all required milestones completed"
+	reasonCohortClosed = "cohort closed"
+)
+
+// ProgressFor computes milestone progress for each of the cohort's enrollments
+func ProgressFor(gdb *gorm.DB, c *db.Cohort, enrollments []db.HubEnrollment) (map[uuid.UUID]Progress, error) {
+	ids := make([]uuid.UUID, len(enrollments))
+	for i, e := range enrollments {
+		ids[i] = e.ID
+	}
+	completions, err := db.ListMilestoneCompletions(gdb, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make(map[uuid.UUID]map[uuid.UUID]bool, len(enrollments))
+	for _, comp := range completions {
+		if done[comp.EnrollmentID] == nil {
+			done[comp.EnrollmentID] = map[uuid.UUID]bool{}
+		}
+		done[comp.EnrollmentID][comp.MilestoneID] = true
+	}
+
+	progress := make(map[uuid.UUID]Progress, len(enrollments))
+	for _, e := range enrollments {
+		progress[e.ID] = Compute(c.Milestones, done[e.ID])
+	}
+	return progress, nil
+}
+
+// SyncEnrollment recomputes an enrollment's progress and marks an active
+// enrollment COMPLETED once every required milestone is done. Completion is
+// not reverted if a milestone is later unmarked. The actor is recorded in the
+// enrollment's status history.
+func SyncEnrollment(gdb *gorm.DB, c *db.Cohort, e *db.HubEnrollment, actor *uuid.UUID) (Progress, bool, error) {
+	progress, err := ProgressFor(gdb, c, []db.HubEnrollment{*e})
+	if err != nil {
+		return Progress{}, false, err
+	}
+	p := progress[e.ID]
+	if !p.Done || e.Status != db.EnrollmentActive {
+		return p, false, nil
+	}
+	if err := lifecycle.TransitionEnrollment(gdb, e, db.EnrollmentCompleted, actor, &reasonMilestones); err != nil {
+		return p, false, err
+	}
+	return p, true, nil
+}
+
+// Close marks the cohort completed and completes every active enrollment
+// that met its required milestones. It returns the completed enrollment IDs
+// and the active ones left incomplete.
+func Close(gdb *gorm.DB, c *db.Cohort, actor *uuid.UUID) (completed, incomplete []uuid.UUID, err error) {
+	completed, incomplete = []uuid.UUID{}, []uuid.UUID{}
+	err = gdb.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(c).Update("completed_at", now).Error; err != nil {
+			return err
+		}
+		c.CompletedAt = &now
+
+		enrollments, err := db.ListCohortEnrollments(tx, c.ID)
+		if err != nil {
+			return err
+		}
+		progress, err := ProgressFor(tx, c, enrollments)
+		if err != nil {
+			return err
+		}
+		for i := range enrollments {
+			e := &enrollments[i]
+			if e.Status != db.EnrollmentActive {
+				continue
+			}
+			if !CompletesOnClose(e, progress[e.ID]) {
+				incomplete = append(incomplete, e.ID)
+				continue
+			}
+			if err := lifecycle.TransitionEnrollment(tx, e, db.EnrollmentCompleted, actor, &reasonCohortClosed); err != nil {
+				return err
+			}
+			completed = append(completed, e.ID)
+		}
+		return nil
+	})
+	return completed, incomplete, err
+}
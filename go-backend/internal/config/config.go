@@ -14,6 +14,17 @@ type Config struct {
     JWTExpiresIn    time.Duration
     RealtimeProv    string
     GoogleClientID  string
+
+    // File storage: "local" keeps uploads under StorageDir, "s3" uses an
+    // S3-compatible bucket
+    StorageDriver   string
+    StorageDir      string
+    S3Endpoint      string
+    S3Region        string
+    S3Bucket        string
+    S3AccessKeyID   string
+    S3SecretKey     string
+    S3PathStyle     bool
 }
 
 func Load() Config {
@@ -24,6 +35,14 @@ func Load() Config {
         JWTSecret:      os.Getenv("JWT_SECRET"),
         RealtimeProv:   getenv("REALTIME_PROVIDER", "socketio"),
         GoogleClientID: os.Getenv("GOOGLE_CLIENT_ID"),
+        StorageDriver:  getenv("STORAGE_DRIVER", "local"),
+        StorageDir:     getenv("STORAGE_DIR", "uploads"),
+        S3Endpoint:     os.Getenv("S3_ENDPOINT"),
+        S3Region:       getenv("S3_REGION", "us-east-1"),
+        S3Bucket:       os.Getenv("S3_BUCKET"),
+        S3AccessKeyID:  os.Getenv("S3_ACCESS_KEY_ID"),
+        S3SecretKey:    os.Getenv("S3_SECRET_ACCESS_KEY"),
+        S3PathStyle:    os.Getenv("S3_PATH_STYLE") == "true",
     }
     // duration with default 168h
     if d := getenv("JWT_EXPIRES_IN", "168h"); d != "" {
@@ -4,6 +4,7 @@ import (
     "github.com/gin-gonic/gin"
     "gorm.io/gorm"
     "communitycentresplatform/go-backend/internal/events"
+    "communitycentresplatform/go-backend/internal/storage"
 )
 
 const (
@@ -11,6 +12,7 @@ const (
     KeyJWTSecret      = "jwtSecret"
     KeyJWTExpiry      = "jwtExpiry"
     KeyBroker         = "sseBroker"
+    KeyStorage        = "storage"
     KeyGoogleClientID = "googleClientID"
 
     KeyUserID = "userId"
@@ -55,6 +57,15 @@ func BrokerFrom(c *gin.Context) *events.Broker {
     return nil
 }
 
+func StorageFrom(c *gin.Context) storage.Blob {
+    if v, ok := c.Get(KeyStorage); ok {
+        if b, ok2 := v.(storage.Blob); ok2 {
+            return b
+        }
+    }
+    return nil
+}
+
 func UserIDFrom(c *gin.Context) string {
     if v, ok := c.Get(KeyUserID); ok {
         if s, ok2 := v.(string); ok2 {
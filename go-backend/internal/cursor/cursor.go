@@ -0,0 +1,48 @@
+// Package cursor encodes keyset pagination positions as opaque strings.
+// A position is the (created_at, id) pair of the last row a client saw;
+// the id breaks ties between rows created in the same instant.
+package cursor
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalid = errors.New("invalid cursor")
+
+// Position is a row's place in (created_at, id) order
+type Position struct {
+	At time.Time
+	ID uuid.UUID
+}
+
+// Encode turns a position into a URL-safe cursor
+func Encode(p Position) string {
+	raw := p.At.UTC().Format(time.RFC3339Nano) + "|" + p.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// Decode parses a cursor made by Encode
+func Decode(s string) (Position, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Position{}, ErrInvalid
+	}
+	at, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return Position{}, ErrInvalid
+	}
+	t, err := time.Parse(time.RFC3339Nano, at)
+	if err != nil {
+		return Position{}, ErrInvalid
+	}
+	u, err := uuid.Parse(id)
+	if err != nil {
+		return Position{}, ErrInvalid
+	}
+	return Position{At: t, ID: u}, nil
+}
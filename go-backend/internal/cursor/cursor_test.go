@@ -0,0 +1,27 @@
+package cursor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRoundTrip(t *testing.T) {
+	p := Position{At: time.Date(2026, 3, 1, 9, 30, 0, 123456789, time.FixedZone("EAT", 3*3600)), ID: uuid.New()}
+	got, err := Decode(Encode(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.At.Equal(p.At) || got.ID != p.ID {
+		t.Fatalf("got %+v, want %+v", got, p)
+	}
+}
+
+func TestDecodeRejectsGarbage(t *testing.T) {
+	for _, s := range []string{"", "not base64!", Encode(Position{})[:10], "MjAyNi0wMy0wMQ"} {
+		if _, err := Decode(s); err != ErrInvalid {
+			t.Errorf("Decode(%q): expected ErrInvalid, got %v", s, err)
+		}
+	}
+}
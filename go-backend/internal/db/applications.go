@@ -0,0 +1,203 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrApplicationChanged is returned when an application's status or stage
+// changed since it was read, e.g. it was decided or withdrawn meanwhile
+var ErrApplicationChanged = errors.New("application has changed; reload and try again")
+
+// ApplicationFilters narrows a form's applications
+type ApplicationFilters struct {
+	Status ApplicationStatus
+	Stage  string
+}
+
+// ListApplicationForms retrieves a hub's application forms, newest first
+func ListApplicationForms(db *gorm.DB, hubID uuid.UUID, includeInactive bool) ([]ApplicationForm, error) {
+	var forms []ApplicationForm
+	query := db.Where("hub_id = ?", hubID)
+	if !includeInactive {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("created_at DESC").Find(&forms).Error
+	return forms, err
+}
+
+// FindApplicationFormByID retrieves a form with its hub
+func FindApplicationFormByID(db *gorm.DB, id uuid.UUID) (*ApplicationForm, error) {
+	var form ApplicationForm
+	if err := db.Preload("Hub").Where("id = ?", id).First(&form).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &form, nil
+}
+
+// FindOpenApplicationForm returns the hub's most recent form that is
+// accepting applications, if any
+func FindOpenApplicationForm(db *gorm.DB, hubID uuid.UUID, now time.Time) (*ApplicationForm, error) {
+	var form ApplicationForm
+	err := db.Where("hub_id = ? AND active = ? AND (closes_at IS NULL OR closes_at > ?)", hubID, true, now).
+		Order("created_at DESC").
+		First(&form).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &form, nil
+}
+
+// SaveApplicationForm creates or updates a form
+func SaveApplicationForm(db *gorm.DB, form *ApplicationForm) error {
+	return db.Omit(clause.Associations).Save(form).Error
+}
+
+// CreateApplication stores a submitted application
+func CreateApplication(db *gorm.DB, app *Application) error {
+	return db.Omit(clause.Associations).Create(app).Error
+}
+
+// FindApplicationByID retrieves an application with its form, applicant and reviews
+func FindApplicationByID(db *gorm.DB, id uuid.UUID) (*Application, error) {
+	var app Application
+	err := db.Preload("Form.Hub").Preload("Entrepreneur.User").
+		Preload("Reviews", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("created_at ASC")
+		}).
+		Preload("Reviews.Reviewer").
+		Where("id = ?", id).First(&app).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &app, nil
+}
+
+// FindActiveApplication returns an entrepreneur's undecided application to a form
+func FindActiveApplication(db *gorm.DB, formID, entrepreneurID uuid.UUID) (*Application, error) {
+	var app Application
+	err := db.Where("form_id = ? AND entrepreneur_id = ? AND status IN ?",
+		formID, entrepreneurID, []ApplicationStatus{ApplicationSubmitted, ApplicationInReview}).
+		First(&app).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &app, nil
+}
+
+// ListFormApplications retrieves a form's applications with applicants and reviews
+func ListFormApplications(db *gorm.DB, formID uuid.UUID, filters ApplicationFilters) ([]Application, error) {
+	var apps []Application
+	query := db.Preload("Entrepreneur.User").Preload("Reviews").Where("form_id = ?", formID)
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if filters.Stage != "" {
+		query = query.Where("stage = ?", filters.Stage)
+	}
+	err := query.Order("created_at ASC").Find(&apps).Error
+	return apps, err
+}
+
+// ListEntrepreneurApplications retrieves an entrepreneur's applications, newest first
+func ListEntrepreneurApplications(db *gorm.DB, entrepreneurID uuid.UUID) ([]Application, error) {
+	var apps []Application
+	err := db.Preload("Form.Hub").
+		Where("entrepreneur_id = ?", entrepreneurID).
+		Order("created_at DESC").
+		Find(&apps).Error
+	return apps, err
+}
+
+// UpdateApplication saves an application's pipeline fields, provided it still
+// has the status and stage it was read with; it returns ErrApplicationChanged
+// otherwise
+func UpdateApplication(db *gorm.DB, app *Application, status ApplicationStatus, stage string) error {
+	result := db.Model(&Application{}).Where("id = ? AND status = ? AND stage = ?", app.ID, status, stage).
+		Updates(map[string]interface{}{
+			"status":         app.Status,
+			"stage":          app.Stage,
+			"decided_by":     app.DecidedBy,
+			"decided_at":     app.DecidedAt,
+			"decision_notes": app.DecisionNotes,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrApplicationChanged
+	}
+	return nil
+}
+
+// SaveApplicationReview records a reviewer's scores for a stage, replacing
+// any earlier review by the same reviewer at that stage
+func SaveApplicationReview(db *gorm.DB, review *ApplicationReview) error {
+	return db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "application_id"}, {Name: "reviewer_id"}, {Name: "stage"}},
+		DoUpdates: clause.AssignmentColumns([]string{"scores", "total", "recommendation", "comment", "updated_at"}),
+	}).Create(review).Error
+}
+
+// AcceptApplication marks an application accepted and enrolls the applicant
+// as ACTIVE in the hub (and the form's cohort, if any) in one transaction.
+// Like UpdateApplication it returns ErrApplicationChanged if the application
+// was decided or withdrawn since it was read.
+func AcceptApplication(db *gorm.DB, app *Application, cohortID *uuid.UUID, decidedBy uuid.UUID, notes *string) (*HubEnrollment, error) {
+	now := time.Now()
+	status, stage := app.Status, app.Stage
+	enrollment := HubEnrollment{
+		HubID:          app.HubID,
+		EntrepreneurID: app.EntrepreneurID,
+		CohortID:       cohortID,
+		Status:         EnrollmentActive,
+		EnrollmentDate: &now,
+	}
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Claim the decision first, so a concurrent one cannot enroll too
+		app.Status = ApplicationAccepted
+		app.DecidedBy = &decidedBy
+		app.DecidedAt = &now
+		app.DecisionNotes = notes
+		if err := UpdateApplication(tx, app, status, stage); err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&enrollment).Error; err != nil {
+			return err
+		}
+		reason := "application accepted"
+		err := RecordStatusTransition(tx, &StatusTransition{
+			EntityType: StatusEntityEnrollment,
+			EntityID:   enrollment.ID,
+			ToStatus:   string(EnrollmentActive),
+			ActorID:    &decidedBy,
+			Reason:     &reason,
+		})
+		if err != nil {
+			return err
+		}
+		app.EnrollmentID = &enrollment.ID
+		return tx.Model(&Application{}).Where("id = ?", app.ID).Update("enrollment_id", enrollment.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrBookingConflict is returned when a booking overlaps an active booking of
+// the same resource
+var ErrBookingConflict = errors.New("booking overlaps an existing booking")
+
+// ErrBookingStatusChanged is returned when a booking's status changed since it
+// was read, e.g. another manager decided it first
+var ErrBookingStatusChanged = errors.New("booking status has changed; reload and try again")
+
+// exclusion_violation, raised by the resource_bookings_no_overlap constraint
+const sqlStateExclusionViolation = "23P01"
+
+// ensureBookingConstraints adds the exclusion constraint that stops two
+// PENDING or CONFIRMED bookings of one resource from overlapping. GORM cannot
+// express it in struct tags, so it is applied after AutoMigrate.
+func ensureBookingConstraints(db *gorm.DB) error {
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS btree_gist`).Error; err != nil {
+		return err
+	}
+	return db.Exec(`DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'resource_bookings_no_overlap') THEN
+		ALTER TABLE resource_bookings ADD CONSTRAINT resource_bookings_no_overlap
+			EXCLUDE USING gist (resource_id WITH =, tstzrange(starts_at, ends_at) WITH &&)
+			WHERE (status IN ('PENDING', 'CONFIRMED'));
+	END IF;
+END $$`).Error
+}
+
+// isExclusionViolation reports whether err came from an exclusion constraint
+func isExclusionViolation(err error) bool {
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && pgErr.SQLState() == sqlStateExclusionViolation
+}
+
+// BookingFilters narrows a booking listing; zero values are ignored
+type BookingFilters struct {
+	ResourceID *uuid.UUID
+	CenterID   *uuid.UUID
+	UserID     *uuid.UUID
+	Statuses   []BookingStatus
+	From       *time.Time // Bookings ending after this instant
+	To         *time.Time // Bookings starting before this instant
+}
+
+// ListBookableResources retrieves a center's resources, optionally including inactive ones
+func ListBookableResources(db *gorm.DB, centerID uuid.UUID, includeInactive bool) ([]BookableResource, error) {
+	var resources []BookableResource
+	query := db.Where("center_id = ?", centerID)
+	if !includeInactive {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("name ASC").Find(&resources).Error
+	return resources, err
+}
+
+// FindBookableResourceByID retrieves a resource by ID
+func FindBookableResourceByID(db *gorm.DB, id uuid.UUID) (*BookableResource, error) {
+	var resource BookableResource
+	if err := db.Where("id = ?", id).First(&resource).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// CreateBookableResource creates a new bookable resource
+func CreateBookableResource(db *gorm.DB, resource *BookableResource) error {
+	return db.Create(resource).Error
+}
+
+// UpdateBookableResource saves changes to a bookable resource
+func UpdateBookableResource(db *gorm.DB, resource *BookableResource) error {
+	return db.Save(resource).Error
+}
+
+// CreateBooking inserts a booking, returning ErrBookingConflict when it
+// overlaps an active booking of the same resource
+func CreateBooking(db *gorm.DB, booking *Booking) error {
+	if err := db.Create(booking).Error; err != nil {
+		if isExclusionViolation(err) {
+			return ErrBookingConflict
+		}
+		return err
+	}
+	return nil
+}
+
+// FindBookingByID retrieves a booking with its resource
+func FindBookingByID(db *gorm.DB, id uuid.UUID) (*Booking, error) {
+	var booking Booking
+	if err := db.Preload("Resource").Where("id = ?", id).First(&booking).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &booking, nil
+}
+
+// ListBookings retrieves bookings matching the filters, earliest first
+func ListBookings(db *gorm.DB, filters BookingFilters) ([]Booking, error) {
+	query := db.Model(&Booking{}).Preload("Resource")
+	if filters.ResourceID != nil {
+		query = query.Where("resource_id = ?", *filters.ResourceID)
+	}
+	if filters.CenterID != nil {
+		query = query.Where("center_id = ?", *filters.CenterID)
+	}
+	if filters.UserID != nil {
+		query = query.Where("user_id = ?", *filters.UserID)
+	}
+	if len(filters.Statuses) > 0 {
+		query = query.Where("status IN ?", filters.Statuses)
+	}
+	if filters.From != nil {
+		query = query.Where("ends_at > ?", *filters.From)
+	}
+	if filters.To != nil {
+		query = query.Where("starts_at < ?", *filters.To)
+	}
+
+	var bookings []Booking
+	err := query.Order("starts_at ASC").Find(&bookings).Error
+	return bookings, err
+}
+
+// UpdateBookingStatus moves a booking to a new status, recording who made
+// the change and why. It only applies if the booking is still in the status
+// it was read with, returning ErrBookingStatusChanged otherwise.
+func UpdateBookingStatus(db *gorm.DB, booking *Booking, status BookingStatus, reviewer *uuid.UUID, notes *string) error {
+	result := db.Model(&Booking{}).Where("id = ? AND status = ?", booking.ID, booking.Status).Updates(map[string]interface{}{
+		"status":      status,
+		"reviewed_by": reviewer,
+		"notes":       notes,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBookingStatusChanged
+	}
+	booking.Status = status
+	booking.ReviewedBy = reviewer
+	booking.Notes = notes
+	return nil
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListCenterImages retrieves the images of the given centers in display
+// order. A nil roles list returns every role.
+func ListCenterImages(db *gorm.DB, centerIDs []uuid.UUID, roles []CenterImageRole) ([]CenterImage, error) {
+	var images []CenterImage
+	if len(centerIDs) == 0 {
+		return images, nil
+	}
+	query := db.Where("center_id IN ?", centerIDs)
+	if roles != nil {
+		query = query.Where("role IN ?", roles)
+	}
+	err := query.Order("position ASC, created_at ASC").Find(&images).Error
+	return images, err
+}
+
+// FindCenterImage retrieves one of a center's images
+func FindCenterImage(db *gorm.DB, centerID, imageID uuid.UUID) (*CenterImage, error) {
+	var image CenterImage
+	err := db.Where("id = ? AND center_id = ?", imageID, centerID).First(&image).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &image, nil
+}
+
+// CountCenterImages counts a center's images in one role
+func CountCenterImages(db *gorm.DB, centerID uuid.UUID, role CenterImageRole) (int64, error) {
+	var count int64
+	err := db.Model(&CenterImage{}).Where("center_id = ? AND role = ?", centerID, role).Count(&count).Error
+	return count, err
+}
+
+// CreateCenterImage records an image. Covers and logos replace the center's
+// current one, which is returned so the caller can delete its stored files.
+func CreateCenterImage(db *gorm.DB, image *CenterImage) ([]CenterImage, error) {
+	var replaced []CenterImage
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if image.Role != CenterImageGallery {
+			err := tx.Where("center_id = ? AND role = ?", image.CenterID, image.Role).Find(&replaced).Error
+			if err != nil {
+				return err
+			}
+			if err := tx.Where("center_id = ? AND role = ?", image.CenterID, image.Role).Delete(&CenterImage{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Create(image).Error
+	})
+	return replaced, err
+}
+
+// SaveCenterImage updates an image's caption or position
+func SaveCenterImage(db *gorm.DB, image *CenterImage) error {
+	return db.Omit(clause.Associations).Save(image).Error
+}
+
+// ReorderCenterGallery sets gallery positions to follow ids
+func ReorderCenterGallery(db *gorm.DB, centerID uuid.UUID, ids []uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			err := tx.Model(&CenterImage{}).
+				Where("id = ? AND center_id = ? AND role = ?", id, centerID, CenterImageGallery).
+				Update("position", i).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteCenterImage removes an image record; the caller deletes the stored
+// files
+func DeleteCenterImage(db *gorm.DB, id uuid.UUID) error {
+	return db.Where("id = ?", id).Delete(&CenterImage{}).Error
+}
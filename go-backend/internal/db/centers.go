@@ -3,6 +3,7 @@ package db
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -11,19 +12,38 @@ import (
 
 // CenterFilters defines filtering options for listing centers
 type CenterFilters struct {
-	SearchQuery        string   // Search in name, location, description
-	Services           []string // Filter by services (has-some logic)
-	Locations          []string // Filter by locations
-	VerificationStatus string   // "verified", "unverified", or "all"
-	AddedByUserID      string   // Filter by who added the center
-	Limit              int      // Pagination limit
-	Offset             int      // Pagination offset
+	SearchQuery        string     // Search in name, location, description
+	Services           []string   // Filter by services (has-some logic)
+	Locations          []string   // Filter by locations
+	VerificationStatus string     // "verified", "unverified", or "all"
+	AddedByUserID      string     // Filter by who added the center
+	OpenAt             *time.Time // Only centers open at this instant (in their own time zone)
+	Limit              int        // Pagination limit
+	Offset             int        // Pagination offset
 }
 
 // ListCenters retrieves centers with optional filters
 func ListCenters(db *gorm.DB, filters CenterFilters) ([]CommunityCenter, error) {
-	query := db.Model(&CommunityCenter{})
+	query := applyCenterFilters(db.Model(&CommunityCenter{}), filters)
 
+	// Pagination
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	// Order by creation date (newest first)
+	query = query.Order("created_at DESC")
+
+	var centers []CommunityCenter
+	err := query.Find(&centers).Error
+	return centers, err
+}
+
+// applyCenterFilters adds the WHERE clauses shared by listing, counting and facets
+func applyCenterFilters(query *gorm.DB, filters CenterFilters) *gorm.DB {
 	// Search query (case-insensitive in name, location, description)
 	if filters.SearchQuery != "" {
 		search := "%" + strings.ToLower(filters.SearchQuery) + "%"
@@ -49,7 +69,7 @@ func ListCenters(db *gorm.DB, filters CenterFilters) ([]CommunityCenter, error)
 		query = query.Where("verified = ?", true)
 	case "unverified":
 		query = query.Where("verified = ?", false)
-	// "all" or empty means no filter
+		// "all" or empty means no filter
 	}
 
 	// Filter by who added the center
@@ -57,20 +77,30 @@ func ListCenters(db *gorm.DB, filters CenterFilters) ([]CommunityCenter, error)
 		query = query.Where("added_by = ?", filters.AddedByUserID)
 	}
 
-	// Pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
+	// Open at a given instant: a date exception replaces the weekly schedule.
+	// Times are stored as zero-padded "HH:MM" so string comparison is safe.
+	if filters.OpenAt != nil {
+		query = query.Where(`CASE WHEN EXISTS (
+				SELECT 1 FROM center_hours_exceptions ex
+				WHERE ex.center_id = community_centers.id
+				AND ex.date = (@at::timestamptz AT TIME ZONE community_centers.time_zone)::date
+			) THEN EXISTS (
+				SELECT 1 FROM center_hours_exceptions ex
+				WHERE ex.center_id = community_centers.id
+				AND ex.date = (@at::timestamptz AT TIME ZONE community_centers.time_zone)::date
+				AND NOT ex.closed
+				AND ex.opens_at <= to_char(@at::timestamptz AT TIME ZONE community_centers.time_zone, 'HH24:MI')
+				AND ex.closes_at > to_char(@at::timestamptz AT TIME ZONE community_centers.time_zone, 'HH24:MI')
+			) ELSE EXISTS (
+				SELECT 1 FROM center_opening_hours oh
+				WHERE oh.center_id = community_centers.id
+				AND oh.weekday = EXTRACT(DOW FROM @at::timestamptz AT TIME ZONE community_centers.time_zone)::int
+				AND oh.opens_at <= to_char(@at::timestamptz AT TIME ZONE community_centers.time_zone, 'HH24:MI')
+				AND oh.closes_at > to_char(@at::timestamptz AT TIME ZONE community_centers.time_zone, 'HH24:MI')
+			) END`, map[string]interface{}{"at": filters.OpenAt.UTC()})
 	}
 
-	// Order by creation date (newest first)
-	query = query.Order("created_at DESC")
-
-	var centers []CommunityCenter
-	err := query.Find(&centers).Error
-	return centers, err
+	return query
 }
 
 // CreateCenter creates a new community center
@@ -110,39 +140,82 @@ func VerifyCenter(db *gorm.DB, centerID uuid.UUID, verified bool) error {
 	return db.Model(&CommunityCenter{}).Where("id = ?", centerID).Update("verified", verified).Error
 }
 
+// ListManagedCenterIDs returns the IDs of the centers a user manages
+func ListManagedCenterIDs(db *gorm.DB, userID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := db.Model(&CommunityCenter{}).Where("manager_id = ?", userID).Pluck("id", &ids).Error
+	return ids, err
+}
+
 // CountCenters returns total count with filters (for pagination metadata)
 func CountCenters(db *gorm.DB, filters CenterFilters) (int64, error) {
-	query := db.Model(&CommunityCenter{})
-
 	// Apply same filters as ListCenters (without limit/offset)
-	if filters.SearchQuery != "" {
-		search := "%" + strings.ToLower(filters.SearchQuery) + "%"
-		query = query.Where(
-			"LOWER(name) LIKE ? OR LOWER(location) LIKE ? OR LOWER(description) LIKE ?",
-			search, search, search,
-		)
-	}
+	query := applyCenterFilters(db.Model(&CommunityCenter{}), filters)
 
-	if len(filters.Services) > 0 {
-		query = query.Where("services @> ?", pq.Array(filters.Services))
-	}
+	var count int64
+	err := query.Count(&count).Error
+	return count, err
+}
 
-	if len(filters.Locations) > 0 {
-		query = query.Where("location IN ?", filters.Locations)
+// FacetCount is the number of centers sharing one facet value
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// CenterFacets holds aggregations for the center filter panel
+type CenterFacets struct {
+	Services   []FacetCount `json:"services"`
+	Locations  []FacetCount `json:"locations"`
+	Verified   int64        `json:"verified"`
+	Unverified int64        `json:"unverified"`
+	Total      int64        `json:"total"`
+}
+
+// CountCenterFacets aggregates service, location and verification counts
+// over the centers matching the filters (limit/offset are ignored)
+func CountCenterFacets(db *gorm.DB, filters CenterFilters) (*CenterFacets, error) {
+	facets := &CenterFacets{Services: []FacetCount{}, Locations: []FacetCount{}}
+
+	// Service counts: one row per array element
+	err := applyCenterFilters(db.Model(&CommunityCenter{}), filters).
+		Joins("CROSS JOIN LATERAL unnest(community_centers.services) AS facet(value)").
+		Select("facet.value AS value, COUNT(DISTINCT community_centers.id) AS count").
+		Group("facet.value").
+		Order("count DESC, value ASC").
+		Scan(&facets.Services).Error
+	if err != nil {
+		return nil, err
 	}
 
-	switch strings.ToLower(filters.VerificationStatus) {
-	case "verified":
-		query = query.Where("verified = ?", true)
-	case "unverified":
-		query = query.Where("verified = ?", false)
+	err = applyCenterFilters(db.Model(&CommunityCenter{}), filters).
+		Select("location AS value, COUNT(*) AS count").
+		Group("location").
+		Order("count DESC, value ASC").
+		Scan(&facets.Locations).Error
+	if err != nil {
+		return nil, err
 	}
 
-	if filters.AddedByUserID != "" {
-		query = query.Where("added_by = ?", filters.AddedByUserID)
+	var verification []struct {
+		Verified bool
+		Count    int64
 	}
+	err = applyCenterFilters(db.Model(&CommunityCenter{}), filters).
+		Select("verified, COUNT(*) AS count").
+		Group("verified").
+		Scan(&verification).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range verification {
+		if v.Verified {
+			facets.Verified = v.Count
+		} else {
+			facets.Unverified = v.Count
+		}
+	}
+	facets.Total = facets.Verified + facets.Unverified
 
-	var count int64
-	err := query.Count(&count).Error
-	return count, err
+	return facets, nil
 }
@@ -92,3 +92,13 @@ func GetConnectedCenters(db *gorm.DB, centerID uuid.UUID) ([]CommunityCenter, er
 	err = db.Where("id IN ?", connectedIDs).Find(&centers).Error
 	return centers, err
 }
+
+// ListActiveConnections retrieves every active connection in the network
+func ListActiveConnections(db *gorm.DB) ([]Connection, error) {
+	var connections []Connection
+	err := db.Where("active = ?", true).
+		Order("created_at ASC").
+		Find(&connections).Error
+
+	return connections, err
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DirectoryFilters narrows the public entrepreneur directory; zero values are
+// ignored
+type DirectoryFilters struct {
+	SearchQuery  string // Business name and description
+	BusinessType string
+	HubID        *uuid.UUID   // Entrepreneurs with an active or completed enrollment at this hub
+	Visibilities []Visibility // Profile visibilities the viewer may see
+	Limit        int
+	Offset       int
+}
+
+// ListDirectoryEntrepreneurs retrieves verified entrepreneurs visible to the
+// viewer, with their account and enrollments, ordered by business name
+func ListDirectoryEntrepreneurs(db *gorm.DB, filters DirectoryFilters) ([]Entrepreneur, error) {
+	var entrepreneurs []Entrepreneur
+	query := applyDirectoryFilters(db, db.Model(&Entrepreneur{}), filters).
+		Preload("User").Preload("Enrollments").Preload("Enrollments.Hub")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+	err := query.Order("business_name ASC").Find(&entrepreneurs).Error
+	return entrepreneurs, err
+}
+
+// CountDirectoryEntrepreneurs returns the number of directory entries
+// matching the filters
+func CountDirectoryEntrepreneurs(db *gorm.DB, filters DirectoryFilters) (int64, error) {
+	var count int64
+	err := applyDirectoryFilters(db, db.Model(&Entrepreneur{}), filters).Count(&count).Error
+	return count, err
+}
+
+func applyDirectoryFilters(db, query *gorm.DB, filters DirectoryFilters) *gorm.DB {
+	query = query.Where("verified = ? AND profile_visibility IN ?", true, filters.Visibilities)
+	if q := strings.TrimSpace(filters.SearchQuery); q != "" {
+		like := "%" + q + "%"
+		query = query.Where("(business_name ILIKE ? OR description ILIKE ?)", like, like)
+	}
+	if t := strings.TrimSpace(filters.BusinessType); t != "" {
+		query = query.Where("LOWER(business_type) = LOWER(?)", t)
+	}
+	if filters.HubID != nil {
+		enrolled := db.Model(&HubEnrollment{}).Select("entrepreneur_id").
+			Where("hub_id = ? AND status IN ?", *filters.HubID, []EnrollmentStatus{EnrollmentActive, EnrollmentCompleted})
+		query = query.Where("id IN (?)", enrolled)
+	}
+	return query
+}
@@ -0,0 +1,30 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CountSharedEntrepreneurs returns, for every other hub, how many of this
+// hub's entrepreneurs are also enrolled there
+func CountSharedEntrepreneurs(db *gorm.DB, hubID uuid.UUID) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		HubID uuid.UUID
+		Count int
+	}
+	err := db.Model(&HubEnrollment{}).
+		Select("hub_id, COUNT(DISTINCT entrepreneur_id) AS count").
+		Where("hub_id <> ?", hubID).
+		Where("entrepreneur_id IN (?)", db.Model(&HubEnrollment{}).Select("entrepreneur_id").Where("hub_id = ?", hubID)).
+		Group("hub_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, r := range rows {
+		counts[r.HubID] = r.Count
+	}
+	return counts, nil
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListOpeningHours retrieves a center's weekly opening intervals
+func ListOpeningHours(db *gorm.DB, centerID uuid.UUID) ([]OpeningHours, error) {
+	var hours []OpeningHours
+	err := db.Where("center_id = ?", centerID).
+		Order("weekday ASC, opens_at ASC").
+		Find(&hours).Error
+	return hours, err
+}
+
+// ReplaceOpeningHours swaps a center's weekly schedule for a new one
+func ReplaceOpeningHours(db *gorm.DB, centerID uuid.UUID, hours []OpeningHours) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("center_id = ?", centerID).Delete(&OpeningHours{}).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		for i := range hours {
+			hours[i].CenterID = centerID
+		}
+		return tx.Create(&hours).Error
+	})
+}
+
+// ListHoursExceptions retrieves a center's exceptions on or after a date
+func ListHoursExceptions(db *gorm.DB, centerID uuid.UUID, from time.Time) ([]HoursException, error) {
+	var exceptions []HoursException
+	err := db.Where("center_id = ? AND date >= ?", centerID, from.Format("2006-01-02")).
+		Order("date ASC").
+		Find(&exceptions).Error
+	return exceptions, err
+}
+
+// SaveHoursException creates or replaces the exception for a center and date
+func SaveHoursException(db *gorm.DB, exception *HoursException) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var existing HoursException
+		err := tx.Where("center_id = ? AND date = ?", exception.CenterID, exception.Date.Format("2006-01-02")).
+			First(&existing).Error
+		if err == nil {
+			exception.ID = existing.ID
+			exception.CreatedAt = existing.CreatedAt
+			return tx.Save(exception).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		return tx.Create(exception).Error
+	})
+}
+
+// DeleteHoursException removes one of a center's exceptions
+func DeleteHoursException(db *gorm.DB, centerID, exceptionID uuid.UUID) (bool, error) {
+	res := db.Where("id = ? AND center_id = ?", exceptionID, centerID).Delete(&HoursException{})
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,274 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// HubEventFilters narrows an event listing; zero values are ignored
+type HubEventFilters struct {
+	HubID            *uuid.UUID
+	Kind             HubEventKind
+	From             *time.Time // Events ending after this instant
+	To               *time.Time // Events starting before this instant
+	IncludeCancelled bool
+}
+
+// RSVPCounts summarizes the RSVPs of one event
+type RSVPCounts struct {
+	Going      int64 `json:"going"`
+	Waitlisted int64 `json:"waitlisted"`
+	CheckedIn  int64 `json:"checkedIn"`
+}
+
+// ListHubEvents retrieves events matching the filters, soonest first
+func ListHubEvents(db *gorm.DB, filters HubEventFilters, limit, offset int) ([]HubEvent, int64, error) {
+	query := db.Model(&HubEvent{})
+	if filters.HubID != nil {
+		query = query.Where("hub_id = ?", *filters.HubID)
+	}
+	if filters.Kind != "" {
+		query = query.Where("kind = ?", filters.Kind)
+	}
+	if filters.From != nil {
+		query = query.Where("ends_at > ?", *filters.From)
+	}
+	if filters.To != nil {
+		query = query.Where("starts_at < ?", *filters.To)
+	}
+	if !filters.IncludeCancelled {
+		query = query.Where("cancelled = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var events []HubEvent
+	query = query.Preload("Hub").Order("starts_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit).Offset(offset)
+	}
+	if err := query.Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// FindHubEventByID retrieves an event with its hub
+func FindHubEventByID(db *gorm.DB, id uuid.UUID) (*HubEvent, error) {
+	var event HubEvent
+	if err := db.Preload("Hub").Where("id = ?", id).First(&event).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CreateHubEvent creates an event, first posting the announcement activity
+// to the hub feed when one is given
+func CreateHubEvent(db *gorm.DB, event *HubEvent, announcement *HubActivity) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if announcement != nil {
+			if err := tx.Create(announcement).Error; err != nil {
+				return err
+			}
+			event.ActivityID = &announcement.ID
+		}
+		return tx.Create(event).Error
+	})
+}
+
+// UpdateHubEvent saves an event and fills any places freed by a larger
+// capacity from the waitlist. It returns the promoted RSVPs.
+func UpdateHubEvent(db *gorm.DB, event *HubEvent) ([]EventRSVP, error) {
+	var promoted []EventRSVP
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(event).Error; err != nil {
+			return err
+		}
+		var err error
+		promoted, err = promoteWaitlist(tx, event)
+		return err
+	})
+	return promoted, err
+}
+
+// CountEventRSVPs returns RSVP counts keyed by event ID
+func CountEventRSVPs(db *gorm.DB, eventIDs []uuid.UUID) (map[uuid.UUID]RSVPCounts, error) {
+	counts := make(map[uuid.UUID]RSVPCounts, len(eventIDs))
+	if len(eventIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		EventID    uuid.UUID
+		Going      int64
+		Waitlisted int64
+		CheckedIn  int64
+	}
+	err := db.Model(&EventRSVP{}).
+		Select(`event_id,
+			COUNT(*) FILTER (WHERE status = ?) AS going,
+			COUNT(*) FILTER (WHERE status = ?) AS waitlisted,
+			COUNT(*) FILTER (WHERE checked_in_at IS NOT NULL) AS checked_in`, RSVPGoing, RSVPWaitlisted).
+		Where("event_id IN ?", eventIDs).
+		Group("event_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		counts[r.EventID] = RSVPCounts{Going: r.Going, Waitlisted: r.Waitlisted, CheckedIn: r.CheckedIn}
+	}
+	return counts, nil
+}
+
+// FindEventRSVP retrieves a user's RSVP for an event
+func FindEventRSVP(db *gorm.DB, eventID, userID uuid.UUID) (*EventRSVP, error) {
+	var rsvp EventRSVP
+	if err := db.Where("event_id = ? AND user_id = ?", eventID, userID).First(&rsvp).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rsvp, nil
+}
+
+// ListEventRSVPs retrieves an event's active RSVPs with their users,
+// attendees first and then the waitlist in order
+func ListEventRSVPs(db *gorm.DB, eventID uuid.UUID) ([]EventRSVP, error) {
+	var rsvps []EventRSVP
+	err := db.Preload("User").
+		Where("event_id = ? AND status <> ?", eventID, RSVPCancelled).
+		Order("status ASC, waitlist_at ASC NULLS FIRST, created_at ASC").
+		Find(&rsvps).Error
+	return rsvps, err
+}
+
+// RSVPToEvent gives the user a place at the event, or a waitlist spot when it
+// is full. The event row is locked so concurrent RSVPs cannot overfill it.
+func RSVPToEvent(db *gorm.DB, eventID, userID uuid.UUID) (*EventRSVP, error) {
+	var rsvp EventRSVP
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var event HubEvent
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", eventID).First(&event).Error; err != nil {
+			return err
+		}
+
+		err := tx.Where("event_id = ? AND user_id = ?", eventID, userID).First(&rsvp).Error
+		if err == nil && rsvp.Status != RSVPCancelled {
+			return nil // already going or waitlisted
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		going, err := countGoing(tx, eventID)
+		if err != nil {
+			return err
+		}
+
+		rsvp.EventID = eventID
+		rsvp.UserID = userID
+		rsvp.CheckedInAt = nil
+		if event.Capacity == nil || going < int64(*event.Capacity) {
+			rsvp.Status = RSVPGoing
+			rsvp.WaitlistAt = nil
+		} else {
+			now := time.Now()
+			rsvp.Status = RSVPWaitlisted
+			rsvp.WaitlistAt = &now
+		}
+		return tx.Omit(clause.Associations).Save(&rsvp).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rsvp, nil
+}
+
+// CancelRSVP withdraws the user's RSVP and promotes the waitlist into any
+// freed place. It returns the cancelled RSVP (nil if there was none) and the
+// promoted RSVPs.
+func CancelRSVP(db *gorm.DB, eventID, userID uuid.UUID) (*EventRSVP, []EventRSVP, error) {
+	var rsvp *EventRSVP
+	var promoted []EventRSVP
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var event HubEvent
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", eventID).First(&event).Error; err != nil {
+			return err
+		}
+
+		var existing EventRSVP
+		err := tx.Where("event_id = ? AND user_id = ? AND status <> ?", eventID, userID, RSVPCancelled).First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		existing.Status = RSVPCancelled
+		existing.WaitlistAt = nil
+		if err := tx.Model(&existing).Updates(map[string]interface{}{"status": RSVPCancelled, "waitlist_at": nil}).Error; err != nil {
+			return err
+		}
+		rsvp = &existing
+
+		promoted, err = promoteWaitlist(tx, &event)
+		return err
+	})
+	return rsvp, promoted, err
+}
+
+// CheckInRSVP marks an attendee as present
+func CheckInRSVP(db *gorm.DB, rsvp *EventRSVP) error {
+	now := time.Now()
+	rsvp.CheckedInAt = &now
+	return db.Model(rsvp).Update("checked_in_at", now).Error
+}
+
+// promoteWaitlist moves waitlisted RSVPs to GOING, earliest first, until the
+// event is full
+func promoteWaitlist(tx *gorm.DB, event *HubEvent) ([]EventRSVP, error) {
+	var candidates []EventRSVP
+	query := tx.Where("event_id = ? AND status = ?", event.ID, RSVPWaitlisted).Order("waitlist_at ASC")
+	if event.Capacity != nil {
+		going, err := countGoing(tx, event.ID)
+		if err != nil {
+			return nil, err
+		}
+		free := int64(*event.Capacity) - going
+		if free <= 0 {
+			return nil, nil
+		}
+		query = query.Limit(int(free))
+	}
+	if err := query.Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range candidates {
+		candidates[i].Status = RSVPGoing
+		candidates[i].WaitlistAt = nil
+		if err := tx.Model(&candidates[i]).Updates(map[string]interface{}{"status": RSVPGoing, "waitlist_at": nil}).Error; err != nil {
+			return nil, err
+		}
+	}
+	return candidates, nil
+}
+
+func countGoing(tx *gorm.DB, eventID uuid.UUID) (int64, error) {
+	var going int64
+	err := tx.Model(&EventRSVP{}).Where("event_id = ? AND status = ?", eventID, RSVPGoing).Count(&going).Error
+	return going, err
+}
@@ -0,0 +1,175 @@
+package db
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InvestorFilters narrows the investor directory; zero values are ignored
+type InvestorFilters struct {
+	SearchQuery     string // Name and description
+	Type            InvestorType
+	FocusSector     string
+	TicketSize      *float64 // Investors whose ticket range covers this amount
+	IncludeInactive bool
+	Limit           int
+	Offset          int
+}
+
+// DealFilters narrows a deal listing; zero values are ignored
+type DealFilters struct {
+	InvestorID     *uuid.UUID
+	EntrepreneurID *uuid.UUID
+	HubIDs         []uuid.UUID // Introducing hubs; nil means any
+	Stage          DealStage
+}
+
+// ListInvestors retrieves directory entries ordered by name
+func ListInvestors(db *gorm.DB, filters InvestorFilters) ([]Investor, error) {
+	var investors []Investor
+	query := applyInvestorFilters(db.Model(&Investor{}), filters)
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+	err := query.Order("name ASC").Find(&investors).Error
+	return investors, err
+}
+
+// CountInvestors returns the number of directory entries matching the filters
+func CountInvestors(db *gorm.DB, filters InvestorFilters) (int64, error) {
+	var count int64
+	err := applyInvestorFilters(db.Model(&Investor{}), filters).Count(&count).Error
+	return count, err
+}
+
+func applyInvestorFilters(query *gorm.DB, filters InvestorFilters) *gorm.DB {
+	if !filters.IncludeInactive {
+		query = query.Where("active = ?", true)
+	}
+	if q := strings.TrimSpace(filters.SearchQuery); q != "" {
+		like := "%" + q + "%"
+		query = query.Where("(name ILIKE ? OR description ILIKE ?)", like, like)
+	}
+	if filters.Type != "" {
+		query = query.Where("type = ?", filters.Type)
+	}
+	if filters.FocusSector != "" {
+		query = query.Where("LOWER(focus_sector) = LOWER(?)", filters.FocusSector)
+	}
+	if filters.TicketSize != nil {
+		query = query.Where("(ticket_min IS NULL OR ticket_min <= ?) AND (ticket_max IS NULL OR ticket_max >= ?)",
+			*filters.TicketSize, *filters.TicketSize)
+	}
+	return query
+}
+
+// FindInvestorByID retrieves a directory entry by ID
+func FindInvestorByID(db *gorm.DB, id uuid.UUID) (*Investor, error) {
+	var investor Investor
+	if err := db.Where("id = ?", id).First(&investor).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &investor, nil
+}
+
+// FindInvestorBySlug retrieves a directory entry by its normalized name
+func FindInvestorBySlug(db *gorm.DB, slug string) (*Investor, error) {
+	var investor Investor
+	if err := db.Where("slug = ?", slug).First(&investor).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &investor, nil
+}
+
+// SaveInvestor creates or updates a directory entry
+func SaveInvestor(db *gorm.DB, investor *Investor) error {
+	return db.Save(investor).Error
+}
+
+// CountInvestorDeals counts an investor's deals per stage
+func CountInvestorDeals(db *gorm.DB, investorID uuid.UUID) (map[DealStage]int64, error) {
+	var rows []struct {
+		Stage DealStage
+		Count int64
+	}
+	err := db.Model(&Deal{}).
+		Select("stage, COUNT(*) AS count").
+		Where("investor_id = ?", investorID).
+		Group("stage").
+		Scan(&rows).Error
+	counts := make(map[DealStage]int64, len(rows))
+	for _, r := range rows {
+		counts[r.Stage] = r.Count
+	}
+	return counts, err
+}
+
+// CreateDeal stores a new introduction
+func CreateDeal(db *gorm.DB, deal *Deal) error {
+	return db.Omit(clause.Associations).Create(deal).Error
+}
+
+// FindDealByID retrieves a deal with its investor, entrepreneur and hub
+func FindDealByID(db *gorm.DB, id uuid.UUID) (*Deal, error) {
+	var deal Deal
+	err := db.Preload("Investor").Preload("Entrepreneur").Preload("Hub").Where("id = ?", id).First(&deal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &deal, nil
+}
+
+// FindOpenDeal returns an investor and entrepreneur's deal that is neither
+// funded nor passed, if any
+func FindOpenDeal(db *gorm.DB, investorID, entrepreneurID uuid.UUID) (*Deal, error) {
+	var deal Deal
+	err := db.Where("investor_id = ? AND entrepreneur_id = ? AND stage NOT IN ?",
+		investorID, entrepreneurID, []DealStage{DealFunded, DealPassed}).
+		First(&deal).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &deal, nil
+}
+
+// ListDeals retrieves deals with their parties, most recently updated first
+func ListDeals(db *gorm.DB, filters DealFilters) ([]Deal, error) {
+	var deals []Deal
+	query := db.Preload("Investor").Preload("Entrepreneur").Preload("Hub")
+	if filters.InvestorID != nil {
+		query = query.Where("investor_id = ?", *filters.InvestorID)
+	}
+	if filters.EntrepreneurID != nil {
+		query = query.Where("entrepreneur_id = ?", *filters.EntrepreneurID)
+	}
+	if filters.HubIDs != nil {
+		if len(filters.HubIDs) == 0 {
+			return deals, nil
+		}
+		query = query.Where("hub_id IN ?", filters.HubIDs)
+	}
+	if filters.Stage != "" {
+		query = query.Where("stage = ?", filters.Stage)
+	}
+	err := query.Order("updated_at DESC").Find(&deals).Error
+	return deals, err
+}
@@ -0,0 +1,285 @@
+package db
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MentorFilters narrows the mentor marketplace; zero values are ignored
+type MentorFilters struct {
+	SearchQuery     string      // Headline and bio
+	Expertise       StringArray // Mentors with any of these tags
+	HubID           *uuid.UUID
+	AcceptingOnly   bool
+	IncludeInactive bool
+	Limit           int
+	Offset          int
+}
+
+// MentorshipScope limits request and session listings to the mentors and
+// entrepreneurs a caller may see; All lifts the limit for admins
+type MentorshipScope struct {
+	All             bool
+	MentorIDs       []uuid.UUID
+	EntrepreneurIDs []uuid.UUID
+}
+
+// SessionFilters narrows a session listing; zero values are ignored
+type SessionFilters struct {
+	RequestID *uuid.UUID
+	Status    MentoringSessionStatus
+	From      *time.Time // Scheduled on or after
+	To        *time.Time // Scheduled before
+}
+
+// ListMentors retrieves marketplace profiles with their user, hub and
+// availability, newest first
+func ListMentors(db *gorm.DB, filters MentorFilters) ([]Mentor, error) {
+	var mentors []Mentor
+	query := applyMentorFilters(db.Model(&Mentor{}), filters).
+		Preload("User").Preload("Hub").Preload("Availability")
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+	err := query.Order("created_at DESC").Find(&mentors).Error
+	return mentors, err
+}
+
+// CountMentors returns the number of profiles matching the filters
+func CountMentors(db *gorm.DB, filters MentorFilters) (int64, error) {
+	var count int64
+	err := applyMentorFilters(db.Model(&Mentor{}), filters).Count(&count).Error
+	return count, err
+}
+
+func applyMentorFilters(query *gorm.DB, filters MentorFilters) *gorm.DB {
+	if !filters.IncludeInactive {
+		query = query.Where("active = ?", true)
+	}
+	if filters.AcceptingOnly {
+		query = query.Where("accepting_requests = ?", true)
+	}
+	if filters.HubID != nil {
+		query = query.Where("hub_id = ?", *filters.HubID)
+	}
+	if len(filters.Expertise) > 0 {
+		query = query.Where("expertise && ?", filters.Expertise)
+	}
+	if q := strings.TrimSpace(filters.SearchQuery); q != "" {
+		like := "%" + q + "%"
+		query = query.Where("(headline ILIKE ? OR bio ILIKE ?)", like, like)
+	}
+	return query
+}
+
+// FindMentorByID retrieves a mentor with their user, hub and availability
+func FindMentorByID(db *gorm.DB, id uuid.UUID) (*Mentor, error) {
+	var mentor Mentor
+	err := db.Preload("User").Preload("Hub").Preload("Availability").Where("id = ?", id).First(&mentor).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &mentor, nil
+}
+
+// FindMentorByUserID retrieves a user's mentor profile, if any
+func FindMentorByUserID(db *gorm.DB, userID uuid.UUID) (*Mentor, error) {
+	var mentor Mentor
+	err := db.Preload("User").Preload("Hub").Preload("Availability").Where("user_id = ?", userID).First(&mentor).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &mentor, nil
+}
+
+// ListMentorIDs returns the profiles owned by a user or hosted by any of the
+// given hubs
+func ListMentorIDs(db *gorm.DB, userID uuid.UUID, hubIDs []uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	query := db.Model(&Mentor{}).Where("user_id = ?", userID)
+	if len(hubIDs) > 0 {
+		query = query.Or("hub_id IN ?", hubIDs)
+	}
+	err := query.Pluck("id", &ids).Error
+	return ids, err
+}
+
+// SaveMentor creates or updates a mentor profile and, when availability is
+// non-nil, replaces their weekly windows
+func SaveMentor(db *gorm.DB, mentor *Mentor, availability []MentorAvailability) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(mentor).Error; err != nil {
+			return err
+		}
+		if availability == nil {
+			return nil
+		}
+		if err := tx.Where("mentor_id = ?", mentor.ID).Delete(&MentorAvailability{}).Error; err != nil {
+			return err
+		}
+		for i := range availability {
+			availability[i].MentorID = mentor.ID
+		}
+		if len(availability) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&availability).Error; err != nil {
+				return err
+			}
+		}
+		mentor.Availability = availability
+		return nil
+	})
+}
+
+// CountMentorSessions counts a mentor's sessions per status
+func CountMentorSessions(db *gorm.DB, mentorID uuid.UUID) (map[MentoringSessionStatus]int64, error) {
+	var rows []struct {
+		Status MentoringSessionStatus
+		Count  int64
+	}
+	err := db.Model(&MentoringSession{}).
+		Select("status, COUNT(*) AS count").
+		Where("mentor_id = ?", mentorID).
+		Group("status").
+		Scan(&rows).Error
+	counts := make(map[MentoringSessionStatus]int64, len(rows))
+	for _, r := range rows {
+		counts[r.Status] = r.Count
+	}
+	return counts, err
+}
+
+// CreateMentorRequest stores a new request for mentorship
+func CreateMentorRequest(db *gorm.DB, request *MentorRequest) error {
+	return db.Omit(clause.Associations).Create(request).Error
+}
+
+// FindMentorRequestByID retrieves a request with its mentor and entrepreneur
+func FindMentorRequestByID(db *gorm.DB, id uuid.UUID) (*MentorRequest, error) {
+	var request MentorRequest
+	err := db.Preload("Mentor.User").Preload("Mentor.Hub").Preload("Entrepreneur").Where("id = ?", id).First(&request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// FindOpenMentorRequest returns an entrepreneur's pending or accepted request
+// to a mentor, if any
+func FindOpenMentorRequest(db *gorm.DB, mentorID, entrepreneurID uuid.UUID) (*MentorRequest, error) {
+	var request MentorRequest
+	err := db.Where("mentor_id = ? AND entrepreneur_id = ? AND status IN ?",
+		mentorID, entrepreneurID, []MentorRequestStatus{MentorRequestPending, MentorRequestAccepted}).
+		First(&request).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ListMentorRequests retrieves requests within a scope, newest first
+func ListMentorRequests(db *gorm.DB, scope MentorshipScope, status MentorRequestStatus) ([]MentorRequest, error) {
+	var requests []MentorRequest
+	query := applyMentorshipScope(db.Preload("Mentor.User").Preload("Mentor.Hub").Preload("Entrepreneur"), scope)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Order("created_at DESC").Find(&requests).Error
+	return requests, err
+}
+
+// CreateMentoringSession stores a newly scheduled session
+func CreateMentoringSession(db *gorm.DB, session *MentoringSession) error {
+	return db.Omit(clause.Associations).Create(session).Error
+}
+
+// UpdateMentoringSession saves a rescheduled session's timing and details
+func UpdateMentoringSession(db *gorm.DB, session *MentoringSession) error {
+	return db.Model(session).Updates(map[string]interface{}{
+		"scheduled_at":     session.ScheduledAt,
+		"duration_minutes": session.DurationMinutes,
+		"location":         session.Location,
+		"agenda":           session.Agenda,
+		"notes":            session.Notes,
+	}).Error
+}
+
+// FindMentoringSessionByID retrieves a session with its request, parties and hub
+func FindMentoringSessionByID(db *gorm.DB, id uuid.UUID) (*MentoringSession, error) {
+	var session MentoringSession
+	err := db.Preload("Request").Preload("Mentor.User").Preload("Entrepreneur").Preload("Hub").
+		Where("id = ?", id).First(&session).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListMentoringSessions retrieves sessions within a scope in schedule order
+func ListMentoringSessions(db *gorm.DB, scope MentorshipScope, filters SessionFilters) ([]MentoringSession, error) {
+	var sessions []MentoringSession
+	query := applyMentorshipScope(db.Preload("Mentor.User").Preload("Entrepreneur").Preload("Hub"), scope)
+	if filters.RequestID != nil {
+		query = query.Where("request_id = ?", *filters.RequestID)
+	}
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if filters.From != nil {
+		query = query.Where("scheduled_at >= ?", *filters.From)
+	}
+	if filters.To != nil {
+		query = query.Where("scheduled_at < ?", *filters.To)
+	}
+	err := query.Order("scheduled_at ASC").Find(&sessions).Error
+	return sessions, err
+}
+
+func applyMentorshipScope(query *gorm.DB, scope MentorshipScope) *gorm.DB {
+	if scope.All {
+		return query
+	}
+	if len(scope.MentorIDs) == 0 && len(scope.EntrepreneurIDs) == 0 {
+		return query.Where("1 = 0")
+	}
+	if len(scope.MentorIDs) == 0 {
+		return query.Where("entrepreneur_id IN ?", scope.EntrepreneurIDs)
+	}
+	if len(scope.EntrepreneurIDs) == 0 {
+		return query.Where("mentor_id IN ?", scope.MentorIDs)
+	}
+	return query.Where("(mentor_id IN ? OR entrepreneur_id IN ?)", scope.MentorIDs, scope.EntrepreneurIDs)
+}
+
+// ListMentorSessionsBetween retrieves a mentor's scheduled sessions starting
+// in [from, to), for double-booking checks
+func ListMentorSessionsBetween(db *gorm.DB, mentorID uuid.UUID, from, to time.Time) ([]MentoringSession, error) {
+	var sessions []MentoringSession
+	err := db.Where("mentor_id = ? AND status = ? AND scheduled_at >= ? AND scheduled_at < ?",
+		mentorID, SessionScheduled, from, to).
+		Order("scheduled_at ASC").
+		Find(&sessions).Error
+	return sessions, err
+}
@@ -2,11 +2,15 @@ package db
 
 import (
 	"errors"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"communitycentresplatform/go-backend/internal/cursor"
 )
 
 // ListThreadsForCenter retrieves all message threads for a center
@@ -19,6 +23,7 @@ func ListThreadsForCenter(db *gorm.DB, centerID uuid.UUID) ([]MessageThread, err
 		Preload("Messages", func(db *gorm.DB) *gorm.DB {
 			return db.Order("created_at DESC").Limit(1) // Load last message for preview
 		}).
+		Preload("Messages.Sender").
 		Order("last_activity DESC").
 		Find(&threads).Error
 
@@ -53,28 +58,51 @@ func ListThreadsForUser(db *gorm.DB, userID uuid.UUID) ([]MessageThread, error)
 		Preload("Messages", func(db *gorm.DB) *gorm.DB {
 			return db.Order("created_at DESC").Limit(1)
 		}).
+		Preload("Messages.Sender").
 		Order("last_activity DESC").
 		Find(&threads).Error
 
 	return threads, err
 }
 
-// ListMessagesInThread retrieves messages in a thread with pagination
-func ListMessagesInThread(db *gorm.DB, threadID uuid.UUID, limit, offset int) ([]CenterMessage, error) {
+// MessagePage selects a page of a thread. With no cursor it is the newest
+// messages; Before pages back through history and After catches up on newer
+// messages. Cursors are (created_at, id) positions.
+type MessagePage struct {
+	Before *cursor.Position
+	After  *cursor.Position
+	Limit  int
+}
+
+// ListMessagesInThread retrieves one page of a thread's messages, newest
+// first, and whether more remain in the direction paged
+func ListMessagesInThread(db *gorm.DB, threadID uuid.UUID, page MessagePage) ([]CenterMessage, bool, error) {
 	var messages []CenterMessage
-	query := db.Where("thread_id = ?", threadID).
-		Preload("Sender").
-		Order("created_at DESC")
+	query := db.Where("thread_id = ?", threadID).Preload("Sender")
+
+	switch {
+	case page.After != nil:
+		query = query.Where("(created_at, id) > (?, ?)", page.After.At, page.After.ID).
+			Order("created_at ASC, id ASC")
+	case page.Before != nil:
+		query = query.Where("(created_at, id) < (?, ?)", page.Before.At, page.Before.ID).
+			Order("created_at DESC, id DESC")
+	default:
+		query = query.Order("created_at DESC, id DESC")
+	}
 
-	if limit > 0 {
-		query = query.Limit(limit)
+	// One extra row tells whether there is another page
+	if err := query.Limit(page.Limit + 1).Find(&messages).Error; err != nil {
+		return nil, false, err
 	}
-	if offset > 0 {
-		query = query.Offset(offset)
+	hasMore := len(messages) > page.Limit
+	if hasMore {
+		messages = messages[:page.Limit]
 	}
-
-	err := query.Find(&messages).Error
-	return messages, err
+	if page.After != nil {
+		slices.Reverse(messages)
+	}
+	return messages, hasMore, nil
 }
 
 // CreateMessageThread creates a new message thread
@@ -101,56 +129,284 @@ func CreateMessageThread(db *gorm.DB, subject string, participantIDs []uuid.UUID
 	return thread, nil
 }
 
-// CreateMessage creates a new message in a thread (with transaction to update thread metadata)
-func CreateMessage(db *gorm.DB, threadID, senderID uuid.UUID, content string) (*CenterMessage, error) {
-	var message *CenterMessage
-	var thread MessageThread
+// CreateMessage creates a new message in a thread (with transaction to update thread metadata),
+// attaching the given uploads to it. A nil senderID sends it as the platform.
+func CreateMessage(db *gorm.DB, threadID uuid.UUID, senderID, sentBy *uuid.UUID, content string, uploadIDs []uuid.UUID) (*CenterMessage, error) {
+	message := &CenterMessage{
+		ThreadID: threadID,
+		SenderID: senderID,
+		SentBy:   sentBy,
+		Kind:     MessageKindText,
+		Content:  content,
+	}
 
 	// Use transaction to ensure atomicity
 	err := db.Transaction(func(tx *gorm.DB) error {
-		// Create the message
-		message = &CenterMessage{
-			ThreadID: threadID,
-			SenderID: senderID,
-			Content:  content,
-			Read:     false,
+		if err := appendMessage(tx, message); err != nil {
+			return err
+		}
+		return AttachUploads(tx, uploadIDs, UploadForMessage, message.ID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Load sender info for response
+	db.Preload("Sender").First(message, message.ID)
+
+	return message, nil
+}
+
+// appendMessage creates a message and updates its thread's last activity and
+// message count; callers run it inside a transaction
+func appendMessage(tx *gorm.DB, message *CenterMessage) error {
+	if err := tx.Omit(clause.Associations).Create(message).Error; err != nil {
+		return err
+	}
+
+	var thread MessageThread
+	if err := tx.Where("id = ?", message.ThreadID).First(&thread).Error; err != nil {
+		return err
+	}
+	thread.LastActivity = time.Now()
+	thread.MessageCount++
+	return tx.Omit(clause.Associations).Save(&thread).Error
+}
+
+// systemNote builds the message recording a change to a thread
+func systemNote(threadID uuid.UUID, by *uuid.UUID, content string) *CenterMessage {
+	return &CenterMessage{ThreadID: threadID, SentBy: by, Kind: MessageKindSystem, Content: content}
+}
+
+// AddThreadParticipants adds centers to a thread and records a system note
+// saying so, which is returned
+func AddThreadParticipants(db *gorm.DB, threadID uuid.UUID, centerIDs []uuid.UUID, by *uuid.UUID, note string) (*CenterMessage, error) {
+	message := systemNote(threadID, by, note)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		rows := make([]map[string]interface{}, len(centerIDs))
+		for i, id := range centerIDs {
+			rows[i] = map[string]interface{}{"message_thread_id": threadID, "community_center_id": id}
+		}
+		err := tx.Table("message_thread_participants").Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+		if err != nil {
+			return err
 		}
+		return appendMessage(tx, message)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
 
-		if err := tx.Create(message).Error; err != nil {
+// RemoveThreadParticipant takes a center out of a thread, dropping its read
+// cursor and settings, and records a system note saying so
+func RemoveThreadParticipant(db *gorm.DB, threadID, centerID uuid.UUID, by *uuid.UUID, note string) (*CenterMessage, error) {
+	message := systemNote(threadID, by, note)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM message_thread_participants WHERE message_thread_id = ? AND community_center_id = ?", threadID, centerID).Error
+		if err != nil {
 			return err
 		}
+		if err := tx.Where("thread_id = ? AND center_id = ?", threadID, centerID).Delete(&ThreadReadCursor{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("thread_id = ? AND center_id = ?", threadID, centerID).Delete(&ThreadParticipantState{}).Error; err != nil {
+			return err
+		}
+		return appendMessage(tx, message)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
 
-		// Update thread's last activity and message count
-		if err := tx.Where("id = ?", threadID).First(&thread).Error; err != nil {
+// RenameThread changes a thread's subject and records a system note saying so
+func RenameThread(db *gorm.DB, threadID uuid.UUID, subject string, by *uuid.UUID, note string) (*CenterMessage, error) {
+	message := systemNote(threadID, by, note)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&MessageThread{}).Where("id = ?", threadID).Update("subject", subject).Error; err != nil {
 			return err
 		}
+		return appendMessage(tx, message)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// ListThreadStates retrieves the given centers' settings for their threads
+func ListThreadStates(db *gorm.DB, centerIDs []uuid.UUID) ([]ThreadParticipantState, error) {
+	var states []ThreadParticipantState
+	if len(centerIDs) == 0 {
+		return states, nil
+	}
+	err := db.Where("center_id IN ?", centerIDs).Find(&states).Error
+	return states, err
+}
 
-		thread.LastActivity = time.Now()
-		thread.MessageCount++
+// SaveThreadState creates or updates a center's settings for a thread,
+// writing only the given columns over an existing row
+func SaveThreadState(db *gorm.DB, state *ThreadParticipantState, columns ...string) (*ThreadParticipantState, error) {
+	err := db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "thread_id"}, {Name: "center_id"}},
+		DoUpdates: clause.AssignmentColumns(append(columns, "updated_by", "updated_at")),
+	}).Create(state).Error
+	if err != nil {
+		return nil, err
+	}
+	// As with read cursors, the row may predate this call
+	var saved ThreadParticipantState
+	err = db.Where("thread_id = ? AND center_id = ?", state.ThreadID, state.CenterID).First(&saved).Error
+	return &saved, err
+}
+
+// FindMessageInThread retrieves one of a thread's messages with its sender
+func FindMessageInThread(db *gorm.DB, threadID, messageID uuid.UUID) (*CenterMessage, error) {
+	var message CenterMessage
+	err := db.Preload("Sender").Where("id = ? AND thread_id = ?", messageID, threadID).First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &message, nil
+}
 
-		if err := tx.Save(&thread).Error; err != nil {
+// ErrMessageChanged is returned when a message was edited or deleted since it
+// was read
+var ErrMessageChanged = errors.New("message has changed; reload and try again")
+
+// EditMessage replaces a message's content, keeping the old content as a
+// revision. It returns ErrMessageChanged if the message no longer has the
+// content it was read with or has been deleted.
+func EditMessage(db *gorm.DB, message *CenterMessage, content string, by *uuid.UUID) error {
+	now := time.Now()
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := changeMessage(tx, message, MessageRevisionEdit, by, map[string]interface{}{"content": content, "edited_at": now})
+		if err != nil {
 			return err
 		}
+		message.Content, message.EditedAt = content, &now
+		return nil
+	})
+}
 
+// DeleteMessage tombstones a message: its content is kept as a revision and
+// blanked, and the message stays in the thread marked deleted. Like
+// EditMessage it returns ErrMessageChanged if the message changed since read.
+func DeleteMessage(db *gorm.DB, message *CenterMessage, by *uuid.UUID) error {
+	now := time.Now()
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := changeMessage(tx, message, MessageRevisionDelete, by, map[string]interface{}{"content": "", "deleted_at": now, "deleted_by": by})
+		if err != nil {
+			return err
+		}
+		message.Content, message.DeletedAt, message.DeletedBy = "", &now, by
 		return nil
 	})
+}
 
+// changeMessage updates a message still as it was read and records the
+// content it replaced
+func changeMessage(tx *gorm.DB, message *CenterMessage, action MessageRevisionAction, by *uuid.UUID, values map[string]interface{}) error {
+	result := tx.Model(&CenterMessage{}).Where("id = ? AND deleted_at IS NULL AND content = ?", message.ID, message.Content).Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMessageChanged
+	}
+	revision := &MessageRevision{MessageID: message.ID, Content: message.Content, Action: action, ChangedBy: by}
+	return tx.Omit(clause.Associations).Create(revision).Error
+}
+
+// ListMessageRevisions retrieves a message's earlier contents, oldest first
+func ListMessageRevisions(db *gorm.DB, messageID uuid.UUID) ([]MessageRevision, error) {
+	var revisions []MessageRevision
+	err := db.Where("message_id = ?", messageID).Order("created_at ASC").Find(&revisions).Error
+	return revisions, err
+}
+
+// MarkThreadRead moves a center's read cursor for a thread up to upTo. The
+// cursor never moves backwards, so a stale client cannot unread messages.
+func MarkThreadRead(db *gorm.DB, threadID, centerID, readerID uuid.UUID, upTo time.Time) (*ThreadReadCursor, error) {
+	cursor := ThreadReadCursor{ThreadID: threadID, CenterID: centerID, LastReadAt: upTo, ReadBy: &readerID}
+	err := db.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "thread_id"}, {Name: "center_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"last_read_at": gorm.Expr("GREATEST(thread_read_cursors.last_read_at, EXCLUDED.last_read_at)"),
+			"read_by":      readerID,
+			"updated_at":   time.Now(),
+		}),
+	}).Create(&cursor).Error
 	if err != nil {
 		return nil, err
 	}
+	// The row may predate this call, so it is read back by its natural key
+	var saved ThreadReadCursor
+	err = db.Where("thread_id = ? AND center_id = ?", threadID, centerID).First(&saved).Error
+	return &saved, err
+}
+
+// ListReadCursors retrieves the read cursors of the given threads
+func ListReadCursors(db *gorm.DB, threadIDs []uuid.UUID) ([]ThreadReadCursor, error) {
+	var cursors []ThreadReadCursor
+	if len(threadIDs) == 0 {
+		return cursors, nil
+	}
+	err := db.Where("thread_id IN ?", threadIDs).Find(&cursors).Error
+	return cursors, err
+}
 
-	// Load sender info for response
-	db.Preload("Sender").First(message, message.ID)
+// UnreadCount is how many messages a center has not read in one thread
+type UnreadCount struct {
+	CenterID uuid.UUID
+	ThreadID uuid.UUID
+	Unread   int64
+}
 
-	return message, nil
+// CountUnread counts unread messages in every thread the given centers
+// take part in; threads with nothing unread are left out
+func CountUnread(db *gorm.DB, centerIDs []uuid.UUID) ([]UnreadCount, error) {
+	var counts []UnreadCount
+	if len(centerIDs) == 0 {
+		return counts, nil
+	}
+	err := db.Table("message_thread_participants AS p").
+		Select("p.community_center_id AS center_id, p.message_thread_id AS thread_id, COUNT(m.id) AS unread").
+		Joins("JOIN center_messages m ON m.thread_id = p.message_thread_id AND m.sender_id IS DISTINCT FROM p.community_center_id AND m.deleted_at IS NULL").
+		Joins("LEFT JOIN thread_read_cursors r ON r.thread_id = p.message_thread_id AND r.center_id = p.community_center_id").
+		Where("p.community_center_id IN ?", centerIDs).
+		Where("r.last_read_at IS NULL OR m.created_at > r.last_read_at").
+		Group("p.community_center_id, p.message_thread_id").
+		Scan(&counts).Error
+	return counts, err
 }
 
-// MarkMessagesAsRead marks messages in a thread as read
-func MarkMessagesAsRead(db *gorm.DB, threadID, centerID uuid.UUID) error {
-	// Mark all messages in thread as read, except those sent by this center
-	return db.Model(&CenterMessage{}).
-		Where("thread_id = ? AND sender_id != ? AND read = ?", threadID, centerID, false).
-		Update("read", true).Error
+// migrateMessageReadFlags replaces the old per-message read flag, shared by
+// every participant, with read cursors. Each participant is treated as having
+// read up to the last message marked read, then the column is dropped.
+func migrateMessageReadFlags(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&CenterMessage{}, "read") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`INSERT INTO thread_read_cursors (id, thread_id, center_id, last_read_at, created_at, updated_at)
+SELECT gen_random_uuid(), p.message_thread_id, p.community_center_id, MAX(m.created_at), NOW(), NOW()
+FROM message_thread_participants p
+JOIN center_messages m ON m.thread_id = p.message_thread_id AND m.read
+GROUP BY p.message_thread_id, p.community_center_id
+ON CONFLICT (thread_id, center_id) DO NOTHING`).Error
+		if err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&CenterMessage{}, "read")
+	})
 }
 
 // FindThreadByID retrieves a thread by ID
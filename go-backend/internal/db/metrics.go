@@ -0,0 +1,170 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MetricEntryFilters narrows the entries fed into impact aggregates
+type MetricEntryFilters struct {
+	HubID             *uuid.UUID
+	ProgramID         *uuid.UUID // Entrepreneurs enrolled in any of the program's cohorts
+	From              *time.Time // Inclusive, on period end
+	To                *time.Time // Inclusive, on period end
+	IncludeUnverified bool       // Count PENDING entries as well as VERIFIED ones
+}
+
+// ListMetricTypes retrieves metric types ordered by name
+func ListMetricTypes(db *gorm.DB, includeInactive bool) ([]MetricType, error) {
+	var types []MetricType
+	query := db
+	if !includeInactive {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("name ASC").Find(&types).Error
+	return types, err
+}
+
+// FindMetricTypeByID retrieves a metric type by ID
+func FindMetricTypeByID(db *gorm.DB, id uuid.UUID) (*MetricType, error) {
+	var metricType MetricType
+	if err := db.Where("id = ?", id).First(&metricType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &metricType, nil
+}
+
+// FindMetricTypeByKey retrieves a metric type by its unique key
+func FindMetricTypeByKey(db *gorm.DB, key string) (*MetricType, error) {
+	var metricType MetricType
+	if err := db.Where("key = ?", key).First(&metricType).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &metricType, nil
+}
+
+// SaveMetricType creates or updates a metric type
+func SaveMetricType(db *gorm.DB, metricType *MetricType) error {
+	return db.Save(metricType).Error
+}
+
+// CreateMetricEntry stores a reported metric value
+func CreateMetricEntry(db *gorm.DB, entry *MetricEntry) error {
+	return db.Omit(clause.Associations).Create(entry).Error
+}
+
+// FindMetricEntryByID retrieves an entry with its metric type and entrepreneur
+func FindMetricEntryByID(db *gorm.DB, id uuid.UUID) (*MetricEntry, error) {
+	var entry MetricEntry
+	err := db.Preload("MetricType").Preload("Entrepreneur.User").Where("id = ?", id).First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ErrMetricEntryReviewed is returned when an entry was verified or rejected
+// after it was read, e.g. by another hub manager
+var ErrMetricEntryReviewed = errors.New("metric entry has already been reviewed")
+
+// ReviewMetricEntry verifies or rejects an entry that is still PENDING,
+// returning ErrMetricEntryReviewed otherwise.
+func ReviewMetricEntry(db *gorm.DB, entry *MetricEntry, status MetricEntryStatus, verifier *uuid.UUID, note *string) error {
+	now := time.Now()
+	result := db.Model(&MetricEntry{}).Where("id = ? AND status = ?", entry.ID, MetricPending).Updates(map[string]interface{}{
+		"status":            status,
+		"verified_by":       verifier,
+		"verified_at":       now,
+		"verification_note": note,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrMetricEntryReviewed
+	}
+	entry.Status = status
+	entry.VerifiedBy = verifier
+	entry.VerifiedAt = &now
+	entry.VerificationNote = note
+	return nil
+}
+
+// ListEntrepreneurMetricEntries retrieves an entrepreneur's entries, most
+// recent period first. A non-nil hubIDs restricts them to those hubs.
+func ListEntrepreneurMetricEntries(db *gorm.DB, entrepreneurID uuid.UUID, hubIDs []uuid.UUID) ([]MetricEntry, error) {
+	var entries []MetricEntry
+	query := db.Preload("MetricType").Preload("Hub").Where("entrepreneur_id = ?", entrepreneurID)
+	if hubIDs != nil {
+		if len(hubIDs) == 0 {
+			return entries, nil
+		}
+		query = query.Where("hub_id IN ?", hubIDs)
+	}
+	err := query.Order("period_end DESC, created_at DESC").Find(&entries).Error
+	return entries, err
+}
+
+// ListPendingMetricEntries retrieves a hub's entries awaiting verification, oldest first
+func ListPendingMetricEntries(db *gorm.DB, hubID uuid.UUID) ([]MetricEntry, error) {
+	var entries []MetricEntry
+	err := db.Preload("MetricType").Preload("Entrepreneur.User").
+		Where("hub_id = ? AND status = ?", hubID, MetricPending).
+		Order("created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// ListMetricEntries retrieves entries for aggregation. Rejected entries are
+// always excluded; pending ones only count when IncludeUnverified is set.
+func ListMetricEntries(db *gorm.DB, filters MetricEntryFilters) ([]MetricEntry, error) {
+	var entries []MetricEntry
+	statuses := []MetricEntryStatus{MetricVerified}
+	if filters.IncludeUnverified {
+		statuses = append(statuses, MetricPending)
+	}
+	query := db.Where("status IN ?", statuses)
+	if filters.HubID != nil {
+		query = query.Where("hub_id = ?", *filters.HubID)
+	}
+	if filters.ProgramID != nil {
+		enrolled := db.Model(&HubEnrollment{}).
+			Select("hub_enrollments.entrepreneur_id").
+			Joins("JOIN cohorts ON cohorts.id = hub_enrollments.cohort_id").
+			Where("cohorts.program_id = ?", *filters.ProgramID)
+		programHub := db.Model(&Program{}).Select("hub_id").Where("id = ?", *filters.ProgramID)
+		query = query.Where("entrepreneur_id IN (?) AND hub_id IN (?)", enrolled, programHub)
+	}
+	if filters.From != nil {
+		query = query.Where("period_end >= ?", *filters.From)
+	}
+	if filters.To != nil {
+		query = query.Where("period_end <= ?", *filters.To)
+	}
+	err := query.Order("period_end ASC, created_at ASC").Find(&entries).Error
+	return entries, err
+}
+
+// HasHubEnrollment reports whether the entrepreneur is, or was, enrolled at
+// the hub (ACTIVE or COMPLETED)
+func HasHubEnrollment(db *gorm.DB, hubID, entrepreneurID uuid.UUID) (bool, error) {
+	var count int64
+	err := db.Model(&HubEnrollment{}).
+		Where("hub_id = ? AND entrepreneur_id = ? AND status IN ?",
+			hubID, entrepreneurID, []EnrollmentStatus{EnrollmentActive, EnrollmentCompleted}).
+		Count(&count).Error
+	return count > 0, err
+}
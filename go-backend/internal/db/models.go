@@ -2,6 +2,8 @@ package db
 
 import (
 	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -69,6 +71,42 @@ func (a *StringArray) Scan(value interface{}) error {
 	return nil
 }
 
+// JSON type for PostgreSQL JSONB columns, kept as raw bytes
+type JSON json.RawMessage
+
+func (j JSON) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+func (j *JSON) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append(JSON{}, v...)
+	case string:
+		*j = JSON(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSON", value)
+	}
+	return nil
+}
+
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	*j = append(JSON{}, data...)
+	return nil
+}
+
 // User model matching Prisma exactly
 type User struct {
 	ID           uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
@@ -120,6 +158,11 @@ type CommunityCenter struct {
 	Email   *string `gorm:"size:255;column:email"`
 	Website *string `gorm:"size:500;column:website"`
 
+	// Availability
+	TimeZone       string `gorm:"size:64;not null;default:'Africa/Kampala';column:time_zone"` // IANA zone for opening hours
+	Capacity       *int   `gorm:"column:capacity"`        // Maximum people on site at once
+	WorkspaceSeats *int   `gorm:"column:workspace_seats"` // Desks available to entrepreneurs
+
 	// Relations
 	Manager            *User             `gorm:"foreignKey:ManagerID"`
 	ConnectionsFrom    []Connection      `gorm:"foreignKey:CenterAID"`
@@ -127,6 +170,9 @@ type CommunityCenter struct {
 	ContactMessages    []ContactMessage  `gorm:"foreignKey:CenterID"`
 	MessageThreads     []MessageThread   `gorm:"many2many:message_thread_participants;"`
 	SentMessages       []CenterMessage   `gorm:"foreignKey:SenderID"`
+	OpeningHours       []OpeningHours    `gorm:"foreignKey:CenterID"`
+	HoursExceptions    []HoursException  `gorm:"foreignKey:CenterID"`
+	Images             []CenterImage     `gorm:"foreignKey:CenterID"`
 }
 
 func (CommunityCenter) TableName() string {
@@ -225,18 +271,30 @@ func (m *MessageThread) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// MessageKind tells messages people wrote from notes the platform records
+type MessageKind string
+
+const (
+	MessageKindText   MessageKind = "TEXT"
+	MessageKindSystem MessageKind = "SYSTEM" // Records a change to the thread, e.g. a center joining
+)
+
 // CenterMessage model - messages within threads
 type CenterMessage struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
-	ThreadID  uuid.UUID `gorm:"type:uuid;not null;index;column:thread_id"`
-	SenderID  uuid.UUID `gorm:"type:uuid;not null;index;column:sender_id"`
-	Content   string    `gorm:"type:text;not null;column:content"`
-	Read      bool      `gorm:"default:false;not null;column:read"`
-	CreatedAt time.Time `gorm:"column:created_at"`
+	ID        uuid.UUID   `gorm:"type:uuid;primaryKey;index:idx_center_messages_thread_page,priority:3;column:id"`
+	ThreadID  uuid.UUID   `gorm:"type:uuid;not null;index;index:idx_center_messages_thread_page,priority:1;column:thread_id"`
+	SenderID  *uuid.UUID  `gorm:"type:uuid;index;column:sender_id"` // Nil for platform messages sent by an admin
+	SentBy    *uuid.UUID  `gorm:"type:uuid;column:sent_by"`         // User who wrote it
+	Kind      MessageKind `gorm:"type:varchar(20);not null;default:'TEXT';column:kind"`
+	Content   string      `gorm:"type:text;not null;column:content"`
+	CreatedAt time.Time   `gorm:"index:idx_center_messages_thread_page,priority:2;column:created_at"` // Keyset pagination runs on (thread_id, created_at, id)
+	EditedAt  *time.Time  `gorm:"column:edited_at"`
+	DeletedAt *time.Time  `gorm:"column:deleted_at"` // Tombstone: the message stays, its content blanked
+	DeletedBy *uuid.UUID  `gorm:"type:uuid;column:deleted_by"`
 
 	// Relations
-	Thread MessageThread   `gorm:"foreignKey:ThreadID;constraint:OnDelete:CASCADE"`
-	Sender CommunityCenter `gorm:"foreignKey:SenderID"`
+	Thread MessageThread    `gorm:"foreignKey:ThreadID;constraint:OnDelete:CASCADE"`
+	Sender *CommunityCenter `gorm:"foreignKey:SenderID"`
 }
 
 func (CenterMessage) TableName() string {
@@ -250,6 +308,116 @@ func (c *CenterMessage) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// MessageRevisionAction says what replaced a message's content
+type MessageRevisionAction string
+
+const (
+	MessageRevisionEdit   MessageRevisionAction = "EDIT"
+	MessageRevisionDelete MessageRevisionAction = "DELETE"
+)
+
+// MessageRevision model - a thread message's content as it was before an
+// edit or delete, kept for admins
+type MessageRevision struct {
+	ID        uuid.UUID             `gorm:"type:uuid;primaryKey;column:id"`
+	MessageID uuid.UUID             `gorm:"type:uuid;not null;index;column:message_id"`
+	Content   string                `gorm:"type:text;not null;column:content"`
+	Action    MessageRevisionAction `gorm:"type:varchar(20);not null;column:action"`
+	ChangedBy *uuid.UUID            `gorm:"type:uuid;column:changed_by"`
+	CreatedAt time.Time             `gorm:"column:created_at"`
+
+	// Relations
+	Message CenterMessage `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE"`
+}
+
+func (MessageRevision) TableName() string {
+	return "message_revisions"
+}
+
+func (r *MessageRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// ThreadReadCursor model - how far a participating center has read a thread.
+// Messages from other centers after LastReadAt are unread for it.
+type ThreadReadCursor struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;column:id"`
+	ThreadID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_thread_read_cursors_thread_center;column:thread_id"`
+	CenterID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_thread_read_cursors_thread_center;index;column:center_id"`
+	LastReadAt time.Time  `gorm:"not null;column:last_read_at"`
+	ReadBy     *uuid.UUID `gorm:"type:uuid;column:read_by"` // User who last moved it
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Thread MessageThread   `gorm:"foreignKey:ThreadID;constraint:OnDelete:CASCADE"`
+	Center CommunityCenter `gorm:"foreignKey:CenterID"`
+}
+
+func (ThreadReadCursor) TableName() string {
+	return "thread_read_cursors"
+}
+
+func (r *ThreadReadCursor) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// ThreadParticipantState model - a participating center's own settings for a
+// thread. An archived thread is hidden from the center's list until a message
+// arrives after ArchivedAt; a muted one leaves its unread badges alone.
+type ThreadParticipantState struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey;column:id"`
+	ThreadID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_thread_participant_states_thread_center;column:thread_id"`
+	CenterID   uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_thread_participant_states_thread_center;index;column:center_id"`
+	ArchivedAt *time.Time `gorm:"column:archived_at"`
+	Muted      bool       `gorm:"not null;default:false;column:muted"`
+	MutedUntil *time.Time `gorm:"column:muted_until"`          // Nil mutes until unmuted
+	UpdatedBy  *uuid.UUID `gorm:"type:uuid;column:updated_by"` // User who last changed it
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Thread MessageThread   `gorm:"foreignKey:ThreadID;constraint:OnDelete:CASCADE"`
+	Center CommunityCenter `gorm:"foreignKey:CenterID"`
+}
+
+func (ThreadParticipantState) TableName() string {
+	return "thread_participant_states"
+}
+
+func (s *ThreadParticipantState) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsArchived reports whether the thread is still archived given its last
+// activity; new messages bring it back
+func (s *ThreadParticipantState) IsArchived(lastActivity time.Time) bool {
+	return s.ArchivedAt != nil && !lastActivity.After(*s.ArchivedAt)
+}
+
+// IsMuted reports whether the thread is muted at the given time
+func (s *ThreadParticipantState) IsMuted(at time.Time) bool {
+	return s.Muted && (s.MutedUntil == nil || at.Before(*s.MutedUntil))
+}
+
+// Visibility controls who may see an entrepreneur's profile or contact detail
+type Visibility string
+
+const (
+	VisibilityPublic  Visibility = "PUBLIC"  // Anyone, signed in or not
+	VisibilityNetwork Visibility = "NETWORK" // Signed-in platform members
+	VisibilityPrivate Visibility = "PRIVATE" // Their hubs' managers and admins
+)
+
 // Entrepreneur model - business profiles for entrepreneurs
 type Entrepreneur struct {
 	ID           uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
@@ -264,10 +432,18 @@ type Entrepreneur struct {
 	CreatedAt    time.Time `gorm:"column:created_at"`
 	UpdatedAt    time.Time `gorm:"column:updated_at"`
 
+	// Privacy settings: who may see the profile in the directory and each
+	// contact detail
+	ProfileVisibility Visibility `gorm:"type:varchar(10);not null;default:'PUBLIC';column:profile_visibility"`
+	PhoneVisibility   Visibility `gorm:"type:varchar(10);not null;default:'PRIVATE';column:phone_visibility"`
+	EmailVisibility   Visibility `gorm:"type:varchar(10);not null;default:'PRIVATE';column:email_visibility"`
+	WebsiteVisibility Visibility `gorm:"type:varchar(10);not null;default:'PUBLIC';column:website_visibility"`
+
 	// Relations
 	User                User                `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 	Enrollments         []HubEnrollment     `gorm:"foreignKey:EntrepreneurID"`
 	ServicesReceived    []ServiceProvision  `gorm:"foreignKey:EntrepreneurID"`
+	Portfolio           []PortfolioItem     `gorm:"foreignKey:EntrepreneurID"`
 }
 
 func (Entrepreneur) TableName() string {
@@ -287,6 +463,7 @@ type HubEnrollment struct {
 	HubID          uuid.UUID        `gorm:"type:uuid;not null;index;column:hub_id"`
 	EntrepreneurID uuid.UUID        `gorm:"type:uuid;not null;index;column:entrepreneur_id"`
 	Status         EnrollmentStatus `gorm:"type:varchar(20);not null;default:'PENDING';column:status"`
+	CohortID       *uuid.UUID       `gorm:"type:uuid;index;column:cohort_id"` // Optional cohort within a program
 	EnrollmentDate *time.Time       `gorm:"column:enrollment_date"`
 	CompletionDate *time.Time       `gorm:"column:completion_date"`
 	CreatedAt      time.Time        `gorm:"column:created_at"`
@@ -295,6 +472,7 @@ type HubEnrollment struct {
 	// Relations
 	Hub          CommunityCenter `gorm:"foreignKey:HubID;constraint:OnDelete:CASCADE"`
 	Entrepreneur Entrepreneur    `gorm:"foreignKey:EntrepreneurID;constraint:OnDelete:CASCADE"`
+	Cohort       *Cohort         `gorm:"foreignKey:CohortID;constraint:OnDelete:SET NULL"`
 }
 
 func (HubEnrollment) TableName() string {
@@ -317,6 +495,7 @@ type ServiceProvision struct {
 	Description         string                 `gorm:"type:text;column:description"`
 	CollaboratingHubID  *uuid.UUID             `gorm:"type:uuid;column:collaborating_hub_id"`
 	InvestorName        *string                `gorm:"size:255;column:investor_name"`
+	InvestorID          *uuid.UUID             `gorm:"type:uuid;index;column:investor_id"` // Directory entry matching InvestorName
 	InvestorDetails     *string                `gorm:"type:text;column:investor_details"`
 	StartDate           *time.Time             `gorm:"column:start_date"`
 	CompletionDate      *time.Time             `gorm:"column:completion_date"`
@@ -351,6 +530,7 @@ const (
 	ActivityCollaboration ActivityType = "COLLABORATION"
 	ActivityAnnouncement  ActivityType = "ANNOUNCEMENT"
 	ActivityConnection    ActivityType = "CONNECTION"
+	ActivityShowcase      ActivityType = "SHOWCASE" // Features an entrepreneur's portfolio item
 )
 
 // HubActivity model - social feed activities for community centers
@@ -365,6 +545,7 @@ type HubActivity struct {
 	ConnectionID       *uuid.UUID   `gorm:"type:uuid;column:connection_id"`
 	CollaboratingHubID *uuid.UUID   `gorm:"type:uuid;column:collaborating_hub_id"`
 	ImageURL           *string      `gorm:"size:500;column:image_url"`
+	PortfolioItemID    *uuid.UUID   `gorm:"type:uuid;column:portfolio_item_id"`
 	Pinned             bool         `gorm:"default:false;column:pinned"`
 	CreatedBy          uuid.UUID    `gorm:"type:uuid;not null;column:created_by"`
 	CreatedAt          time.Time    `gorm:"column:created_at;index"`
@@ -376,6 +557,7 @@ type HubActivity struct {
 	ServiceProvision *ServiceProvision `gorm:"foreignKey:ServiceProvisionID"`
 	Connection       *Connection       `gorm:"foreignKey:ConnectionID"`
 	CollaboratingHub *CommunityCenter  `gorm:"foreignKey:CollaboratingHubID"`
+	PortfolioItem    *PortfolioItem    `gorm:"foreignKey:PortfolioItemID"`
 	Creator          User              `gorm:"foreignKey:CreatedBy"`
 }
 
@@ -421,21 +603,1067 @@ func (r *RoleUpgradeRequest) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// OpeningHours model - one weekly opening interval for a center (local time)
+type OpeningHours struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
+	CenterID  uuid.UUID `gorm:"type:uuid;not null;index;column:center_id"`
+	Weekday   int       `gorm:"not null;column:weekday"`           // 0 = Sunday ... 6 = Saturday
+	OpensAt   string    `gorm:"size:5;not null;column:opens_at"`  // "HH:MM"
+	ClosesAt  string    `gorm:"size:5;not null;column:closes_at"` // "HH:MM", "24:00" for midnight
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+
+	// Relations
+	Center CommunityCenter `gorm:"foreignKey:CenterID;constraint:OnDelete:CASCADE"`
+}
+
+func (OpeningHours) TableName() string {
+	return "center_opening_hours"
+}
+
+func (o *OpeningHours) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+// HoursException model - holiday closure or special hours replacing the weekly schedule for a date
+type HoursException struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
+	CenterID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_center_hours_exception;column:center_id"`
+	Date      time.Time `gorm:"type:date;not null;uniqueIndex:idx_center_hours_exception;column:date"`
+	Closed    bool      `gorm:"default:true;not null;column:closed"`
+	OpensAt   *string   `gorm:"size:5;column:opens_at"`  // Special hours when not closed
+	ClosesAt  *string   `gorm:"size:5;column:closes_at"` // Special hours when not closed
+	Reason    string    `gorm:"size:255;column:reason"`  // e.g. "Independence Day"
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+
+	// Relations
+	Center CommunityCenter `gorm:"foreignKey:CenterID;constraint:OnDelete:CASCADE"`
+}
+
+func (HoursException) TableName() string {
+	return "center_hours_exceptions"
+}
+
+func (h *HoursException) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
+
+// TaxonomyKind distinguishes service categories from resource categories
+type TaxonomyKind string
+
+const (
+	TaxonomyService  TaxonomyKind = "SERVICE"
+	TaxonomyResource TaxonomyKind = "RESOURCE"
+)
+
+// ServiceCategory model - managed taxonomy entry for center services and resources
+type ServiceCategory struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primaryKey;column:id"`
+	Kind        TaxonomyKind `gorm:"type:varchar(20);not null;default:'SERVICE';uniqueIndex:idx_taxonomy_kind_slug;column:kind"`
+	Slug        string       `gorm:"size:100;not null;uniqueIndex:idx_taxonomy_kind_slug;column:slug"`
+	Name        string       `gorm:"size:255;not null;column:name"`
+	Description string       `gorm:"type:text;column:description"`
+	Synonyms    StringArray  `gorm:"type:text[];column:synonyms"` // Alternative spellings mapped onto this entry
+	ParentID    *uuid.UUID   `gorm:"type:uuid;index;column:parent_id"`
+	CreatedAt   time.Time    `gorm:"column:created_at"`
+	UpdatedAt   time.Time    `gorm:"column:updated_at"`
+
+	// Relations
+	Parent *ServiceCategory `gorm:"foreignKey:ParentID;constraint:OnDelete:SET NULL"`
+}
+
+func (ServiceCategory) TableName() string {
+	return "service_categories"
+}
+
+func (s *ServiceCategory) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// BookingStatus tracks a reservation through approval
+type BookingStatus string
+
+const (
+	BookingPending   BookingStatus = "PENDING"
+	BookingConfirmed BookingStatus = "CONFIRMED"
+	BookingRejected  BookingStatus = "REJECTED"
+	BookingCancelled BookingStatus = "CANCELLED"
+)
+
+// BookableResource model - a room or piece of equipment that can be reserved at a center
+type BookableResource struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
+	CenterID         uuid.UUID `gorm:"type:uuid;not null;index;column:center_id"`
+	Name             string    `gorm:"size:255;not null;column:name"`
+	Kind             string    `gorm:"size:50;not null;default:'ROOM';column:kind"` // ROOM, EQUIPMENT, LAB, STUDIO
+	Description      string    `gorm:"type:text;column:description"`
+	Capacity         *int      `gorm:"column:capacity"`                                 // People the resource holds
+	RequiresApproval bool      `gorm:"default:true;not null;column:requires_approval"` // Otherwise bookings confirm immediately
+	Active           bool      `gorm:"default:true;not null;column:active"`
+	CreatedAt        time.Time `gorm:"column:created_at"`
+	UpdatedAt        time.Time `gorm:"column:updated_at"`
+
+	// Relations
+	Center CommunityCenter `gorm:"foreignKey:CenterID;constraint:OnDelete:CASCADE"`
+}
+
+func (BookableResource) TableName() string {
+	return "bookable_resources"
+}
+
+func (b *BookableResource) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// Booking model - a time-slot reservation of a bookable resource.
+// Overlapping PENDING/CONFIRMED bookings of one resource are rejected by an
+// exclusion constraint (see ensureBookingConstraints).
+type Booking struct {
+	ID         uuid.UUID     `gorm:"type:uuid;primaryKey;column:id"`
+	ResourceID uuid.UUID     `gorm:"type:uuid;not null;index;column:resource_id"`
+	CenterID   uuid.UUID     `gorm:"type:uuid;not null;index;column:center_id"`
+	UserID     uuid.UUID     `gorm:"type:uuid;not null;index;column:user_id"`
+	StartsAt   time.Time     `gorm:"not null;column:starts_at"`
+	EndsAt     time.Time     `gorm:"not null;column:ends_at"`
+	Status     BookingStatus `gorm:"type:varchar(20);not null;default:'PENDING';index;column:status"`
+	Purpose    string        `gorm:"size:500;column:purpose"`
+	Attendees  *int          `gorm:"column:attendees"`
+	ReviewedBy *uuid.UUID    `gorm:"type:uuid;column:reviewed_by"` // Manager who approved or rejected
+	Notes      *string       `gorm:"type:text;column:notes"`       // Reason for rejection or cancellation
+	CreatedAt  time.Time     `gorm:"column:created_at"`
+	UpdatedAt  time.Time     `gorm:"column:updated_at"`
+
+	// Relations
+	Resource BookableResource `gorm:"foreignKey:ResourceID;constraint:OnDelete:CASCADE"`
+	Center   CommunityCenter  `gorm:"foreignKey:CenterID;constraint:OnDelete:CASCADE"`
+	User     User             `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (Booking) TableName() string {
+	return "resource_bookings"
+}
+
+func (b *Booking) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// HubEventKind categorizes upcoming hub events
+type HubEventKind string
+
+const (
+	EventWorkshop HubEventKind = "WORKSHOP"
+	EventPitchDay HubEventKind = "PITCH_DAY"
+	EventTraining HubEventKind = "TRAINING"
+	EventMeetup   HubEventKind = "MEETUP"
+	EventOther    HubEventKind = "OTHER"
+)
+
+type RSVPStatus string
+
+const (
+	RSVPGoing      RSVPStatus = "GOING"
+	RSVPWaitlisted RSVPStatus = "WAITLISTED"
+	RSVPCancelled  RSVPStatus = "CANCELLED"
+)
+
+// HubEvent model - upcoming workshop, pitch day or training run by a hub
+type HubEvent struct {
+	ID          uuid.UUID    `gorm:"type:uuid;primaryKey;column:id"`
+	HubID       uuid.UUID    `gorm:"type:uuid;not null;index;column:hub_id"`
+	Kind        HubEventKind `gorm:"type:varchar(20);not null;default:'WORKSHOP';column:kind"`
+	Title       string       `gorm:"size:255;not null;column:title"`
+	Description string       `gorm:"type:text;column:description"`
+	StartsAt    time.Time    `gorm:"not null;index;column:starts_at"`
+	EndsAt      time.Time    `gorm:"not null;column:ends_at"`
+	Location    string       `gorm:"size:255;column:location"`    // Defaults to the hub's location
+	OnlineURL   *string      `gorm:"size:500;column:online_url"` // For virtual or hybrid events
+	Capacity    *int         `gorm:"column:capacity"`            // Nil means unlimited
+	Cancelled   bool         `gorm:"default:false;not null;column:cancelled"`
+	ActivityID  *uuid.UUID   `gorm:"type:uuid;column:activity_id"` // Announcement posted to the hub feed
+	CreatedBy   uuid.UUID    `gorm:"type:uuid;not null;column:created_by"`
+	CreatedAt   time.Time    `gorm:"column:created_at"`
+	UpdatedAt   time.Time    `gorm:"column:updated_at"`
+
+	// Relations
+	Hub      CommunityCenter `gorm:"foreignKey:HubID;constraint:OnDelete:CASCADE"`
+	Activity *HubActivity    `gorm:"foreignKey:ActivityID;constraint:OnDelete:SET NULL"`
+	RSVPs    []EventRSVP     `gorm:"foreignKey:EventID"`
+}
+
+func (HubEvent) TableName() string {
+	return "hub_events"
+}
+
+func (h *HubEvent) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	return nil
+}
+
+// EventRSVP model - a user's place (or waitlist spot) at a hub event
+type EventRSVP struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;column:id"`
+	EventID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_event_rsvp_user;column:event_id"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_event_rsvp_user;index;column:user_id"`
+	Status      RSVPStatus `gorm:"type:varchar(20);not null;default:'GOING';column:status"`
+	WaitlistAt  *time.Time `gorm:"column:waitlist_at"` // Position in the waitlist, earliest first
+	CheckedInAt *time.Time `gorm:"column:checked_in_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Event HubEvent `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE"`
+	User  User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (EventRSVP) TableName() string {
+	return "hub_event_rsvps"
+}
+
+func (e *EventRSVP) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// Program model - an incubation or acceleration program run by a hub
+type Program struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
+	HubID       uuid.UUID `gorm:"type:uuid;not null;index;column:hub_id"`
+	Name        string    `gorm:"size:255;not null;column:name"`
+	Description string    `gorm:"type:text;column:description"`
+	Curriculum  string    `gorm:"type:text;column:curriculum"` // Outline of what the program covers
+	Active      bool      `gorm:"default:true;not null;column:active"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+
+	// Relations
+	Hub     CommunityCenter `gorm:"foreignKey:HubID;constraint:OnDelete:CASCADE"`
+	Cohorts []Cohort        `gorm:"foreignKey:ProgramID"`
+}
+
+func (Program) TableName() string {
+	return "programs"
+}
+
+func (p *Program) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// Cohort model - a time-boxed intake of a program
+type Cohort struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primaryKey;column:id"`
+	ProgramID           uuid.UUID  `gorm:"type:uuid;not null;index;column:program_id"`
+	HubID               uuid.UUID  `gorm:"type:uuid;not null;index;column:hub_id"` // Copied from the program for hub-scoped queries
+	Name                string     `gorm:"size:255;not null;column:name"`
+	StartDate           time.Time  `gorm:"type:date;not null;column:start_date"`
+	EndDate             time.Time  `gorm:"type:date;not null;column:end_date"`
+	Capacity            *int       `gorm:"column:capacity"` // Nil means unlimited
+	ApplicationOpensAt  *time.Time `gorm:"column:application_opens_at"`
+	ApplicationClosesAt *time.Time `gorm:"column:application_closes_at"`
+	CompletedAt         *time.Time `gorm:"column:completed_at"` // Set when the cohort is closed
+	CreatedAt           time.Time  `gorm:"column:created_at"`
+	UpdatedAt           time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Program     Program           `gorm:"foreignKey:ProgramID;constraint:OnDelete:CASCADE"`
+	Milestones  []CohortMilestone `gorm:"foreignKey:CohortID"`
+	Enrollments []HubEnrollment   `gorm:"foreignKey:CohortID"`
+}
+
+func (Cohort) TableName() string {
+	return "cohorts"
+}
+
+func (c *Cohort) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// CohortMilestone model - a curriculum checkpoint every enrollment in the cohort works towards
+type CohortMilestone struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey;column:id"`
+	CohortID    uuid.UUID  `gorm:"type:uuid;not null;index;column:cohort_id"`
+	Title       string     `gorm:"size:255;not null;column:title"`
+	Description string     `gorm:"type:text;column:description"`
+	DueDate     *time.Time `gorm:"type:date;column:due_date"`
+	Position    int        `gorm:"default:0;not null;column:position"`
+	Required    bool       `gorm:"default:true;not null;column:required"` // Optional milestones don't block completion
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Cohort Cohort `gorm:"foreignKey:CohortID;constraint:OnDelete:CASCADE"`
+}
+
+func (CohortMilestone) TableName() string {
+	return "cohort_milestones"
+}
+
+func (c *CohortMilestone) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// MilestoneCompletion model - records that an enrollment reached a cohort milestone
+type MilestoneCompletion struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;column:id"`
+	EnrollmentID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_milestone_completion;column:enrollment_id"`
+	MilestoneID  uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_milestone_completion;index;column:milestone_id"`
+	CompletedAt  time.Time  `gorm:"not null;column:completed_at"`
+	CompletedBy  *uuid.UUID `gorm:"type:uuid;column:completed_by"`
+	Notes        *string    `gorm:"type:text;column:notes"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+
+	// Relations
+	Enrollment HubEnrollment   `gorm:"foreignKey:EnrollmentID;constraint:OnDelete:CASCADE"`
+	Milestone  CohortMilestone `gorm:"foreignKey:MilestoneID;constraint:OnDelete:CASCADE"`
+}
+
+func (MilestoneCompletion) TableName() string {
+	return "milestone_completions"
+}
+
+func (m *MilestoneCompletion) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+type ApplicationStatus string
+
+const (
+	ApplicationSubmitted ApplicationStatus = "SUBMITTED"
+	ApplicationInReview  ApplicationStatus = "IN_REVIEW"
+	ApplicationAccepted  ApplicationStatus = "ACCEPTED"
+	ApplicationRejected  ApplicationStatus = "REJECTED"
+	ApplicationWithdrawn ApplicationStatus = "WITHDRAWN"
+)
+
+// ApplicationForm model - a hub's configurable enrollment application
+type ApplicationForm struct {
+	ID          uuid.UUID   `gorm:"type:uuid;primaryKey;column:id"`
+	HubID       uuid.UUID   `gorm:"type:uuid;not null;index;column:hub_id"`
+	CohortID    *uuid.UUID  `gorm:"type:uuid;column:cohort_id"` // Accepted applicants join this cohort
+	Title       string      `gorm:"size:255;not null;column:title"`
+	Description string      `gorm:"type:text;column:description"`
+	Schema      JSON        `gorm:"type:jsonb;not null;column:schema"` // JSON Schema describing the questions
+	Rubric      JSON        `gorm:"type:jsonb;column:rubric"`          // Scoring criteria for reviewers
+	Stages      StringArray `gorm:"type:text[];column:stages"`         // Review stages in order, e.g. SCREENING, INTERVIEW
+	Scorers     StringArray `gorm:"type:text[];column:scorers"`        // User IDs allowed to score besides hub managers
+	Active      bool        `gorm:"default:true;not null;column:active"`
+	ClosesAt    *time.Time  `gorm:"column:closes_at"`
+	CreatedAt   time.Time   `gorm:"column:created_at"`
+	UpdatedAt   time.Time   `gorm:"column:updated_at"`
+
+	// Relations
+	Hub    CommunityCenter `gorm:"foreignKey:HubID;constraint:OnDelete:CASCADE"`
+	Cohort *Cohort         `gorm:"foreignKey:CohortID;constraint:OnDelete:SET NULL"`
+}
+
+func (ApplicationForm) TableName() string {
+	return "application_forms"
+}
+
+func (a *ApplicationForm) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// Application model - an entrepreneur's submitted answers to a hub's form
+type Application struct {
+	ID             uuid.UUID         `gorm:"type:uuid;primaryKey;column:id"`
+	FormID         uuid.UUID         `gorm:"type:uuid;not null;index;column:form_id"`
+	HubID          uuid.UUID         `gorm:"type:uuid;not null;index;column:hub_id"`
+	EntrepreneurID uuid.UUID         `gorm:"type:uuid;not null;index;column:entrepreneur_id"`
+	Answers        JSON              `gorm:"type:jsonb;not null;column:answers"`
+	Status         ApplicationStatus `gorm:"type:varchar(20);not null;default:'SUBMITTED';index;column:status"`
+	Stage          string            `gorm:"size:100;column:stage"` // Current review stage
+	DecidedBy      *uuid.UUID        `gorm:"type:uuid;column:decided_by"`
+	DecidedAt      *time.Time        `gorm:"column:decided_at"`
+	DecisionNotes  *string           `gorm:"type:text;column:decision_notes"`
+	EnrollmentID   *uuid.UUID        `gorm:"type:uuid;column:enrollment_id"` // Created on acceptance
+	CreatedAt      time.Time         `gorm:"column:created_at"`
+	UpdatedAt      time.Time         `gorm:"column:updated_at"`
+
+	// Relations
+	Form         ApplicationForm     `gorm:"foreignKey:FormID;constraint:OnDelete:CASCADE"`
+	Entrepreneur Entrepreneur        `gorm:"foreignKey:EntrepreneurID;constraint:OnDelete:CASCADE"`
+	Enrollment   *HubEnrollment      `gorm:"foreignKey:EnrollmentID;constraint:OnDelete:SET NULL"`
+	Reviews      []ApplicationReview `gorm:"foreignKey:ApplicationID"`
+}
+
+func (Application) TableName() string {
+	return "hub_applications"
+}
+
+func (a *Application) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// ApplicationReview model - one scorer's rubric scores for an application at a stage
+type ApplicationReview struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
+	ApplicationID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_application_review;column:application_id"`
+	ReviewerID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_application_review;column:reviewer_id"`
+	Stage          string    `gorm:"size:100;not null;uniqueIndex:idx_application_review;column:stage"`
+	Scores         JSON      `gorm:"type:jsonb;not null;column:scores"` // Criterion key to score
+	Total          float64   `gorm:"not null;column:total"`             // Weighted score out of 100
+	Recommendation string    `gorm:"size:20;column:recommendation"`     // ADVANCE, HOLD or REJECT
+	Comment        string    `gorm:"type:text;column:comment"`
+	CreatedAt      time.Time `gorm:"column:created_at"`
+	UpdatedAt      time.Time `gorm:"column:updated_at"`
+
+	// Relations
+	Application Application `gorm:"foreignKey:ApplicationID;constraint:OnDelete:CASCADE"`
+	Reviewer    User        `gorm:"foreignKey:ReviewerID"`
+}
+
+func (ApplicationReview) TableName() string {
+	return "application_reviews"
+}
+
+func (a *ApplicationReview) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// StatusEntity names the kind of record a status transition belongs to
+type StatusEntity string
+
+const (
+	StatusEntityEnrollment StatusEntity = "ENROLLMENT"
+	StatusEntityService    StatusEntity = "SERVICE_PROVISION"
+	StatusEntityDeal       StatusEntity = "INVESTOR_DEAL"
+	StatusEntitySession    StatusEntity = "MENTORING_SESSION"
+)
+
+// StatusTransition model - audit trail of enrollment and service status changes
+type StatusTransition struct {
+	ID         uuid.UUID    `gorm:"type:uuid;primaryKey;column:id"`
+	EntityType StatusEntity `gorm:"type:varchar(30);not null;index:idx_status_transition_entity;column:entity_type"`
+	EntityID   uuid.UUID    `gorm:"type:uuid;not null;index:idx_status_transition_entity;column:entity_id"`
+	FromStatus string       `gorm:"type:varchar(20);column:from_status"` // Empty for the initial status
+	ToStatus   string       `gorm:"type:varchar(20);not null;column:to_status"`
+	ActorID    *uuid.UUID   `gorm:"type:uuid;column:actor_id"` // Nil for automatic transitions
+	Reason     *string      `gorm:"type:text;column:reason"`
+	CreatedAt  time.Time    `gorm:"column:created_at"`
+
+	// Relations
+	Actor *User `gorm:"foreignKey:ActorID;constraint:OnDelete:SET NULL"`
+}
+
+func (StatusTransition) TableName() string {
+	return "status_transitions"
+}
+
+func (s *StatusTransition) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+type MetricValueType string
+
+const (
+	MetricInteger MetricValueType = "INTEGER"
+	MetricDecimal MetricValueType = "DECIMAL"
+	MetricBand    MetricValueType = "BAND"
+)
+
+type MetricAggregation string
+
+const (
+	MetricSum    MetricAggregation = "SUM"    // Entries are increments, e.g. jobs created in a period
+	MetricLatest MetricAggregation = "LATEST" // Entries are snapshots, e.g. current revenue band
+)
+
+type MetricEntryStatus string
+
+const (
+	MetricPending  MetricEntryStatus = "PENDING"
+	MetricVerified MetricEntryStatus = "VERIFIED"
+	MetricRejected MetricEntryStatus = "REJECTED"
+)
+
+// MetricType model - an admin-defined impact metric
+type MetricType struct {
+	ID          uuid.UUID         `gorm:"type:uuid;primaryKey;column:id"`
+	Key         string            `gorm:"size:100;not null;uniqueIndex;column:key"`
+	Name        string            `gorm:"size:255;not null;column:name"`
+	Description string            `gorm:"type:text;column:description"`
+	ValueType   MetricValueType   `gorm:"type:varchar(20);not null;column:value_type"`
+	Aggregation MetricAggregation `gorm:"type:varchar(20);not null;default:'SUM';column:aggregation"`
+	Unit        string            `gorm:"size:50;column:unit"`      // e.g. jobs, USD
+	Bands       StringArray       `gorm:"type:text[];column:bands"` // Ordered options for BAND metrics
+	Active      bool              `gorm:"default:true;not null;column:active"`
+	CreatedAt   time.Time         `gorm:"column:created_at"`
+	UpdatedAt   time.Time         `gorm:"column:updated_at"`
+}
+
+func (MetricType) TableName() string {
+	return "metric_types"
+}
+
+func (m *MetricType) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// MetricEntry model - one reported value of a metric for an entrepreneur and period
+type MetricEntry struct {
+	ID               uuid.UUID         `gorm:"type:uuid;primaryKey;column:id"`
+	MetricTypeID     uuid.UUID         `gorm:"type:uuid;not null;index;column:metric_type_id"`
+	EntrepreneurID   uuid.UUID         `gorm:"type:uuid;not null;index;column:entrepreneur_id"`
+	HubID            uuid.UUID         `gorm:"type:uuid;not null;index;column:hub_id"` // Hub that verifies the entry
+	Value            *float64          `gorm:"column:value"`                           // INTEGER and DECIMAL metrics
+	Band             *string           `gorm:"size:100;column:band"`                   // BAND metrics
+	PeriodStart      time.Time         `gorm:"type:date;not null;column:period_start"`
+	PeriodEnd        time.Time         `gorm:"type:date;not null;index;column:period_end"`
+	Notes            *string           `gorm:"type:text;column:notes"`
+	Status           MetricEntryStatus `gorm:"type:varchar(20);not null;default:'PENDING';index;column:status"`
+	SubmittedBy      uuid.UUID         `gorm:"type:uuid;not null;column:submitted_by"`
+	VerifiedBy       *uuid.UUID        `gorm:"type:uuid;column:verified_by"`
+	VerifiedAt       *time.Time        `gorm:"column:verified_at"`
+	VerificationNote *string           `gorm:"type:text;column:verification_note"`
+	CreatedAt        time.Time         `gorm:"column:created_at"`
+	UpdatedAt        time.Time         `gorm:"column:updated_at"`
+
+	// Relations
+	MetricType   MetricType      `gorm:"foreignKey:MetricTypeID;constraint:OnDelete:CASCADE"`
+	Entrepreneur Entrepreneur    `gorm:"foreignKey:EntrepreneurID;constraint:OnDelete:CASCADE"`
+	Hub          CommunityCenter `gorm:"foreignKey:HubID;constraint:OnDelete:CASCADE"`
+}
+
+func (MetricEntry) TableName() string {
+	return "metric_entries"
+}
+
+func (m *MetricEntry) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// InvestorType classifies an investor in the directory
+type InvestorType string
+
+const (
+	InvestorAngel          InvestorType = "ANGEL"
+	InvestorVentureCapital InvestorType = "VENTURE_CAPITAL"
+	InvestorImpactFund     InvestorType = "IMPACT_FUND"
+	InvestorGrantMaker     InvestorType = "GRANT_MAKER"
+	InvestorCorporate      InvestorType = "CORPORATE"
+	InvestorOther          InvestorType = "OTHER"
+)
+
+// Investor model - a funder entrepreneurs can be introduced to
+type Investor struct {
+	ID           uuid.UUID    `gorm:"type:uuid;primaryKey;column:id"`
+	Name         string       `gorm:"size:255;not null;column:name"`
+	Slug         string       `gorm:"size:255;not null;uniqueIndex;column:slug"` // Normalized name, used to match free-text mentions
+	Type         InvestorType `gorm:"type:varchar(30);not null;default:'OTHER';column:type"`
+	Description  string       `gorm:"type:text;column:description"`
+	FocusSector  *string      `gorm:"size:100;index;column:focus_sector"`
+	TicketMin    *float64     `gorm:"column:ticket_min"`
+	TicketMax    *float64     `gorm:"column:ticket_max"`
+	Currency     string       `gorm:"size:3;not null;default:'USD';column:currency"`
+	Website      *string      `gorm:"size:500;column:website"`
+	ContactEmail *string      `gorm:"size:255;column:contact_email"`
+	Location     *string      `gorm:"size:255;column:location"`
+	Active       bool         `gorm:"default:true;not null;column:active"`
+	CreatedBy    *uuid.UUID   `gorm:"type:uuid;column:created_by"` // Nil for investors extracted by migration
+	CreatedAt    time.Time    `gorm:"column:created_at"`
+	UpdatedAt    time.Time    `gorm:"column:updated_at"`
+}
+
+func (Investor) TableName() string {
+	return "investors"
+}
+
+func (i *Investor) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// DealStage tracks an introduction through the funding pipeline
+type DealStage string
+
+const (
+	DealIntro     DealStage = "INTRO"
+	DealMeeting   DealStage = "MEETING"
+	DealTermSheet DealStage = "TERM_SHEET"
+	DealFunded    DealStage = "FUNDED"
+	DealPassed    DealStage = "PASSED"
+)
+
+// Deal model - an introduction of an entrepreneur to an investor by a hub
+type Deal struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primaryKey;column:id"`
+	InvestorID         uuid.UUID  `gorm:"type:uuid;not null;index;column:investor_id"`
+	EntrepreneurID     uuid.UUID  `gorm:"type:uuid;not null;index;column:entrepreneur_id"`
+	HubID              uuid.UUID  `gorm:"type:uuid;not null;index;column:hub_id"` // Introducing hub
+	Stage              DealStage  `gorm:"type:varchar(20);not null;default:'INTRO';index;column:stage"`
+	Amount             *float64   `gorm:"column:amount"` // Proposed or funded amount, in the investor's currency
+	Notes              *string    `gorm:"type:text;column:notes"`
+	IntroducedBy       *uuid.UUID `gorm:"type:uuid;column:introduced_by"`
+	ServiceProvisionID *uuid.UUID `gorm:"type:uuid;index;column:service_provision_id"` // Service the deal was extracted from, if any
+	ClosedAt           *time.Time `gorm:"column:closed_at"`                             // Set when funded or passed
+	CreatedAt          time.Time  `gorm:"column:created_at"`
+	UpdatedAt          time.Time  `gorm:"column:updated_at"`
+
+	// Relations
+	Investor     Investor        `gorm:"foreignKey:InvestorID;constraint:OnDelete:CASCADE"`
+	Entrepreneur Entrepreneur    `gorm:"foreignKey:EntrepreneurID;constraint:OnDelete:CASCADE"`
+	Hub          CommunityCenter `gorm:"foreignKey:HubID;constraint:OnDelete:CASCADE"`
+}
+
+func (Deal) TableName() string {
+	return "investor_deals"
+}
+
+func (d *Deal) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// Mentor model - a user offering mentorship through a hosting hub. Any user
+// can mentor; the profile, not the account role, makes them one.
+type Mentor struct {
+	ID                uuid.UUID   `gorm:"type:uuid;primaryKey;column:id"`
+	UserID            uuid.UUID   `gorm:"type:uuid;not null;uniqueIndex;column:user_id"`
+	HubID             uuid.UUID   `gorm:"type:uuid;not null;index;column:hub_id"` // Hosting hub, credited with completed sessions
+	Headline          string      `gorm:"size:255;column:headline"`
+	Bio               string      `gorm:"type:text;column:bio"`
+	Expertise         StringArray `gorm:"type:text[];column:expertise"` // Slugified tags
+	AcceptingRequests bool        `gorm:"default:true;not null;column:accepting_requests"`
+	Active            bool        `gorm:"default:true;not null;column:active"`
+	CreatedBy         *uuid.UUID  `gorm:"type:uuid;column:created_by"`
+	CreatedAt         time.Time   `gorm:"column:created_at"`
+	UpdatedAt         time.Time   `gorm:"column:updated_at"`
+
+	// Relations
+	User         User                 `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Hub          CommunityCenter      `gorm:"foreignKey:HubID;constraint:OnDelete:CASCADE"`
+	Availability []MentorAvailability `gorm:"foreignKey:MentorID"`
+}
+
+func (Mentor) TableName() string {
+	return "mentors"
+}
+
+func (m *Mentor) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// MentorAvailability model - a weekly window, in the hosting hub's time zone,
+// in which a mentor takes sessions
+type MentorAvailability struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
+	MentorID  uuid.UUID `gorm:"type:uuid;not null;index;column:mentor_id"`
+	Weekday   int       `gorm:"not null;column:weekday"`          // 0 = Sunday ... 6 = Saturday
+	StartsAt  string    `gorm:"size:5;not null;column:starts_at"` // "HH:MM"
+	EndsAt    string    `gorm:"size:5;not null;column:ends_at"`   // "HH:MM", "24:00" for midnight
+	CreatedAt time.Time `gorm:"column:created_at"`
+
+	// Relations
+	Mentor Mentor `gorm:"foreignKey:MentorID;constraint:OnDelete:CASCADE"`
+}
+
+func (MentorAvailability) TableName() string {
+	return "mentor_availability"
+}
+
+func (a *MentorAvailability) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// MentorRequestStatus tracks an entrepreneur's request for a mentor
+type MentorRequestStatus string
+
+const (
+	MentorRequestPending   MentorRequestStatus = "PENDING"
+	MentorRequestAccepted  MentorRequestStatus = "ACCEPTED"
+	MentorRequestDeclined  MentorRequestStatus = "DECLINED"
+	MentorRequestWithdrawn MentorRequestStatus = "WITHDRAWN"
+	MentorRequestClosed    MentorRequestStatus = "CLOSED" // Mentorship ended
+)
+
+// MentorRequest model - an entrepreneur asking a mentor for mentorship.
+// Sessions are scheduled against accepted requests.
+type MentorRequest struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primaryKey;column:id"`
+	MentorID       uuid.UUID           `gorm:"type:uuid;not null;index;column:mentor_id"`
+	EntrepreneurID uuid.UUID           `gorm:"type:uuid;not null;index;column:entrepreneur_id"`
+	Topic          string              `gorm:"size:255;not null;column:topic"`
+	Message        string              `gorm:"type:text;column:message"`
+	Status         MentorRequestStatus `gorm:"type:varchar(20);not null;default:'PENDING';index;column:status"`
+	ResponseNote   *string             `gorm:"type:text;column:response_note"`
+	RespondedBy    *uuid.UUID          `gorm:"type:uuid;column:responded_by"`
+	RespondedAt    *time.Time          `gorm:"column:responded_at"`
+	CreatedAt      time.Time           `gorm:"column:created_at"`
+	UpdatedAt      time.Time           `gorm:"column:updated_at"`
+
+	// Relations
+	Mentor       Mentor       `gorm:"foreignKey:MentorID;constraint:OnDelete:CASCADE"`
+	Entrepreneur Entrepreneur `gorm:"foreignKey:EntrepreneurID;constraint:OnDelete:CASCADE"`
+}
+
+func (MentorRequest) TableName() string {
+	return "mentor_requests"
+}
+
+func (r *MentorRequest) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// MentoringSessionStatus tracks a scheduled mentoring session
+type MentoringSessionStatus string
+
+const (
+	SessionScheduled MentoringSessionStatus = "SCHEDULED"
+	SessionCompleted MentoringSessionStatus = "COMPLETED"
+	SessionCancelled MentoringSessionStatus = "CANCELLED"
+)
+
+// MentoringSession model - a session between a mentor and a mentee. Completing
+// it logs a mentorship service provision at the hosting hub.
+type MentoringSession struct {
+	ID                 uuid.UUID              `gorm:"type:uuid;primaryKey;column:id"`
+	RequestID          uuid.UUID              `gorm:"type:uuid;not null;index;column:request_id"`
+	MentorID           uuid.UUID              `gorm:"type:uuid;not null;index;column:mentor_id"`
+	EntrepreneurID     uuid.UUID              `gorm:"type:uuid;not null;index;column:entrepreneur_id"`
+	HubID              uuid.UUID              `gorm:"type:uuid;not null;index;column:hub_id"` // Mentor's hosting hub when scheduled
+	ScheduledAt        time.Time              `gorm:"not null;index;column:scheduled_at"`
+	DurationMinutes    int                    `gorm:"not null;column:duration_minutes"`
+	Location           *string                `gorm:"size:500;column:location"` // Room or meeting link
+	Agenda             *string                `gorm:"type:text;column:agenda"`
+	Notes              *string                `gorm:"type:text;column:notes"`   // Shared between mentor and mentee
+	Outcome            *string                `gorm:"type:text;column:outcome"` // Copied to the service provision
+	Status             MentoringSessionStatus `gorm:"type:varchar(20);not null;default:'SCHEDULED';index;column:status"`
+	ServiceProvisionID *uuid.UUID             `gorm:"type:uuid;column:service_provision_id"` // Set on completion
+	CompletedAt        *time.Time             `gorm:"column:completed_at"`
+	CreatedBy          *uuid.UUID             `gorm:"type:uuid;column:created_by"`
+	CreatedAt          time.Time              `gorm:"column:created_at"`
+	UpdatedAt          time.Time              `gorm:"column:updated_at"`
+
+	// Relations
+	Request      MentorRequest   `gorm:"foreignKey:RequestID;constraint:OnDelete:CASCADE"`
+	Mentor       Mentor          `gorm:"foreignKey:MentorID;constraint:OnDelete:CASCADE"`
+	Entrepreneur Entrepreneur    `gorm:"foreignKey:EntrepreneurID;constraint:OnDelete:CASCADE"`
+	Hub          CommunityCenter `gorm:"foreignKey:HubID;constraint:OnDelete:CASCADE"`
+}
+
+func (MentoringSession) TableName() string {
+	return "mentoring_sessions"
+}
+
+func (s *MentoringSession) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// PortfolioItemKind classifies what an entrepreneur is showcasing
+type PortfolioItemKind string
+
+const (
+	PortfolioProduct   PortfolioItemKind = "PRODUCT"
+	PortfolioService   PortfolioItemKind = "SERVICE"
+	PortfolioPitchDeck PortfolioItemKind = "PITCH_DECK"
+	PortfolioOther     PortfolioItemKind = "OTHER"
+)
+
+// PortfolioItem model - a product, service or pitch an entrepreneur shows to
+// hubs and investors
+type PortfolioItem struct {
+	ID             uuid.UUID         `gorm:"type:uuid;primaryKey;column:id"`
+	EntrepreneurID uuid.UUID         `gorm:"type:uuid;not null;index;column:entrepreneur_id"`
+	Kind           PortfolioItemKind `gorm:"type:varchar(20);not null;default:'PRODUCT';column:kind"`
+	Title          string            `gorm:"size:255;not null;column:title"`
+	Description    string            `gorm:"type:text;column:description"`
+	Price          *float64          `gorm:"column:price"`
+	Currency       string            `gorm:"size:3;not null;default:'UGX';column:currency"`
+	Visibility     Visibility        `gorm:"type:varchar(10);not null;default:'PUBLIC';column:visibility"`
+	Position       int               `gorm:"not null;default:0;column:position"` // Display order on the profile
+	CreatedBy      uuid.UUID         `gorm:"type:uuid;not null;column:created_by"`
+	CreatedAt      time.Time         `gorm:"column:created_at"`
+	UpdatedAt      time.Time         `gorm:"column:updated_at"`
+
+	// Relations
+	Entrepreneur Entrepreneur     `gorm:"foreignKey:EntrepreneurID;constraint:OnDelete:CASCADE"`
+	Media        []PortfolioMedia `gorm:"foreignKey:ItemID"`
+}
+
+func (PortfolioItem) TableName() string {
+	return "portfolio_items"
+}
+
+func (p *PortfolioItem) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// MediaKind is the broad type of an uploaded file
+type MediaKind string
+
+const (
+	MediaImage    MediaKind = "IMAGE"
+	MediaDocument MediaKind = "DOCUMENT"
+	MediaVideo    MediaKind = "VIDEO"
+)
+
+// PortfolioMedia model - a file attached to a portfolio item. The file itself
+// lives in blob storage under StorageKey.
+type PortfolioMedia struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;column:id"`
+	ItemID       uuid.UUID `gorm:"type:uuid;not null;index;column:item_id"`
+	Kind         MediaKind `gorm:"type:varchar(20);not null;column:kind"`
+	StorageKey   string    `gorm:"size:500;not null;uniqueIndex;column:storage_key"`
+	FileName     string    `gorm:"size:255;not null;column:file_name"` // As uploaded, for downloads
+	ContentType  string    `gorm:"size:100;not null;column:content_type"`
+	SizeBytes    int64     `gorm:"not null;column:size_bytes"`
+	ThumbnailKey *string   `gorm:"size:500;column:thumbnail_key"` // Images only
+	Caption      *string   `gorm:"size:500;column:caption"`
+	Position     int       `gorm:"not null;default:0;column:position"`
+	UploadedBy   uuid.UUID `gorm:"type:uuid;not null;column:uploaded_by"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+
+	// Relations
+	Item PortfolioItem `gorm:"foreignKey:ItemID;constraint:OnDelete:CASCADE"`
+}
+
+func (PortfolioMedia) TableName() string {
+	return "portfolio_media"
+}
+
+func (m *PortfolioMedia) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// UploadEntity names what an uploaded file is attached to
+type UploadEntity string
+
+const (
+	UploadForActivity     UploadEntity = "ACTIVITY"
+	UploadForCenter       UploadEntity = "CENTER"
+	UploadForEntrepreneur UploadEntity = "ENTREPRENEUR"
+	UploadForMessage      UploadEntity = "MESSAGE"
+)
+
+// Upload model - a file in blob storage. Files are uploaded first and then
+// attached to an activity, center, entrepreneur or message; until attached
+// only the uploader can see them.
+type Upload struct {
+	ID           uuid.UUID    `gorm:"type:uuid;primaryKey;column:id"`
+	UploadedBy   uuid.UUID    `gorm:"type:uuid;not null;index;column:uploaded_by"`
+	EntityType   UploadEntity `gorm:"type:varchar(20);index:idx_uploads_entity;column:entity_type"`
+	EntityID     *uuid.UUID   `gorm:"type:uuid;index:idx_uploads_entity;column:entity_id"`
+	StorageKey   string       `gorm:"size:500;not null;uniqueIndex;column:storage_key"`
+	ThumbnailKey *string      `gorm:"size:500;column:thumbnail_key"` // Images only
+	FileName     string       `gorm:"size:255;not null;column:file_name"`
+	ContentType  string       `gorm:"size:100;not null;column:content_type"`
+	Kind         MediaKind    `gorm:"type:varchar(20);not null;column:kind"`
+	SizeBytes    int64        `gorm:"not null;column:size_bytes"`
+	Width        *int         `gorm:"column:width"` // Images only
+	Height       *int         `gorm:"column:height"`
+	CreatedAt    time.Time    `gorm:"column:created_at"`
+
+	// Relations
+	Uploader User `gorm:"foreignKey:UploadedBy"`
+}
+
+func (Upload) TableName() string {
+	return "uploads"
+}
+
+func (u *Upload) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
+
+// CenterImageRole says where a center's image is shown
+type CenterImageRole string
+
+const (
+	CenterImageCover   CenterImageRole = "COVER"
+	CenterImageLogo    CenterImageRole = "LOGO"
+	CenterImageGallery CenterImageRole = "GALLERY"
+)
+
+// ImageRendition is one resized copy of an image in blob storage
+type ImageRendition struct {
+	Size        string `json:"size"`
+	Key         string `json:"key"`
+	ContentType string `json:"contentType"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// ImageRenditions type for PostgreSQL JSONB columns listing an image's sizes,
+// smallest first
+type ImageRenditions []ImageRendition
+
+func (r ImageRenditions) Value() (driver.Value, error) {
+	if r == nil {
+		r = ImageRenditions{}
+	}
+	data, err := json.Marshal(r)
+	return string(data), err
+}
+
+func (r *ImageRenditions) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, r)
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	}
+	return fmt.Errorf("cannot scan %T into ImageRenditions", value)
+}
+
+// CenterImage model - a center's cover, logo or gallery photo. Only the
+// resized renditions are kept, never the upload itself.
+type CenterImage struct {
+	ID         uuid.UUID       `gorm:"type:uuid;primaryKey;column:id"`
+	CenterID   uuid.UUID       `gorm:"type:uuid;not null;index;column:center_id"`
+	Role       CenterImageRole `gorm:"type:varchar(20);not null;column:role"`
+	Caption    *string         `gorm:"size:500;column:caption"`            // Alt text
+	Position   int             `gorm:"not null;default:0;column:position"` // Gallery order
+	Width      int             `gorm:"not null;column:width"`              // Of the upload
+	Height     int             `gorm:"not null;column:height"`
+	Renditions ImageRenditions `gorm:"type:jsonb;not null;column:renditions"`
+	UploadedBy uuid.UUID       `gorm:"type:uuid;not null;column:uploaded_by"`
+	CreatedAt  time.Time       `gorm:"column:created_at"`
+	UpdatedAt  time.Time       `gorm:"column:updated_at"`
+
+	// Relations
+	Center CommunityCenter `gorm:"foreignKey:CenterID;constraint:OnDelete:CASCADE"`
+}
+
+func (CenterImage) TableName() string {
+	return "center_images"
+}
+
+func (i *CenterImage) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
 // AutoMigrate runs migrations for all models
 func AutoMigrate(gdb *gorm.DB) error {
-	return gdb.AutoMigrate(
+	if err := gdb.AutoMigrate(
 		&User{},
 		&CommunityCenter{},
 		&Connection{},
 		&ContactMessage{},
 		&MessageThread{},
 		&CenterMessage{},
+		&MessageRevision{},
+		&ThreadReadCursor{},
+		&ThreadParticipantState{},
 		&Entrepreneur{},
 		&HubEnrollment{},
 		&ServiceProvision{},
 		&HubActivity{},
 		&RoleUpgradeRequest{},
-	)
+		&ServiceCategory{},
+		&OpeningHours{},
+		&HoursException{},
+		&BookableResource{},
+		&Booking{},
+		&HubEvent{},
+		&EventRSVP{},
+		&Program{},
+		&Cohort{},
+		&CohortMilestone{},
+		&MilestoneCompletion{},
+		&ApplicationForm{},
+		&Application{},
+		&ApplicationReview{},
+		&StatusTransition{},
+		&MetricType{},
+		&MetricEntry{},
+		&Investor{},
+		&Deal{},
+		&Mentor{},
+		&MentorAvailability{},
+		&MentorRequest{},
+		&MentoringSession{},
+		&PortfolioItem{},
+		&PortfolioMedia{},
+		&Upload{},
+		&CenterImage{},
+	); err != nil {
+		return err
+	}
+	if err := ensureBookingConstraints(gdb); err != nil {
+		return err
+	}
+	return migrateMessageReadFlags(gdb)
 }
 
 
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func orderedMedia(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC, created_at ASC")
+}
+
+// ListPortfolioItems retrieves an entrepreneur's items with their media, in
+// display order. A nil visibilities list returns every item.
+func ListPortfolioItems(db *gorm.DB, entrepreneurID uuid.UUID, visibilities []Visibility) ([]PortfolioItem, error) {
+	var items []PortfolioItem
+	query := db.Preload("Media", orderedMedia).Where("entrepreneur_id = ?", entrepreneurID)
+	if visibilities != nil {
+		query = query.Where("visibility IN ?", visibilities)
+	}
+	err := query.Order("position ASC, created_at ASC").Find(&items).Error
+	return items, err
+}
+
+// FindPortfolioItemByID retrieves an item with its media and entrepreneur
+func FindPortfolioItemByID(db *gorm.DB, id uuid.UUID) (*PortfolioItem, error) {
+	var item PortfolioItem
+	err := db.Preload("Media", orderedMedia).Preload("Entrepreneur").Where("id = ?", id).First(&item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// SavePortfolioItem creates or updates an item
+func SavePortfolioItem(db *gorm.DB, item *PortfolioItem) error {
+	return db.Omit(clause.Associations).Save(item).Error
+}
+
+// DeletePortfolioItem removes an item and its media records; the caller
+// deletes the stored files
+func DeletePortfolioItem(db *gorm.DB, id uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("item_id = ?", id).Delete(&PortfolioMedia{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&HubActivity{}).Where("portfolio_item_id = ?", id).Update("portfolio_item_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&PortfolioItem{}).Error
+	})
+}
+
+// CreatePortfolioMedia records an uploaded file
+func CreatePortfolioMedia(db *gorm.DB, media *PortfolioMedia) error {
+	return db.Omit(clause.Associations).Create(media).Error
+}
+
+// FindPortfolioMedia retrieves a file record belonging to an item
+func FindPortfolioMedia(db *gorm.DB, itemID, mediaID uuid.UUID) (*PortfolioMedia, error) {
+	var media PortfolioMedia
+	err := db.Where("id = ? AND item_id = ?", mediaID, itemID).First(&media).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &media, nil
+}
+
+// DeletePortfolioMedia removes a file record
+func DeletePortfolioMedia(db *gorm.DB, id uuid.UUID) error {
+	return db.Where("id = ?", id).Delete(&PortfolioMedia{}).Error
+}
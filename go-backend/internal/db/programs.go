@@ -0,0 +1,170 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ListPrograms retrieves a hub's programs with their cohorts
+func ListPrograms(db *gorm.DB, hubID uuid.UUID, includeInactive bool) ([]Program, error) {
+	var programs []Program
+	query := db.Preload("Cohorts", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("start_date ASC")
+	}).Where("hub_id = ?", hubID)
+	if !includeInactive {
+		query = query.Where("active = ?", true)
+	}
+	err := query.Order("name ASC").Find(&programs).Error
+	return programs, err
+}
+
+// FindProgramByID retrieves a program with its hub and cohorts
+func FindProgramByID(db *gorm.DB, id uuid.UUID) (*Program, error) {
+	var program Program
+	err := db.Preload("Hub").Preload("Cohorts", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("start_date ASC")
+	}).Where("id = ?", id).First(&program).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &program, nil
+}
+
+// SaveProgram creates or updates a program
+func SaveProgram(db *gorm.DB, program *Program) error {
+	return db.Omit(clause.Associations).Save(program).Error
+}
+
+// FindCohortByID retrieves a cohort with its program and ordered milestones
+func FindCohortByID(db *gorm.DB, id uuid.UUID) (*Cohort, error) {
+	var cohort Cohort
+	err := db.Preload("Program.Hub").Preload("Milestones", func(tx *gorm.DB) *gorm.DB {
+		return tx.Order("position ASC, created_at ASC")
+	}).Where("id = ?", id).First(&cohort).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cohort, nil
+}
+
+// SaveCohort creates or updates a cohort
+func SaveCohort(db *gorm.DB, cohort *Cohort) error {
+	return db.Omit(clause.Associations).Save(cohort).Error
+}
+
+// CountCohortEnrollments counts enrollments holding a place in each cohort;
+// suspended enrollments free their place
+func CountCohortEnrollments(db *gorm.DB, cohortIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64, len(cohortIDs))
+	if len(cohortIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		CohortID uuid.UUID
+		Count    int64
+	}
+	err := db.Model(&HubEnrollment{}).
+		Select("cohort_id, COUNT(*) AS count").
+		Where("cohort_id IN ? AND status <> ?", cohortIDs, EnrollmentSuspended).
+		Group("cohort_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rows {
+		counts[r.CohortID] = r.Count
+	}
+	return counts, nil
+}
+
+// ListCohortEnrollments retrieves a cohort's enrollments with entrepreneurs
+func ListCohortEnrollments(db *gorm.DB, cohortID uuid.UUID) ([]HubEnrollment, error) {
+	var enrollments []HubEnrollment
+	err := db.Preload("Entrepreneur.User").
+		Where("cohort_id = ?", cohortID).
+		Order("created_at ASC").
+		Find(&enrollments).Error
+	return enrollments, err
+}
+
+// FindCohortMilestone retrieves a milestone belonging to a cohort
+func FindCohortMilestone(db *gorm.DB, cohortID, milestoneID uuid.UUID) (*CohortMilestone, error) {
+	var milestone CohortMilestone
+	if err := db.Where("id = ? AND cohort_id = ?", milestoneID, cohortID).First(&milestone).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &milestone, nil
+}
+
+// SaveCohortMilestone creates or updates a milestone
+func SaveCohortMilestone(db *gorm.DB, milestone *CohortMilestone) error {
+	return db.Omit(clause.Associations).Save(milestone).Error
+}
+
+// DeleteCohortMilestone removes a milestone and its completions
+func DeleteCohortMilestone(db *gorm.DB, milestone *CohortMilestone) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("milestone_id = ?", milestone.ID).Delete(&MilestoneCompletion{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(milestone).Error
+	})
+}
+
+// MoveEnrollmentToCohort moves an enrollment into a cohort, or out of any
+// with a nil cohortID. Milestones belong to one cohort, so the enrollment's
+// completions are cleared with the cohort they were recorded against.
+func MoveEnrollmentToCohort(db *gorm.DB, enrollment *HubEnrollment, cohortID *uuid.UUID) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&HubEnrollment{}).Where("id = ?", enrollment.ID).Update("cohort_id", cohortID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("enrollment_id = ?", enrollment.ID).Delete(&MilestoneCompletion{}).Error; err != nil {
+			return err
+		}
+		enrollment.CohortID = cohortID
+		return nil
+	})
+}
+
+// ListMilestoneCompletions retrieves completions for the given enrollments
+func ListMilestoneCompletions(db *gorm.DB, enrollmentIDs []uuid.UUID) ([]MilestoneCompletion, error) {
+	var completions []MilestoneCompletion
+	if len(enrollmentIDs) == 0 {
+		return completions, nil
+	}
+	err := db.Where("enrollment_id IN ?", enrollmentIDs).Find(&completions).Error
+	return completions, err
+}
+
+// CompleteMilestone records a milestone for an enrollment; completing it
+// again is a no-op
+func CompleteMilestone(db *gorm.DB, enrollmentID, milestoneID uuid.UUID, by *uuid.UUID, notes *string) error {
+	completion := MilestoneCompletion{
+		EnrollmentID: enrollmentID,
+		MilestoneID:  milestoneID,
+		CompletedAt:  time.Now(),
+		CompletedBy:  by,
+		Notes:        notes,
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&completion).Error
+}
+
+// UncompleteMilestone removes a milestone completion
+func UncompleteMilestone(db *gorm.DB, enrollmentID, milestoneID uuid.UUID) error {
+	return db.Where("enrollment_id = ? AND milestone_id = ?", enrollmentID, milestoneID).
+		Delete(&MilestoneCompletion{}).Error
+}
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReportFilters bounds the records behind a funder report. Records count
+// when they were running at any point between From and To (inclusive dates).
+type ReportFilters struct {
+	From   time.Time
+	To     time.Time
+	HubIDs []uuid.UUID // Empty means every hub
+}
+
+// until is the exclusive upper bound for the inclusive To date
+func (f ReportFilters) until() time.Time {
+	return f.To.AddDate(0, 0, 1)
+}
+
+// ListReportEnrollments retrieves non-pending enrollments that overlap the
+// report period, with their hub and entrepreneur
+func ListReportEnrollments(db *gorm.DB, filters ReportFilters) ([]HubEnrollment, error) {
+	var enrollments []HubEnrollment
+	query := db.Preload("Hub").Preload("Entrepreneur").
+		Where("status <> ?", EnrollmentPending).
+		Where("COALESCE(enrollment_date, created_at) < ?", filters.until()).
+		Where("(completion_date IS NULL OR completion_date >= ?)", filters.From)
+	if len(filters.HubIDs) > 0 {
+		query = query.Where("hub_id IN ?", filters.HubIDs)
+	}
+	err := query.Order("created_at ASC").Find(&enrollments).Error
+	return enrollments, err
+}
+
+// ListReportServices retrieves service provisions that overlap the report
+// period. With includeCollaborating, services a filtered hub co-delivered
+// are included as well as the ones it led.
+func ListReportServices(db *gorm.DB, filters ReportFilters, includeCollaborating bool) ([]ServiceProvision, error) {
+	var services []ServiceProvision
+	query := db.Preload("Hub").Preload("Entrepreneur").Preload("CollaboratingHub").
+		Where("COALESCE(start_date, created_at) < ?", filters.until()).
+		Where("(completion_date IS NULL OR completion_date >= ?)", filters.From)
+	if len(filters.HubIDs) > 0 {
+		if includeCollaborating {
+			query = query.Where("(hub_id IN ? OR collaborating_hub_id IN ?)", filters.HubIDs, filters.HubIDs)
+		} else {
+			query = query.Where("hub_id IN ?", filters.HubIDs)
+		}
+	}
+	err := query.Order("created_at ASC").Find(&services).Error
+	return services, err
+}
+
+// ListReportActivities retrieves hub activities of one type posted during
+// the report period, by or with the filtered hubs
+func ListReportActivities(db *gorm.DB, filters ReportFilters, activityType ActivityType) ([]HubActivity, error) {
+	var activities []HubActivity
+	query := db.Preload("Hub").Preload("CollaboratingHub").
+		Where("type = ? AND created_at >= ? AND created_at < ?", activityType, filters.From, filters.until())
+	if len(filters.HubIDs) > 0 {
+		query = query.Where("(hub_id IN ? OR collaborating_hub_id IN ?)", filters.HubIDs, filters.HubIDs)
+	}
+	err := query.Order("created_at ASC").Find(&activities).Error
+	return activities, err
+}
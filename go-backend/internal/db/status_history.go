@@ -0,0 +1,22 @@
+package db
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordStatusTransition appends an entry to a record's status history
+func RecordStatusTransition(db *gorm.DB, t *StatusTransition) error {
+	return db.Omit(clause.Associations).Create(t).Error
+}
+
+// ListStatusTransitions retrieves a record's status history, oldest first
+func ListStatusTransitions(db *gorm.DB, entity StatusEntity, entityID uuid.UUID) ([]StatusTransition, error) {
+	var transitions []StatusTransition
+	err := db.Preload("Actor").
+		Where("entity_type = ? AND entity_id = ?", entity, entityID).
+		Order("created_at ASC").
+		Find(&transitions).Error
+	return transitions, err
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListServiceCategories retrieves taxonomy entries, optionally of one kind
+func ListServiceCategories(db *gorm.DB, kind TaxonomyKind) ([]ServiceCategory, error) {
+	query := db.Model(&ServiceCategory{})
+	if kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+
+	var categories []ServiceCategory
+	err := query.Order("kind ASC, name ASC").Find(&categories).Error
+	return categories, err
+}
+
+// FindServiceCategoryByID retrieves a taxonomy entry by ID
+func FindServiceCategoryByID(db *gorm.DB, id uuid.UUID) (*ServiceCategory, error) {
+	var category ServiceCategory
+	err := db.Where("id = ?", id).First(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// FindServiceCategoryBySlug retrieves a taxonomy entry by kind and slug
+func FindServiceCategoryBySlug(db *gorm.DB, kind TaxonomyKind, slug string) (*ServiceCategory, error) {
+	var category ServiceCategory
+	err := db.Where("kind = ? AND slug = ?", kind, slug).First(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// CreateServiceCategory creates a new taxonomy entry
+func CreateServiceCategory(db *gorm.DB, category *ServiceCategory) error {
+	return db.Create(category).Error
+}
+
+// UpdateServiceCategory updates an existing taxonomy entry. Centers hold
+// entry names, so a rename is carried over to them, and an entry moved to the
+// other kind is taken off the centers listing it under the old one.
+func UpdateServiceCategory(db *gorm.DB, category *ServiceCategory) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		var previous ServiceCategory
+		if err := tx.Where("id = ?", category.ID).First(&previous).Error; err != nil {
+			return err
+		}
+		if err := tx.Save(category).Error; err != nil {
+			return err
+		}
+		switch {
+		case previous.Kind != category.Kind:
+			return removeCenterTerm(tx, previous.Kind, previous.Name)
+		case previous.Name != category.Name:
+			return renameCenterTerm(tx, category.Kind, previous.Name, category.Name)
+		}
+		return nil
+	})
+}
+
+// DeleteServiceCategory removes a taxonomy entry; children are re-parented
+// to the deleted entry's parent so the hierarchy stays connected, and the
+// entry is taken off every center listing it
+func DeleteServiceCategory(db *gorm.DB, category *ServiceCategory) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&ServiceCategory{}).
+			Where("parent_id = ?", category.ID).
+			Update("parent_id", category.ParentID).Error; err != nil {
+			return err
+		}
+		if err := removeCenterTerm(tx, category.Kind, category.Name); err != nil {
+			return err
+		}
+		return tx.Delete(category).Error
+	})
+}
+
+// centerTermColumn is the center array holding entries of a taxonomy kind
+func centerTermColumn(kind TaxonomyKind) string {
+	if kind == TaxonomyResource {
+		return "resources"
+	}
+	return "services"
+}
+
+// renameCenterTerm replaces a term in every center's array for its kind,
+// dropping it instead where the center already lists the new name
+func renameCenterTerm(tx *gorm.DB, kind TaxonomyKind, from, to string) error {
+	col := centerTermColumn(kind)
+	return tx.Model(&CommunityCenter{}).
+		Where("? = ANY("+col+")", from).
+		Update(col, gorm.Expr("CASE WHEN ? = ANY("+col+") THEN array_remove("+col+", ?) ELSE array_replace("+col+", ?, ?) END", to, from, from, to)).Error
+}
+
+// removeCenterTerm takes a term out of every center's array for its kind
+func removeCenterTerm(tx *gorm.DB, kind TaxonomyKind, term string) error {
+	col := centerTermColumn(kind)
+	return tx.Model(&CommunityCenter{}).
+		Where("? = ANY("+col+")", term).
+		Update(col, gorm.Expr("array_remove("+col+", ?)", term)).Error
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateUpload records a stored file
+func CreateUpload(db *gorm.DB, upload *Upload) error {
+	return db.Omit(clause.Associations).Create(upload).Error
+}
+
+// FindUploadByID retrieves a file record
+func FindUploadByID(db *gorm.DB, id uuid.UUID) (*Upload, error) {
+	var upload Upload
+	err := db.Where("id = ?", id).First(&upload).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// ListUploadsByID retrieves the given file records; missing ones are skipped
+func ListUploadsByID(db *gorm.DB, ids []uuid.UUID) ([]Upload, error) {
+	var uploads []Upload
+	if len(ids) == 0 {
+		return uploads, nil
+	}
+	err := db.Where("id IN ?", ids).Find(&uploads).Error
+	return uploads, err
+}
+
+// ListUploadsFor retrieves the files attached to any of the given entities,
+// oldest first
+func ListUploadsFor(db *gorm.DB, entityType UploadEntity, entityIDs []uuid.UUID) ([]Upload, error) {
+	var uploads []Upload
+	if len(entityIDs) == 0 {
+		return uploads, nil
+	}
+	err := db.Where("entity_type = ? AND entity_id IN ?", entityType, entityIDs).
+		Order("created_at ASC").
+		Find(&uploads).Error
+	return uploads, err
+}
+
+// ErrUploadsClaimed is returned when a file was attached elsewhere after it
+// was checked
+var ErrUploadsClaimed = errors.New("files were attached elsewhere; upload them again")
+
+// AttachUploads links unattached files to an entity. It attaches all of them
+// or, when one was claimed meanwhile, returns ErrUploadsClaimed; callers run
+// it in the transaction creating the entity so that rolls back too.
+func AttachUploads(db *gorm.DB, ids []uuid.UUID, entityType UploadEntity, entityID uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	result := db.Model(&Upload{}).Where("id IN ? AND entity_id IS NULL", ids).Updates(map[string]interface{}{
+		"entity_type": entityType,
+		"entity_id":   entityID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != int64(len(ids)) {
+		return ErrUploadsClaimed
+	}
+	return nil
+}
+
+// DeleteUploads removes file records; the caller deletes the stored files
+func DeleteUploads(db *gorm.DB, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return db.Where("id IN ?", ids).Delete(&Upload{}).Error
+}
@@ -0,0 +1,119 @@
+package directory
+
+import (
+	"fmt"
+	"strings"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// Audience is how close a viewer is to an entrepreneur, from strangers to
+// the entrepreneur themselves. Each level sees everything the ones below it do.
+type Audience int
+
+const (
+	AudiencePublic Audience = iota // Not signed in
+	AudienceMember                 // Any signed-in user
+	AudienceHub                    // Manager of a hub the entrepreneur is enrolled with
+	AudienceOwner                  // The entrepreneur or an admin
+)
+
+// Settings are an entrepreneur's privacy choices
+type Settings struct {
+	Profile db.Visibility
+	Phone   db.Visibility
+	Email   db.Visibility
+	Website db.Visibility
+}
+
+// Defaults keep contact details private until the entrepreneur opts in,
+// while listing the business and its website publicly
+var Defaults = Settings{
+	Profile: db.VisibilityPublic,
+	Phone:   db.VisibilityPrivate,
+	Email:   db.VisibilityPrivate,
+	Website: db.VisibilityPublic,
+}
+
+// ParseVisibility accepts a visibility in any case
+func ParseVisibility(s string) (db.Visibility, error) {
+	v := db.Visibility(strings.ToUpper(strings.TrimSpace(s)))
+	switch v {
+	case db.VisibilityPublic, db.VisibilityNetwork, db.VisibilityPrivate:
+		return v, nil
+	}
+	return "", fmt.Errorf("visibility must be PUBLIC, NETWORK or PRIVATE, got %q", s)
+}
+
+// CanSee reports whether an audience may see something with the given
+// visibility. Unknown values are treated as private.
+func CanSee(v db.Visibility, a Audience) bool {
+	switch v {
+	case db.VisibilityPublic:
+		return true
+	case db.VisibilityNetwork:
+		return a >= AudienceMember
+	}
+	return a >= AudienceHub
+}
+
+// SettingsOf reads an entrepreneur's settings, filling blanks with defaults
+func SettingsOf(e *db.Entrepreneur) Settings {
+	pick := func(v, def db.Visibility) db.Visibility {
+		if v == "" {
+			return def
+		}
+		return v
+	}
+	return Settings{
+		Profile: pick(e.ProfileVisibility, Defaults.Profile),
+		Phone:   pick(e.PhoneVisibility, Defaults.Phone),
+		Email:   pick(e.EmailVisibility, Defaults.Email),
+		Website: pick(e.WebsiteVisibility, Defaults.Website),
+	}
+}
+
+// Apply stores settings on an entrepreneur
+func (s Settings) Apply(e *db.Entrepreneur) {
+	e.ProfileVisibility = s.Profile
+	e.PhoneVisibility = s.Phone
+	e.EmailVisibility = s.Email
+	e.WebsiteVisibility = s.Website
+}
+
+// Listed reports whether the entrepreneur appears in the directory for an
+// audience. Only verified profiles are listed to anyone outside their hubs.
+func Listed(e *db.Entrepreneur, a Audience) bool {
+	if a >= AudienceHub {
+		return true
+	}
+	return e.Verified && CanSee(SettingsOf(e).Profile, a)
+}
+
+// Contact holds the contact details an audience may see; hidden ones are nil
+type Contact struct {
+	Phone   *string
+	Email   *string
+	Website *string
+}
+
+// ContactFor reveals an entrepreneur's contact details according to their
+// settings. The account email stands in when no business email is set.
+func ContactFor(e *db.Entrepreneur, a Audience) Contact {
+	s := SettingsOf(e)
+	var out Contact
+	if CanSee(s.Phone, a) {
+		out.Phone = e.Phone
+	}
+	if CanSee(s.Email, a) {
+		out.Email = e.Email
+		if out.Email == nil && e.User.Email != "" {
+			email := e.User.Email
+			out.Email = &email
+		}
+	}
+	if CanSee(s.Website, a) {
+		out.Website = e.Website
+	}
+	return out
+}
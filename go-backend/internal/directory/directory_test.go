@@ -0,0 +1,83 @@
+package directory
+
+import (
+	"testing"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func ptr(s string) *string { return &s }
+
+func TestCanSee(t *testing.T) {
+	cases := []struct {
+		v    db.Visibility
+		a    Audience
+		want bool
+	}{
+		{db.VisibilityPublic, AudiencePublic, true},
+		{db.VisibilityNetwork, AudiencePublic, false},
+		{db.VisibilityNetwork, AudienceMember, true},
+		{db.VisibilityPrivate, AudienceMember, false},
+		{db.VisibilityPrivate, AudienceHub, true},
+		{db.VisibilityPrivate, AudienceOwner, true},
+		{"", AudienceMember, false},
+	}
+	for _, tc := range cases {
+		if got := CanSee(tc.v, tc.a); got != tc.want {
+			t.Errorf("CanSee(%q, %d) = %v, want %v", tc.v, tc.a, got, tc.want)
+		}
+	}
+}
+
+func TestParseVisibility(t *testing.T) {
+	if v, err := ParseVisibility(" network "); err != nil || v != db.VisibilityNetwork {
+		t.Fatalf("got %q, %v", v, err)
+	}
+	if _, err := ParseVisibility("friends"); err == nil {
+		t.Fatal("expected unknown visibility to be rejected")
+	}
+}
+
+func TestContactFor(t *testing.T) {
+	e := &db.Entrepreneur{
+		Phone:             ptr("+256 700 000000"),
+		Website:           ptr("https://example.com"),
+		Verified:          true,
+		PhoneVisibility:   db.VisibilityPrivate,
+		EmailVisibility:   db.VisibilityNetwork,
+		WebsiteVisibility: db.VisibilityPublic,
+		User:              db.User{Email: "founder@example.com"},
+	}
+
+	public := ContactFor(e, AudiencePublic)
+	if public.Phone != nil || public.Email != nil || public.Website == nil {
+		t.Fatalf("public should only see the website, got %+v", public)
+	}
+	member := ContactFor(e, AudienceMember)
+	if member.Phone != nil || member.Email == nil || *member.Email != "founder@example.com" {
+		t.Fatalf("members should see the account email but not the phone, got %+v", member)
+	}
+	if hub := ContactFor(e, AudienceHub); hub.Phone == nil {
+		t.Fatal("the entrepreneur's hubs should see a private phone")
+	}
+
+	// Blank settings fall back to private contact details
+	legacy := &db.Entrepreneur{Phone: ptr("123"), Email: ptr("biz@example.com")}
+	if got := ContactFor(legacy, AudienceMember); got.Phone != nil || got.Email != nil {
+		t.Fatalf("expected defaults to hide contact details, got %+v", got)
+	}
+}
+
+func TestListed(t *testing.T) {
+	e := &db.Entrepreneur{Verified: true, ProfileVisibility: db.VisibilityNetwork}
+	if Listed(e, AudiencePublic) {
+		t.Fatal("network profiles should not be listed publicly")
+	}
+	if !Listed(e, AudienceMember) {
+		t.Fatal("network profiles should be listed to members")
+	}
+	e.Verified = false
+	if Listed(e, AudienceMember) || !Listed(e, AudienceHub) {
+		t.Fatal("unverified profiles should only be visible to their hubs")
+	}
+}
@@ -10,6 +10,15 @@ type Event struct {
     ID      int64       `json:"id"`
     Type    string      `json:"type"`
     Payload interface{} `json:"payload"`
+    To      Audience    `json:"-"`
+}
+
+// Audience records who an event was emitted for, so that replaying the
+// backlog to a reconnecting client can be limited to what it may see
+type Audience struct {
+	ThreadID  string   // Participants of the thread
+	CenterIDs []string // Managers of any of the centers
+	UserID    string   // The one user, e.g. who made a booking
 }
 
 type Client struct {
@@ -82,7 +91,7 @@ func (b *Broker) UnsubscribeThread(userId, threadId string) {
 // Emit helpers
 func (b *Broker) EmitCenterUpdate(centerId string, payload interface{}) {
 	b.mu.Lock()
-	ev := b.nextEvent("center-updated", payload)
+	ev := b.nextEvent("center-updated", payload, Audience{CenterIDs: []string{centerId}})
 	b.mu.Unlock()
 
 	b.mu.RLock()
@@ -96,7 +105,7 @@ func (b *Broker) EmitCenterUpdate(centerId string, payload interface{}) {
 
 func (b *Broker) EmitNewMessage(threadId string, payload interface{}) {
 	b.mu.Lock()
-	ev := b.nextEvent("new-message", payload)
+	ev := b.nextEvent("new-message", payload, Audience{ThreadID: threadId})
 	b.mu.Unlock()
 
 	b.mu.RLock()
@@ -108,9 +117,41 @@ func (b *Broker) EmitNewMessage(threadId string, payload interface{}) {
 	}
 }
 
+// EmitMessageUpdated notifies subscribers of the thread that a message was
+// edited
+func (b *Broker) EmitMessageUpdated(threadId string, payload interface{}) {
+	b.mu.Lock()
+	ev := b.nextEvent("message-updated", payload, Audience{ThreadID: threadId})
+	b.mu.Unlock()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, cl := range b.clients {
+		if _, ok := cl.Threads[threadId]; ok {
+			select { case cl.Send <- ev: default: }
+		}
+	}
+}
+
+// EmitMessageDeleted notifies subscribers of the thread that a message was
+// deleted
+func (b *Broker) EmitMessageDeleted(threadId string, payload interface{}) {
+	b.mu.Lock()
+	ev := b.nextEvent("message-deleted", payload, Audience{ThreadID: threadId})
+	b.mu.Unlock()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, cl := range b.clients {
+		if _, ok := cl.Threads[threadId]; ok {
+			select { case cl.Send <- ev: default: }
+		}
+	}
+}
+
 func (b *Broker) EmitUserTyping(threadId string, payload interface{}) {
 	b.mu.Lock()
-	ev := b.nextEvent("user-typing", payload)
+	ev := b.nextEvent("user-typing", payload, Audience{ThreadID: threadId})
 	b.mu.Unlock()
 
 	b.mu.RLock()
@@ -122,6 +163,62 @@ func (b *Broker) EmitUserTyping(threadId string, payload interface{}) {
 	}
 }
 
+// EmitBookingUpdate notifies subscribers of the booking's center and the
+// user who made the booking
+func (b *Broker) EmitBookingUpdate(centerId, userId string, payload interface{}) {
+	b.mu.Lock()
+	ev := b.nextEvent("booking-updated", payload, Audience{CenterIDs: []string{centerId}, UserID: userId})
+	b.mu.Unlock()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, cl := range b.clients {
+		if _, ok := cl.Centers[centerId]; ok || cl.UserID == userId {
+			select { case cl.Send <- ev: default: }
+		}
+	}
+}
+
+// EmitReadReceipt notifies subscribers of the thread, and of the center that
+// read it so its unread badges update
+func (b *Broker) EmitReadReceipt(threadId, centerId string, payload interface{}) {
+	b.mu.Lock()
+	ev := b.nextEvent("read-receipt", payload, Audience{ThreadID: threadId, CenterIDs: []string{centerId}})
+	b.mu.Unlock()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, cl := range b.clients {
+		_, inThread := cl.Threads[threadId]
+		_, inCenter := cl.Centers[centerId]
+		if inThread || inCenter {
+			select { case cl.Send <- ev: default: }
+		}
+	}
+}
+
+// EmitThreadUpdate notifies subscribers of the thread, and of the given
+// centers, e.g. ones just added or removed, so their thread lists update
+func (b *Broker) EmitThreadUpdate(threadId string, centerIds []string, payload interface{}) {
+	b.mu.Lock()
+	ev := b.nextEvent("thread-updated", payload, Audience{ThreadID: threadId, CenterIDs: centerIds})
+	b.mu.Unlock()
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, cl := range b.clients {
+		_, notify := cl.Threads[threadId]
+		for _, centerId := range centerIds {
+			if _, ok := cl.Centers[centerId]; ok {
+				notify = true
+			}
+		}
+		if notify {
+			select { case cl.Send <- ev: default: }
+		}
+	}
+}
+
 // Format SSE line
 func ToSSE(e Event) []byte {
     buf, _ := json.Marshal(e.Payload)
@@ -138,17 +235,20 @@ func ToSSE(e Event) []byte {
 }
 
 // internal: allocate id and append to backlog (rolling buffer)
-func (b *Broker) nextEvent(t string, payload interface{}) Event {
+func (b *Broker) nextEvent(t string, payload interface{}, to Audience) Event {
     b.lastID++
-    ev := Event{ID: b.lastID, Type: t, Payload: payload}
+    ev := Event{ID: b.lastID, Type: t, Payload: payload, To: to}
     // append with simple cap of 512 events
     if len(b.backlog) >= 512 { b.backlog = b.backlog[1:] }
     b.backlog = append(b.backlog, ev)
     return ev
 }
 
-// Get events since id (exclusive)
+// Get events since id (exclusive). They are for every audience; callers
+// replaying them must check each event's To against the client.
 func (b *Broker) GetSince(id int64) []Event {
+    b.mu.RLock()
+    defer b.mu.RUnlock()
     out := make([]Event, 0)
     for _, ev := range b.backlog {
         if ev.ID > id { out = append(out, ev) }
@@ -0,0 +1,195 @@
+package hours
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	_ "time/tzdata" // containers often ship without a zoneinfo database
+)
+
+// Interval is an opening window within one day, in minutes after local midnight
+type Interval struct {
+	Open  int
+	Close int
+}
+
+// Schedule is a center's weekly opening hours in its own time zone
+type Schedule struct {
+	Location   *time.Location
+	Weekly     [7][]Interval // indexed by time.Weekday
+	exceptions map[string][]Interval
+}
+
+// Window is a concrete opening period
+type Window struct {
+	Opens  time.Time `json:"opens"`
+	Closes time.Time `json:"closes"`
+}
+
+var ErrInvalidTime = errors.New("time must be HH:MM between 00:00 and 24:00")
+
+// ParseClock parses "HH:MM" into minutes after midnight; "24:00" is allowed
+// as a closing time
+func ParseClock(s string) (int, error) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, ErrInvalidTime
+	}
+	for _, i := range []int{0, 1, 3, 4} {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, ErrInvalidTime
+		}
+	}
+	h := int(s[0]-'0')*10 + int(s[1]-'0')
+	m := int(s[3]-'0')*10 + int(s[4]-'0')
+	if m > 59 || h > 24 || (h == 24 && m != 0) {
+		return 0, ErrInvalidTime
+	}
+	return h*60 + m, nil
+}
+
+// FormatClock renders minutes after midnight as "HH:MM"
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// NewInterval parses and validates an opening interval
+func NewInterval(opens, closes string) (Interval, error) {
+	o, err := ParseClock(opens)
+	if err != nil {
+		return Interval{}, err
+	}
+	c, err := ParseClock(closes)
+	if err != nil {
+		return Interval{}, err
+	}
+	if o >= c {
+		return Interval{}, fmt.Errorf("opening time %s must be before closing time %s", opens, closes)
+	}
+	return Interval{Open: o, Close: c}, nil
+}
+
+// NewSchedule creates an empty schedule for an IANA time zone. Go's special
+// names "" (UTC) and "Local" are rejected: they mean nothing to PostgreSQL,
+// which evaluates the same zone when filtering centers that are open now.
+func NewSchedule(timeZone string) (*Schedule, error) {
+	if timeZone == "" || timeZone == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", timeZone)
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", timeZone)
+	}
+	return &Schedule{Location: loc, exceptions: map[string][]Interval{}}, nil
+}
+
+// Add appends a weekly interval, keeping each day sorted
+func (s *Schedule) Add(day time.Weekday, iv Interval) {
+	s.Weekly[day] = append(s.Weekly[day], iv)
+	sort.Slice(s.Weekly[day], func(i, j int) bool { return s.Weekly[day][i].Open < s.Weekly[day][j].Open })
+}
+
+// AddException overrides the weekly schedule on a date; pass no intervals to
+// mark the center closed
+func (s *Schedule) AddException(date time.Time, intervals ...Interval) {
+	sorted := append([]Interval{}, intervals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Open < sorted[j].Open })
+	s.exceptions[dateKey(date)] = sorted
+}
+
+// Overlaps reports whether any two intervals on the same day overlap
+func Overlaps(intervals []Interval) bool {
+	sorted := append([]Interval{}, intervals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Open < sorted[j].Open })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].Open < sorted[i-1].Close {
+			return true
+		}
+	}
+	return false
+}
+
+// intervalsOn returns the opening intervals for a local date
+func (s *Schedule) intervalsOn(local time.Time) []Interval {
+	if ex, ok := s.exceptions[dateKey(local)]; ok {
+		return ex
+	}
+	return s.Weekly[local.Weekday()]
+}
+
+// IsOpen reports whether the center is open at the instant t
+func (s *Schedule) IsOpen(t time.Time) bool {
+	local := t.In(s.Location)
+	minute := local.Hour()*60 + local.Minute()
+	for _, iv := range s.intervalsOn(local) {
+		if minute >= iv.Open && minute < iv.Close {
+			return true
+		}
+	}
+	return false
+}
+
+// HasWeeklyHours reports whether any weekly opening hours are configured
+func (s *Schedule) HasWeeklyHours() bool {
+	for _, day := range s.Weekly {
+		if len(day) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers reports whether the center stays open for the whole of [start, end)
+func (s *Schedule) Covers(start, end time.Time) bool {
+	if !end.After(start) {
+		return false
+	}
+	horizon := int(end.Sub(start).Hours()/24) + 1
+	windows := s.NextOpenings(start, 1, horizon)
+	return len(windows) == 1 && !windows[0].Opens.After(start) && !windows[0].Closes.Before(end)
+}
+
+// NextOpenings returns up to count opening windows that end after from,
+// looking ahead at most horizonDays. A window already in progress at from is
+// included. Adjacent windows (e.g. one closing at 24:00 and the next opening at
+// 00:00) are merged.
+func (s *Schedule) NextOpenings(from time.Time, count, horizonDays int) []Window {
+	out := []Window{}
+	if count <= 0 {
+		return out
+	}
+	local := from.In(s.Location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.Location)
+
+	for d := 0; d <= horizonDays; d++ {
+		date := day.AddDate(0, 0, d)
+		// once full, only keep going to extend a window that runs past midnight
+		if len(out) == count && !out[count-1].Closes.Equal(date) {
+			break
+		}
+		for _, iv := range s.intervalsOn(date) {
+			opens := atMinute(date, iv.Open, s.Location)
+			closes := atMinute(date, iv.Close, s.Location)
+			if !closes.After(from) {
+				continue
+			}
+			if n := len(out); n > 0 && out[n-1].Closes.Equal(opens) {
+				out[n-1].Closes = closes
+				continue
+			}
+			if len(out) == count {
+				break
+			}
+			out = append(out, Window{Opens: opens, Closes: closes})
+		}
+	}
+	return out
+}
+
+func atMinute(date time.Time, minute int, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), minute/60, minute%60, 0, 0, loc)
+}
+
+func dateKey(t time.Time) string {
+	return t.Format("2006-01-02")
+}
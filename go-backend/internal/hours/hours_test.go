@@ -0,0 +1,135 @@
+package hours
+
+import (
+	"testing"
+	"time"
+)
+
+func mustInterval(t *testing.T, opens, closes string) Interval {
+	t.Helper()
+	iv, err := NewInterval(opens, closes)
+	if err != nil {
+		t.Fatalf("interval %s-%s: %v", opens, closes, err)
+	}
+	return iv
+}
+
+func TestParseClock(t *testing.T) {
+	if m, err := ParseClock("08:30"); err != nil || m != 510 {
+		t.Fatalf("unexpected result: %d %v", m, err)
+	}
+	if m, err := ParseClock("24:00"); err != nil || m != 1440 {
+		t.Fatalf("expected 24:00 to be allowed, got %d %v", m, err)
+	}
+	for _, bad := range []string{"8:30", " 9:00", "+9:00", "09:-1", "24:30", "12:60", "ab:cd", ""} {
+		if _, err := ParseClock(bad); err == nil {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+	if _, err := NewInterval("17:00", "09:00"); err == nil {
+		t.Fatalf("expected inverted interval to be rejected")
+	}
+}
+
+func TestNewScheduleRejectsNonIANAZones(t *testing.T) {
+	for _, bad := range []string{"", "Local", "Mars/Olympus"} {
+		if _, err := NewSchedule(bad); err == nil {
+			t.Fatalf("expected time zone %q to be rejected", bad)
+		}
+	}
+	if _, err := NewSchedule("UTC"); err != nil {
+		t.Fatalf("expected UTC to be accepted: %v", err)
+	}
+}
+
+func TestScheduleIsOpenUsesCenterTimeZone(t *testing.T) {
+	s, err := NewSchedule("Africa/Kampala") // UTC+3
+	if err != nil {
+		t.Fatalf("schedule: %v", err)
+	}
+	s.Add(time.Monday, mustInterval(t, "09:00", "17:00"))
+
+	// Monday 2024-06-03 06:30 UTC is 09:30 in Kampala
+	if !s.IsOpen(time.Date(2024, 6, 3, 6, 30, 0, 0, time.UTC)) {
+		t.Fatalf("expected open at 09:30 local")
+	}
+	// 14:30 UTC is 17:30 in Kampala
+	if s.IsOpen(time.Date(2024, 6, 3, 14, 30, 0, 0, time.UTC)) {
+		t.Fatalf("expected closed at 17:30 local")
+	}
+}
+
+func TestScheduleExceptions(t *testing.T) {
+	s, _ := NewSchedule("UTC")
+	s.Add(time.Monday, mustInterval(t, "09:00", "17:00"))
+	s.Add(time.Tuesday, mustInterval(t, "09:00", "17:00"))
+	s.AddException(time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC))                                    // holiday closure
+	s.AddException(time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC), mustInterval(t, "12:00", "14:00")) // short day
+
+	if s.IsOpen(time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected holiday closure")
+	}
+	if s.IsOpen(time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC)) || !s.IsOpen(time.Date(2024, 6, 4, 13, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected special hours to replace the weekly schedule")
+	}
+}
+
+func TestNextOpenings(t *testing.T) {
+	s, _ := NewSchedule("UTC")
+	s.Add(time.Monday, mustInterval(t, "09:00", "12:00"))
+	s.Add(time.Monday, mustInterval(t, "13:00", "17:00"))
+	s.Add(time.Friday, mustInterval(t, "20:00", "24:00"))
+	s.Add(time.Saturday, mustInterval(t, "00:00", "02:00"))
+
+	// Monday 10:00: the morning window is in progress
+	from := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	got := s.NextOpenings(from, 3, 14)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 windows, got %d", len(got))
+	}
+	if got[0].Opens.Hour() != 9 || got[1].Opens.Hour() != 13 {
+		t.Fatalf("unexpected windows: %+v", got)
+	}
+	// Friday 20:00 to Saturday 02:00 is merged into one window
+	if got[2].Opens.Weekday() != time.Friday || got[2].Closes.Weekday() != time.Saturday || got[2].Closes.Hour() != 2 {
+		t.Fatalf("expected merged overnight window, got %+v", got[2])
+	}
+
+	empty, _ := NewSchedule("UTC")
+	if len(empty.NextOpenings(from, 3, 14)) != 0 {
+		t.Fatalf("expected no openings for an empty schedule")
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	if !Overlaps([]Interval{{Open: 600, Close: 720}, {Open: 700, Close: 800}}) {
+		t.Fatalf("expected overlap")
+	}
+	if Overlaps([]Interval{{Open: 600, Close: 720}, {Open: 720, Close: 800}}) {
+		t.Fatalf("touching intervals do not overlap")
+	}
+}
+
+func TestCovers(t *testing.T) {
+	s, _ := NewSchedule("UTC")
+	if s.HasWeeklyHours() {
+		t.Fatalf("expected empty schedule")
+	}
+	s.Add(time.Monday, mustInterval(t, "09:00", "17:00"))
+	s.Add(time.Friday, mustInterval(t, "20:00", "24:00"))
+	s.Add(time.Saturday, mustInterval(t, "00:00", "02:00"))
+
+	at := func(day, hour int) time.Time { return time.Date(2024, 6, day, hour, 0, 0, 0, time.UTC) }
+	if !s.Covers(at(3, 10), at(3, 12)) {
+		t.Fatalf("expected Monday 10-12 to be covered")
+	}
+	if s.Covers(at(3, 16), at(3, 18)) {
+		t.Fatalf("expected booking past closing to be rejected")
+	}
+	if s.Covers(at(3, 7), at(3, 10)) {
+		t.Fatalf("expected booking before opening to be rejected")
+	}
+	if !s.Covers(at(7, 22), at(8, 1)) {
+		t.Fatalf("expected overnight window to cover Friday 22:00 to Saturday 01:00")
+	}
+}
@@ -0,0 +1,52 @@
+package hours
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// FromModels builds a schedule from stored weekly hours and exceptions.
+// Malformed rows are skipped rather than failing the whole schedule.
+func FromModels(timeZone string, weekly []db.OpeningHours, exceptions []db.HoursException) (*Schedule, error) {
+	s, err := NewSchedule(timeZone)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range weekly {
+		iv, err := NewInterval(h.OpensAt, h.ClosesAt)
+		if err != nil || h.Weekday < 0 || h.Weekday > 6 {
+			continue
+		}
+		s.Add(time.Weekday(h.Weekday), iv)
+	}
+	for _, ex := range exceptions {
+		if ex.Closed || ex.OpensAt == nil || ex.ClosesAt == nil {
+			s.AddException(ex.Date)
+			continue
+		}
+		iv, err := NewInterval(*ex.OpensAt, *ex.ClosesAt)
+		if err != nil {
+			s.AddException(ex.Date)
+			continue
+		}
+		s.AddException(ex.Date, iv)
+	}
+	return s, nil
+}
+
+// Load builds the schedule for a center, including exceptions from today on
+func Load(gdb *gorm.DB, center *db.CommunityCenter, now time.Time) (*Schedule, error) {
+	weekly, err := db.ListOpeningHours(gdb, center.ID)
+	if err != nil {
+		return nil, err
+	}
+	// start a day early so the center's local "today" is always covered
+	exceptions, err := db.ListHoursExceptions(gdb, center.ID, now.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	return FromModels(center.TimeZone, weekly, exceptions)
+}
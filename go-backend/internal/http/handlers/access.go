@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// Hub-scoped access rules for enrollments and service provisions. Admins see
+// everything, entrepreneurs see their own records and center managers see
+// records of the hubs they manage. Managers of a collaborating hub may read,
+// but not change, the services they co-deliver.
+
+// callerManagedCenterIDs returns the hubs managed by the caller
+func callerManagedCenterIDs(c *gin.Context, gdb *gorm.DB) ([]uuid.UUID, error) {
+	uid, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		return nil, nil
+	}
+	return db.ListManagedCenterIDs(gdb, uid)
+}
+
+// isEntrepreneurOwner reports whether the caller is the entrepreneur's user
+func isEntrepreneurOwner(c *gin.Context, gdb *gorm.DB, entrepreneurID uuid.UUID) (bool, error) {
+	uid, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		return false, nil
+	}
+	var count int64
+	err = gdb.Model(&db.Entrepreneur{}).Where("id = ? AND user_id = ?", entrepreneurID, uid).Count(&count).Error
+	return count > 0, err
+}
+
+// canViewEnrollment applies the hub-scoped read rule to an enrollment
+func canViewEnrollment(c *gin.Context, gdb *gorm.DB, e *db.HubEnrollment) (bool, error) {
+	switch ctxutil.RoleFrom(c) {
+	case string(db.RoleAdmin):
+		return true, nil
+	case string(db.RoleCenterManager):
+		return canManageCenterID(c, gdb, e.HubID)
+	case string(db.RoleEntrepreneur):
+		return isEntrepreneurOwner(c, gdb, e.EntrepreneurID)
+	}
+	return false, nil
+}
+
+// canViewService applies the hub-scoped read rule to a service provision,
+// including managers of the collaborating hub
+func canViewService(c *gin.Context, gdb *gorm.DB, s *db.ServiceProvision) (bool, error) {
+	switch ctxutil.RoleFrom(c) {
+	case string(db.RoleAdmin):
+		return true, nil
+	case string(db.RoleCenterManager):
+		if ok, err := canManageCenterID(c, gdb, s.HubID); ok || err != nil {
+			return ok, err
+		}
+		if s.CollaboratingHubID == nil {
+			return false, nil
+		}
+		return canManageCenterID(c, gdb, *s.CollaboratingHubID)
+	case string(db.RoleEntrepreneur):
+		return isEntrepreneurOwner(c, gdb, s.EntrepreneurID)
+	}
+	return false, nil
+}
+
+// canViewDeal applies the hub-scoped read rule to an investor deal, keyed on
+// the introducing hub
+func canViewDeal(c *gin.Context, gdb *gorm.DB, d *db.Deal) (bool, error) {
+	switch ctxutil.RoleFrom(c) {
+	case string(db.RoleAdmin):
+		return true, nil
+	case string(db.RoleCenterManager):
+		return canManageCenterID(c, gdb, d.HubID)
+	case string(db.RoleEntrepreneur):
+		return isEntrepreneurOwner(c, gdb, d.EntrepreneurID)
+	}
+	return false, nil
+}
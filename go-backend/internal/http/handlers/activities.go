@@ -1,26 +1,30 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"communitycentresplatform/go-backend/internal/ctxutil"
 	"communitycentresplatform/go-backend/internal/db"
 )
 
 type createActivityRequest struct {
-	HubID              string  `json:"hubId" binding:"required"`
-	Type               string  `json:"type" binding:"required,oneof=ENROLLMENT SERVICE COLLABORATION ANNOUNCEMENT CONNECTION"`
-	Title              string  `json:"title" binding:"required,min=5,max=255"`
-	Description        string  `json:"description" binding:"required,min=10"`
-	EntrepreneurID     *string `json:"entrepreneurId"`
-	ServiceProvisionID *string `json:"serviceProvisionId"`
-	ConnectionID       *string `json:"connectionId"`
-	CollaboratingHubID *string `json:"collaboratingHubId"`
-	Pinned             *bool   `json:"pinned"`
+	HubID              string   `json:"hubId" binding:"required"`
+	Type               string   `json:"type" binding:"required,oneof=ENROLLMENT SERVICE COLLABORATION ANNOUNCEMENT CONNECTION SHOWCASE"`
+	Title              string   `json:"title" binding:"required,min=5,max=255"`
+	Description        string   `json:"description" binding:"required,min=10"`
+	EntrepreneurID     *string  `json:"entrepreneurId"`
+	ServiceProvisionID *string  `json:"serviceProvisionId"`
+	ConnectionID       *string  `json:"connectionId"`
+	CollaboratingHubID *string  `json:"collaboratingHubId"`
+	PortfolioItemID    *string  `json:"portfolioItemId"`
+	UploadIDs          []string `json:"uploadIds"` // Files from POST /api/uploads to attach
+	Pinned             *bool    `json:"pinned"`
 }
 
 type updateActivityRequest struct {
@@ -93,6 +97,50 @@ func CreateActivity(c *gin.Context) {
 		}
 	}
 
+	// A featured portfolio item must be public and belong to one of the hub's
+	// entrepreneurs; it also sets the activity's entrepreneur
+	var portfolioItemID *uuid.UUID
+	if req.PortfolioItemID != nil {
+		parsed, err := uuid.Parse(*req.PortfolioItemID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid portfolio item id"})
+			return
+		}
+		item, err := db.FindPortfolioItemByID(gdb, parsed)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch portfolio item"})
+			return
+		}
+		if item == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "portfolio item not found"})
+			return
+		}
+		if item.Visibility != db.VisibilityPublic {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "only public portfolio items can be featured"})
+			return
+		}
+		if entrepreneurID != nil && *entrepreneurID != item.EntrepreneurID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "portfolio item belongs to a different entrepreneur"})
+			return
+		}
+		enrolled, err := db.HasHubEnrollment(gdb, hubID, item.EntrepreneurID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check enrollment"})
+			return
+		}
+		if !enrolled {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "entrepreneur is not enrolled with this hub"})
+			return
+		}
+		portfolioItemID = &item.ID
+		entrepreneurID = &item.EntrepreneurID
+	}
+
+	uploadIDs, ok := claimUploads(c, gdb, req.UploadIDs)
+	if !ok {
+		return
+	}
+
 	pinned := false
 	if req.Pinned != nil {
 		pinned = *req.Pinned
@@ -109,22 +157,41 @@ func CreateActivity(c *gin.Context) {
 		ServiceProvisionID: serviceProvisionID,
 		ConnectionID:       connectionID,
 		CollaboratingHubID: collaboratingHubID,
+		PortfolioItemID:    portfolioItemID,
 		Pinned:             pinned,
 		CreatedBy:          creatorID,
 	}
 
-	if err := gdb.Create(&activity).Error; err != nil {
+	err = gdb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&activity).Error; err != nil {
+			return err
+		}
+		return db.AttachUploads(tx, uploadIDs, db.UploadForActivity, activity.ID)
+	})
+	if errors.Is(err, db.ErrUploadsClaimed) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create activity"})
 		return
 	}
 
 	// Eager load relations
 	gdb.Preload("Entrepreneur").Preload("ServiceProvision").Preload("Connection").
-		Preload("CollaboratingHub").Preload("Creator").First(&activity, activity.ID)
+		Preload("CollaboratingHub").Preload("PortfolioItem.Media").Preload("Creator").First(&activity, activity.ID)
+
+	attachments, err := attachmentsFor(c, gdb, db.UploadForActivity, []uuid.UUID{activity.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch attachments"})
+		return
+	}
+	result := transformActivity(&activity)
+	result["attachments"] = attachments[activity.ID]
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message":  "Activity created successfully",
-		"activity": transformActivity(&activity),
+		"activity": result,
 	})
 }
 
@@ -173,6 +240,7 @@ func GetHubActivities(c *gin.Context) {
 		Preload("ServiceProvision").
 		Preload("Connection").
 		Preload("CollaboratingHub").
+		Preload("PortfolioItem.Media").
 		Preload("Creator")
 
 	if err := query.Find(&activities).Error; err != nil {
@@ -180,9 +248,20 @@ func GetHubActivities(c *gin.Context) {
 		return
 	}
 
+	ids := make([]uuid.UUID, len(activities))
+	for i := range activities {
+		ids[i] = activities[i].ID
+	}
+	attachments, err := attachmentsFor(c, gdb, db.UploadForActivity, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch attachments"})
+		return
+	}
+
 	transformed := make([]gin.H, len(activities))
 	for i, activity := range activities {
 		transformed[i] = transformActivity(&activity)
+		transformed[i]["attachments"] = attachments[activity.ID]
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -247,7 +326,7 @@ func UpdateActivity(c *gin.Context) {
 
 	// Reload with relations
 	gdb.Preload("Entrepreneur").Preload("ServiceProvision").Preload("Connection").
-		Preload("CollaboratingHub").Preload("Creator").First(&activity, activity.ID)
+		Preload("CollaboratingHub").Preload("PortfolioItem.Media").Preload("Creator").First(&activity, activity.ID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":  "Activity updated successfully",
@@ -289,6 +368,10 @@ func DeleteActivity(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete activity"})
 		return
 	}
+	if err := deleteAttachments(c, gdb, db.UploadForActivity, activity.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete attachments"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Activity deleted successfully"})
 }
@@ -344,7 +427,7 @@ func PinActivity(c *gin.Context) {
 
 	// Reload with relations
 	gdb.Preload("Entrepreneur").Preload("ServiceProvision").Preload("Connection").
-		Preload("CollaboratingHub").Preload("Creator").First(&activity, activity.ID)
+		Preload("CollaboratingHub").Preload("PortfolioItem.Media").Preload("Creator").First(&activity, activity.ID)
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":  "Activity pin status updated successfully",
@@ -396,5 +479,10 @@ func transformActivity(activity *db.HubActivity) gin.H {
 		result["imageUrl"] = activity.ImageURL
 	}
 
+	// Activities are public, so an item made private since is left out
+	if activity.PortfolioItem != nil && activity.PortfolioItem.Visibility == db.VisibilityPublic {
+		result["portfolioItem"] = transformPortfolioItem(activity.PortfolioItem)
+	}
+
 	return result
 }
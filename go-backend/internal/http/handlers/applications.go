@@ -0,0 +1,857 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/applications"
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+type applicationFormRequest struct {
+	HubID       string          `json:"hubId"`
+	CohortID    *string         `json:"cohortId"`
+	Title       string          `json:"title" binding:"required,min=2,max=255"`
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema" binding:"required"`
+	Rubric      json.RawMessage `json:"rubric"`
+	Stages      []string        `json:"stages"`
+	Scorers     []string        `json:"scorers"` // User IDs
+	Active      *bool           `json:"active"`
+	ClosesAt    *time.Time      `json:"closesAt"`
+}
+
+type submitApplicationRequest struct {
+	FormID  string          `json:"formId" binding:"required"`
+	Answers json.RawMessage `json:"answers" binding:"required"`
+}
+
+type applicationReviewRequest struct {
+	Scores         map[string]float64 `json:"scores"`
+	Recommendation string             `json:"recommendation"`
+	Comment        string             `json:"comment"`
+}
+
+type applicationDecisionRequest struct {
+	Decision string  `json:"decision" binding:"required,oneof=ACCEPTED REJECTED"`
+	Notes    *string `json:"notes"`
+}
+
+// GET /api/application-forms/hub/:id - List a hub's open application forms
+func GetHubApplicationForms(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	hubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+
+	forms, err := db.ListApplicationForms(gdb, hubID, c.Query("includeInactive") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch application forms"})
+		return
+	}
+
+	transformed := make([]gin.H, len(forms))
+	for i := range forms {
+		transformed[i] = transformApplicationForm(&forms[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"forms": transformed, "total": len(transformed)})
+}
+
+// GET /api/application-forms/:id - Get an application form
+func GetApplicationForm(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	form := findApplicationFormFromParam(c, gdb)
+	if form == nil {
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"form": transformApplicationForm(form)})
+}
+
+// POST /api/application-forms - Create an application form (ADMIN or the hub's CENTER_MANAGER)
+func CreateApplicationForm(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req applicationFormRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	hubID, err := uuid.Parse(req.HubID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+	hub, err := db.FindCenterByID(gdb, hubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if hub == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hub not found"})
+		return
+	}
+	if !canManageCenter(c, hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only manage forms for your own hub"})
+		return
+	}
+
+	form := db.ApplicationForm{HubID: hub.ID, Active: true}
+	if status, msg := applyApplicationFormRequest(gdb, &form, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	if err := db.SaveApplicationForm(gdb, &form); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create application form"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Application form created successfully",
+		"form":    transformApplicationForm(&form),
+	})
+}
+
+// PUT /api/application-forms/:id - Update an application form
+func UpdateApplicationForm(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	form := findApplicationFormFromParam(c, gdb)
+	if form == nil {
+		return
+	}
+	if !canManageCenter(c, &form.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only manage forms for your own hub"})
+		return
+	}
+
+	var req applicationFormRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	if status, msg := applyApplicationFormRequest(gdb, form, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	if err := db.SaveApplicationForm(gdb, form); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update application form"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Application form updated successfully",
+		"form":    transformApplicationForm(form),
+	})
+}
+
+// GET /api/application-forms/:id/applications - List a form's applications (managers and scorers)
+func GetFormApplications(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	form := findApplicationFormFromParam(c, gdb)
+	if form == nil {
+		return
+	}
+	if !canReviewApplications(c, form) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return
+	}
+
+	filters := db.ApplicationFilters{
+		Status: db.ApplicationStatus(strings.ToUpper(c.Query("status"))),
+		Stage:  strings.ToUpper(c.Query("stage")),
+	}
+	apps, err := db.ListFormApplications(gdb, form.ID, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch applications"})
+		return
+	}
+
+	transformed := make([]gin.H, len(apps))
+	for i := range apps {
+		apps[i].Form = *form
+		transformed[i] = transformApplication(&apps[i], true)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"applications": transformed, "total": len(transformed)})
+}
+
+// POST /api/applications - Submit an application (ENTREPRENEUR)
+func SubmitApplication(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req submitApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	formID, err := uuid.Parse(req.FormID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid form id"})
+		return
+	}
+	form, err := db.FindApplicationFormByID(gdb, formID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch application form"})
+		return
+	}
+	if form == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "application form not found"})
+		return
+	}
+	if !form.Active || (form.ClosesAt != nil && !time.Now().Before(*form.ClosesAt)) {
+		c.JSON(http.StatusConflict, gin.H{"error": "this form is not accepting applications"})
+		return
+	}
+
+	entrepreneur := currentEntrepreneur(c, gdb)
+	if entrepreneur == nil {
+		return
+	}
+
+	existing, err := db.FindActiveApplication(gdb, form.ID, entrepreneur.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check existing applications"})
+		return
+	}
+	if existing != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "you already have an application under review for this form", "applicationId": existing.ID})
+		return
+	}
+
+	schema, err := applications.ParseSchema(form.Schema)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "application form is misconfigured"})
+		return
+	}
+	if _, fieldErrs := schema.Validate(req.Answers); fieldErrs != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid answers", "fields": fieldErrs})
+		return
+	}
+
+	stages := applications.DefaultStages
+	if len(form.Stages) > 0 {
+		stages = form.Stages
+	}
+	app := db.Application{
+		FormID:         form.ID,
+		HubID:          form.HubID,
+		EntrepreneurID: entrepreneur.ID,
+		Answers:        db.JSON(req.Answers),
+		Status:         db.ApplicationSubmitted,
+		Stage:          stages[0],
+	}
+	if err := db.CreateApplication(gdb, &app); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to submit application"})
+		return
+	}
+	app.Form = *form
+
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitCenterUpdate(form.HubID.String(), gin.H{
+			"applicationId":  app.ID,
+			"formId":         form.ID,
+			"hubId":          form.HubID,
+			"entrepreneurId": entrepreneur.ID,
+			"status":         app.Status,
+			"action":         "application_submitted",
+		})
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":     "Application submitted successfully",
+		"application": transformApplication(&app, false),
+	})
+}
+
+// GET /api/applications/mine - List the current entrepreneur's applications
+func GetMyApplications(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	entrepreneur := currentEntrepreneur(c, gdb)
+	if entrepreneur == nil {
+		return
+	}
+
+	apps, err := db.ListEntrepreneurApplications(gdb, entrepreneur.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch applications"})
+		return
+	}
+
+	transformed := make([]gin.H, len(apps))
+	for i := range apps {
+		transformed[i] = transformApplication(&apps[i], false)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"applications": transformed, "total": len(transformed)})
+}
+
+// GET /api/applications/:id - Get an application (applicant, hub managers and scorers)
+func GetApplication(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	app := findApplicationFromParam(c, gdb)
+	if app == nil {
+		return
+	}
+
+	reviewer := canReviewApplications(c, &app.Form)
+	if !reviewer && app.Entrepreneur.UserID.String() != ctxutil.UserIDFrom(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return
+	}
+
+	// Applicants see their answers and status but not the panel's scores
+	c.JSON(http.StatusOK, gin.H{"application": transformApplication(app, reviewer)})
+}
+
+// POST /api/applications/:id/reviews - Score an application at its current stage (managers and scorers)
+func ReviewApplication(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	app := findApplicationFromParam(c, gdb)
+	if app == nil {
+		return
+	}
+	if !canReviewApplications(c, &app.Form) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return
+	}
+	if !applications.Open(app.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": applications.ErrDecisionFinal.Error()})
+		return
+	}
+
+	var req applicationReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	req.Recommendation = strings.ToUpper(req.Recommendation)
+	if !applications.ValidRecommendation(req.Recommendation) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "recommendation must be ADVANCE, HOLD or REJECT"})
+		return
+	}
+
+	rubric, err := applications.ParseRubric(app.Form.Rubric)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "application form is misconfigured"})
+		return
+	}
+	total, err := rubric.Score(req.Scores)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	scores, _ := json.Marshal(req.Scores)
+
+	reviewerID, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	review := db.ApplicationReview{
+		ApplicationID:  app.ID,
+		ReviewerID:     reviewerID,
+		Stage:          app.Stage,
+		Scores:         db.JSON(scores),
+		Total:          total,
+		Recommendation: req.Recommendation,
+		Comment:        req.Comment,
+	}
+
+	err = gdb.Transaction(func(tx *gorm.DB) error {
+		if err := db.SaveApplicationReview(tx, &review); err != nil {
+			return err
+		}
+		if app.Status == db.ApplicationSubmitted {
+			app.Status = db.ApplicationInReview
+			return db.UpdateApplication(tx, app, db.ApplicationSubmitted, app.Stage)
+		}
+		return nil
+	})
+	if errors.Is(err, db.ErrApplicationChanged) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save review"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Review saved successfully",
+		"review":  transformApplicationReview(&review),
+	})
+}
+
+// PATCH /api/applications/:id/advance - Move an application to the next review stage (ADMIN or the hub's CENTER_MANAGER)
+func AdvanceApplication(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	app := findApplicationFromParam(c, gdb)
+	if app == nil {
+		return
+	}
+	if !canManageCenter(c, &app.Form.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only manage applications for your own hub"})
+		return
+	}
+	if !applications.Open(app.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": applications.ErrDecisionFinal.Error()})
+		return
+	}
+
+	stages := applications.DefaultStages
+	if len(app.Form.Stages) > 0 {
+		stages = app.Form.Stages
+	}
+	next, err := applications.NextStage(stages, app.Stage)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	status, stage := app.Status, app.Stage
+	app.Stage = next
+	app.Status = db.ApplicationInReview
+	if err := db.UpdateApplication(gdb, app, status, stage); err != nil {
+		if errors.Is(err, db.ErrApplicationChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update application"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Application advanced successfully",
+		"application": transformApplication(app, true),
+	})
+}
+
+// PATCH /api/applications/:id/decision - Accept or reject an application (ADMIN or the hub's CENTER_MANAGER)
+func DecideApplication(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	app := findApplicationFromParam(c, gdb)
+	if app == nil {
+		return
+	}
+	if !canManageCenter(c, &app.Form.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only manage applications for your own hub"})
+		return
+	}
+
+	var req applicationDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	decision := db.ApplicationStatus(req.Decision)
+	if err := applications.CheckDecision(app.Status, decision); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	deciderID, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if decision == db.ApplicationAccepted {
+		// Managers may fill a cohort outside its application window but not beyond capacity
+		var cohortID *uuid.UUID
+		if app.Form.CohortID != nil {
+			ch, status, msg := admitToCohort(gdb, app.Form.CohortID.String(), app.HubID, true)
+			if status != 0 {
+				c.JSON(status, gin.H{"error": msg})
+				return
+			}
+			cohortID = &ch.ID
+		}
+
+		var existing int64
+		existingQuery := gdb.Model(&db.HubEnrollment{}).Where("hub_id = ? AND entrepreneur_id = ?", app.HubID, app.EntrepreneurID)
+		if cohortID != nil {
+			existingQuery = existingQuery.Where("cohort_id = ?", *cohortID)
+		} else {
+			existingQuery = existingQuery.Where("cohort_id IS NULL")
+		}
+		if err := existingQuery.Count(&existing).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check existing enrollment"})
+			return
+		}
+		if existing > 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "applicant is already enrolled"})
+			return
+		}
+
+		if _, err := db.AcceptApplication(gdb, app, cohortID, deciderID, req.Notes); err != nil {
+			if errors.Is(err, db.ErrApplicationChanged) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to accept application"})
+			return
+		}
+	} else {
+		now := time.Now()
+		status := app.Status
+		app.Status = db.ApplicationRejected
+		app.DecidedBy = &deciderID
+		app.DecidedAt = &now
+		app.DecisionNotes = req.Notes
+		if err := db.UpdateApplication(gdb, app, status, app.Stage); err != nil {
+			if errors.Is(err, db.ErrApplicationChanged) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reject application"})
+			return
+		}
+	}
+
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitCenterUpdate(app.HubID.String(), gin.H{
+			"applicationId":  app.ID,
+			"hubId":          app.HubID,
+			"entrepreneurId": app.EntrepreneurID,
+			"enrollmentId":   app.EnrollmentID,
+			"status":         app.Status,
+			"action":         "application_decided",
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Application decision recorded successfully",
+		"application": transformApplication(app, true),
+	})
+}
+
+// PATCH /api/applications/:id/withdraw - Withdraw an application (applicant only)
+func WithdrawApplication(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	app := findApplicationFromParam(c, gdb)
+	if app == nil {
+		return
+	}
+	if app.Entrepreneur.UserID.String() != ctxutil.UserIDFrom(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only withdraw your own application"})
+		return
+	}
+	if !applications.Open(app.Status) {
+		c.JSON(http.StatusConflict, gin.H{"error": applications.ErrDecisionFinal.Error()})
+		return
+	}
+
+	status := app.Status
+	app.Status = db.ApplicationWithdrawn
+	if err := db.UpdateApplication(gdb, app, status, app.Stage); err != nil {
+		if errors.Is(err, db.ErrApplicationChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to withdraw application"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Application withdrawn successfully",
+		"application": transformApplication(app, false),
+	})
+}
+
+// applyApplicationFormRequest validates the form definition and copies it onto
+// the form. It returns a non-zero status and message when the request is rejected.
+func applyApplicationFormRequest(gdb *gorm.DB, form *db.ApplicationForm, req *applicationFormRequest) (int, string) {
+	if _, err := applications.ParseSchema(req.Schema); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	if _, err := applications.ParseRubric(req.Rubric); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	stages, err := applications.NormalizeStages(req.Stages)
+	if err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+
+	scorers := make([]string, 0, len(req.Scorers))
+	for _, s := range req.Scorers {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return http.StatusBadRequest, "invalid scorer id"
+		}
+		scorers = append(scorers, id.String())
+	}
+	if len(scorers) > 0 {
+		var found int64
+		if err := gdb.Model(&db.User{}).Where("id IN ?", scorers).Count(&found).Error; err != nil {
+			return http.StatusInternalServerError, "failed to check scorers"
+		}
+		if int(found) != len(scorers) {
+			return http.StatusNotFound, "scorer not found"
+		}
+	}
+
+	var cohortID *uuid.UUID
+	if req.CohortID != nil && *req.CohortID != "" {
+		id, err := uuid.Parse(*req.CohortID)
+		if err != nil {
+			return http.StatusBadRequest, "invalid cohort id"
+		}
+		ch, err := db.FindCohortByID(gdb, id)
+		if err != nil {
+			return http.StatusInternalServerError, "failed to fetch cohort"
+		}
+		if ch == nil || ch.HubID != form.HubID {
+			return http.StatusNotFound, "cohort not found for this hub"
+		}
+		cohortID = &ch.ID
+	}
+
+	rubric := db.JSON(req.Rubric)
+	if string(req.Rubric) == "null" {
+		rubric = nil
+	}
+
+	form.CohortID = cohortID
+	form.Title = strings.TrimSpace(req.Title)
+	form.Description = req.Description
+	form.Schema = db.JSON(req.Schema)
+	form.Rubric = rubric
+	form.Stages = db.StringArray(stages)
+	form.Scorers = db.StringArray(scorers)
+	form.ClosesAt = req.ClosesAt
+	if req.Active != nil {
+		form.Active = *req.Active
+	}
+	return 0, ""
+}
+
+// canReviewApplications reports whether the current user manages the form's
+// hub or is one of its named scorers
+func canReviewApplications(c *gin.Context, form *db.ApplicationForm) bool {
+	if canManageCenter(c, &form.Hub) {
+		return true
+	}
+	userID := ctxutil.UserIDFrom(c)
+	for _, s := range form.Scorers {
+		if s == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// currentEntrepreneur loads the entrepreneur profile of the current user,
+// writing the error response and returning nil when there is none
+func currentEntrepreneur(c *gin.Context, gdb *gorm.DB) *db.Entrepreneur {
+	userID, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return nil
+	}
+	var entrepreneur db.Entrepreneur
+	if err := gdb.Where("user_id = ?", userID).First(&entrepreneur).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "entrepreneur profile not found"})
+			return nil
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch entrepreneur profile"})
+		return nil
+	}
+	return &entrepreneur
+}
+
+// findApplicationFormFromParam loads the form named by the :id parameter,
+// writing the error response and returning nil when it cannot
+func findApplicationFormFromParam(c *gin.Context, gdb *gorm.DB) *db.ApplicationForm {
+	formID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid form id"})
+		return nil
+	}
+	form, err := db.FindApplicationFormByID(gdb, formID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch application form"})
+		return nil
+	}
+	if form == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "application form not found"})
+		return nil
+	}
+	return form
+}
+
+// findApplicationFromParam loads the application named by the :id parameter,
+// writing the error response and returning nil when it cannot
+func findApplicationFromParam(c *gin.Context, gdb *gorm.DB) *db.Application {
+	appID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid application id"})
+		return nil
+	}
+	app, err := db.FindApplicationByID(gdb, appID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch application"})
+		return nil
+	}
+	if app == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "application not found"})
+		return nil
+	}
+	return app
+}
+
+func transformApplicationForm(f *db.ApplicationForm) gin.H {
+	stages := f.Stages
+	if len(stages) == 0 {
+		stages = applications.DefaultStages
+	}
+	return gin.H{
+		"id":          f.ID,
+		"hubId":       f.HubID,
+		"cohortId":    f.CohortID,
+		"title":       f.Title,
+		"description": f.Description,
+		"schema":      f.Schema,
+		"rubric":      f.Rubric,
+		"stages":      stages,
+		"scorers":     f.Scorers,
+		"active":      f.Active,
+		"closesAt":    f.ClosesAt,
+		"createdAt":   f.CreatedAt,
+		"updatedAt":   f.UpdatedAt,
+	}
+}
+
+// transformApplication renders an application; reviews and decision notes
+// are only included for the review panel
+func transformApplication(a *db.Application, withReviews bool) gin.H {
+	out := gin.H{
+		"id":             a.ID,
+		"formId":         a.FormID,
+		"formTitle":      a.Form.Title,
+		"hubId":          a.HubID,
+		"entrepreneurId": a.EntrepreneurID,
+		"answers":        a.Answers,
+		"status":         a.Status,
+		"stage":          a.Stage,
+		"decidedAt":      a.DecidedAt,
+		"enrollmentId":   a.EnrollmentID,
+		"createdAt":      a.CreatedAt,
+		"updatedAt":      a.UpdatedAt,
+	}
+	if a.Form.Hub.ID != uuid.Nil {
+		out["hubName"] = a.Form.Hub.Name
+	}
+	if a.Entrepreneur.ID != uuid.Nil {
+		out["businessName"] = a.Entrepreneur.BusinessName
+		out["applicantName"] = a.Entrepreneur.User.Name
+	}
+	if withReviews {
+		stages := applications.DefaultStages
+		if len(a.Form.Stages) > 0 {
+			stages = a.Form.Stages
+		}
+		reviews := make([]gin.H, len(a.Reviews))
+		for i := range a.Reviews {
+			reviews[i] = transformApplicationReview(&a.Reviews[i])
+		}
+		out["reviews"] = reviews
+		out["summary"] = applications.Summarize(stages, a.Reviews)
+		out["decidedBy"] = a.DecidedBy
+		out["decisionNotes"] = a.DecisionNotes
+	}
+	return out
+}
+
+func transformApplicationReview(r *db.ApplicationReview) gin.H {
+	out := gin.H{
+		"id":             r.ID,
+		"applicationId":  r.ApplicationID,
+		"reviewerId":     r.ReviewerID,
+		"stage":          r.Stage,
+		"scores":         r.Scores,
+		"total":          r.Total,
+		"recommendation": r.Recommendation,
+		"comment":        r.Comment,
+		"createdAt":      r.CreatedAt,
+		"updatedAt":      r.UpdatedAt,
+	}
+	if r.Reviewer.ID != uuid.Nil {
+		out["reviewerName"] = r.Reviewer.Name
+	}
+	return out
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestApplicationDecisionConflict(t *testing.T) {
+	f := newHubFixture(t)
+	now := time.Now()
+	form, app := uuid.NewString(), uuid.NewString()
+	f.fake.insert("application_forms", map[string]interface{}{
+		"id": form, "hub_id": f.hubA, "cohort_id": nil, "title": "2025 intake", "schema": `{}`,
+		"stages": "{SCREENING,INTERVIEW}", "active": true, "created_at": now, "updated_at": now,
+	})
+	f.fake.insert("hub_applications", map[string]interface{}{
+		"id": app, "form_id": form, "hub_id": f.hubA, "entrepreneur_id": f.otherEntrepreneur, "answers": `{}`,
+		"status": "SUBMITTED", "stage": "SCREENING", "created_at": now, "updated_at": now,
+	})
+	path := "/api/applications/" + app
+
+	// Another manager advances the application while we do
+	f.fake.race("hub_applications", app, map[string]interface{}{"stage": "INTERVIEW", "status": "IN_REVIEW"})
+	if w := f.do(t, f.managerA, "PATCH", path+"/advance", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected a stale advance to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The applicant withdraws while the manager accepts
+	f.fake.race("hub_applications", app, map[string]interface{}{"status": "WITHDRAWN"})
+	if w := f.do(t, f.managerA, "PATCH", path+"/decision", gin.H{"decision": "ACCEPTED"}); w.Code != http.StatusConflict {
+		t.Fatalf("expected accepting a withdrawn application to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := f.fake.row("hub_applications", app)["status"]; got != "WITHDRAWN" {
+		t.Fatalf("expected the withdrawal to stand, got %v", got)
+	}
+	if n := f.fake.writeCount("hub_enrollments"); n != 0 {
+		t.Fatalf("expected no enrollment for a withdrawn application, got %d writes", n)
+	}
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cross-hub access regression suite: managers must only reach enrollments
+// and services of the hubs they manage; collaborating hubs get read access.
+
+func TestCrossHubAccess(t *testing.T) {
+	f := newHubFixture(t)
+
+	f.check(t, []accessCase{
+		// Hub enrollment listing
+		{"own hub enrollments", f.managerA, "GET", "/api/enrollments/hub/" + f.hubA, nil, http.StatusOK},
+		{"other hub enrollments", f.managerB, "GET", "/api/enrollments/hub/" + f.hubA, nil, http.StatusForbidden},
+		{"admin hub enrollments", f.admin, "GET", "/api/enrollments/hub/" + f.hubA, nil, http.StatusOK},
+
+		// Single enrollment and its history
+		{"own enrollment", f.managerA, "GET", "/api/enrollments/" + f.enrollment, nil, http.StatusOK},
+		{"other hub enrollment", f.managerB, "GET", "/api/enrollments/" + f.enrollment, nil, http.StatusForbidden},
+		{"owner enrollment", f.founder, "GET", "/api/enrollments/" + f.enrollment, nil, http.StatusOK},
+		{"other founder enrollment", f.otherFounder, "GET", "/api/enrollments/" + f.enrollment, nil, http.StatusForbidden},
+		{"own enrollment history", f.managerA, "GET", "/api/enrollments/" + f.enrollment + "/history", nil, http.StatusOK},
+		{"other hub enrollment history", f.managerB, "GET", "/api/enrollments/" + f.enrollment + "/history", nil, http.StatusForbidden},
+
+		// Enrollment status changes
+		{"other hub status change", f.managerB, "PATCH", "/api/enrollments/" + f.enrollment + "/status", gin.H{"status": "ACTIVE"}, http.StatusForbidden},
+		{"own hub status change", f.managerA, "PATCH", "/api/enrollments/" + f.enrollment + "/status", gin.H{"status": "ACTIVE"}, http.StatusOK},
+
+		// Enrolling into someone else's hub
+		{"enroll into other hub", f.managerB, "POST", "/api/enrollments", gin.H{"hubId": f.hubA, "entrepreneurId": f.otherEntrepreneur}, http.StatusForbidden},
+
+		// Hub service listing
+		{"own hub services", f.managerA, "GET", "/api/services/hub/" + f.hubA, nil, http.StatusOK},
+		{"other hub services", f.managerC, "GET", "/api/services/hub/" + f.hubA, nil, http.StatusForbidden},
+		{"collaborator lists via own hub", f.managerB, "GET", "/api/services/hub/" + f.hubB, nil, http.StatusOK},
+
+		// Logging services
+		{"log service for other hub", f.managerB, "POST", "/api/services", gin.H{
+			"hubId": f.hubA, "entrepreneurId": f.entrepreneur, "serviceType": "TRAINING", "description": "Bookkeeping workshop",
+		}, http.StatusForbidden},
+		{"log service for own hub", f.managerA, "POST", "/api/services", gin.H{
+			"hubId": f.hubA, "entrepreneurId": f.entrepreneur, "serviceType": "TRAINING", "description": "Bookkeeping workshop",
+		}, http.StatusCreated},
+
+		// Single services: collaborators may read but not change
+		{"lead hub service", f.managerA, "GET", "/api/services/" + f.service, nil, http.StatusOK},
+		{"unrelated hub service", f.managerB, "GET", "/api/services/" + f.service, nil, http.StatusForbidden},
+		{"collaborating hub service", f.managerB, "GET", "/api/services/" + f.sharedService, nil, http.StatusOK},
+		{"collaborating hub history", f.managerB, "GET", "/api/services/" + f.sharedService + "/history", nil, http.StatusOK},
+		{"uninvolved hub service", f.managerC, "GET", "/api/services/" + f.sharedService, nil, http.StatusForbidden},
+		{"collaborator update", f.managerB, "PUT", "/api/services/" + f.sharedService, gin.H{"outcome": "done"}, http.StatusForbidden},
+		{"uninvolved history", f.managerC, "GET", "/api/services/" + f.sharedService + "/history", nil, http.StatusForbidden},
+		{"lead hub update", f.managerA, "PUT", "/api/services/" + f.sharedService, gin.H{"outcome": "done"}, http.StatusOK},
+	})
+}
+
+func TestDeniedRequestsDoNotWrite(t *testing.T) {
+	f := newHubFixture(t)
+
+	f.do(t, f.managerB, "PATCH", "/api/enrollments/"+f.enrollment+"/status", gin.H{"status": "SUSPENDED"})
+	f.do(t, f.managerB, "PUT", "/api/services/"+f.sharedService, gin.H{"status": "CANCELLED"})
+	f.do(t, f.managerB, "POST", "/api/services", gin.H{
+		"hubId": f.hubA, "entrepreneurId": f.entrepreneur, "serviceType": "TRAINING", "description": "Bookkeeping workshop",
+	})
+
+	for _, table := range []string{"hub_enrollments", "service_provisions", "status_transitions"} {
+		if n := f.fake.writeCount(table); n != 0 {
+			t.Fatalf("expected no writes to %s, got %d", table, n)
+		}
+	}
+}
+
+func TestScopedListings(t *testing.T) {
+	f := newHubFixture(t)
+
+	total := func(w *httptest.ResponseRecorder) int {
+		var body struct {
+			Total int `json:"total"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return body.Total
+	}
+
+	// The entrepreneur's enrollment is at hub A only
+	if got := total(f.do(t, f.managerA, "GET", "/api/enrollments/entrepreneur/"+f.entrepreneur, nil)); got != 1 {
+		t.Fatalf("expected hub A manager to see 1 enrollment, got %d", got)
+	}
+	if got := total(f.do(t, f.managerB, "GET", "/api/enrollments/entrepreneur/"+f.entrepreneur, nil)); got != 0 {
+		t.Fatalf("expected hub B manager to see no enrollments, got %d", got)
+	}
+
+	// Hub B co-delivers one of hub A's two services
+	if got := total(f.do(t, f.managerB, "GET", "/api/services/hub/"+f.hubB, nil)); got != 1 {
+		t.Fatalf("expected hub B to list its co-delivered service, got %d", got)
+	}
+	if got := total(f.do(t, f.managerB, "GET", "/api/services/entrepreneur/"+f.entrepreneur, nil)); got != 1 {
+		t.Fatalf("expected hub B manager to see 1 service, got %d", got)
+	}
+	if got := total(f.do(t, f.managerC, "GET", "/api/services/entrepreneur/"+f.entrepreneur, nil)); got != 0 {
+		t.Fatalf("expected hub C manager to see no services, got %d", got)
+	}
+	if got := total(f.do(t, f.admin, "GET", "/api/services/entrepreneur/"+f.entrepreneur, nil)); got != 2 {
+		t.Fatalf("expected admin to see 2 services, got %d", got)
+	}
+}
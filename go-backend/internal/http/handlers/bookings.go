@@ -0,0 +1,590 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/booking"
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/hours"
+	"communitycentresplatform/go-backend/internal/ical"
+)
+
+type bookableResourceRequest struct {
+	Name             string `json:"name" binding:"required,min=2,max=255"`
+	Kind             string `json:"kind" binding:"omitempty,oneof=ROOM EQUIPMENT LAB STUDIO"`
+	Description      string `json:"description"`
+	Capacity         *int   `json:"capacity" binding:"omitempty,min=1"`
+	RequiresApproval *bool  `json:"requiresApproval"`
+	Active           *bool  `json:"active"`
+}
+
+type createBookingRequest struct {
+	StartsAt  time.Time `json:"startsAt" binding:"required"`
+	EndsAt    time.Time `json:"endsAt" binding:"required"`
+	Purpose   string    `json:"purpose" binding:"max=500"`
+	Attendees *int      `json:"attendees" binding:"omitempty,min=1"`
+}
+
+type bookingDecisionRequest struct {
+	Notes *string `json:"notes"`
+}
+
+// GET /api/centers/:id/bookable-resources - List a center's bookable resources
+func ListBookableResources(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+
+	resources, err := db.ListBookableResources(gdb, center.ID, c.Query("includeInactive") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch resources"})
+		return
+	}
+
+	transformed := make([]gin.H, len(resources))
+	for i := range resources {
+		transformed[i] = transformBookableResource(&resources[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"resources": transformed, "total": len(transformed)})
+}
+
+// POST /api/centers/:id/bookable-resources - Add a bookable resource (ADMIN or the hub's CENTER_MANAGER)
+func CreateBookableResource(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req bookableResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	resource := db.BookableResource{CenterID: center.ID, Kind: "ROOM", RequiresApproval: true, Active: true}
+	applyBookableResourceRequest(&resource, &req)
+
+	if err := db.CreateBookableResource(gdb, &resource); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create resource"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Resource created successfully",
+		"resource": transformBookableResource(&resource),
+	})
+}
+
+// PUT /api/bookable-resources/:id - Update a bookable resource (ADMIN or the hub's CENTER_MANAGER)
+func UpdateBookableResource(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	resource := findBookableResourceFromParam(c, gdb)
+	if resource == nil {
+		return
+	}
+	if ok, err := canManageCenterID(c, gdb, resource.CenterID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center"})
+		return
+	} else if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req bookableResourceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	applyBookableResourceRequest(resource, &req)
+
+	if err := db.UpdateBookableResource(gdb, resource); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update resource"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Resource updated successfully",
+		"resource": transformBookableResource(resource),
+	})
+}
+
+// GET /api/bookable-resources/:id/availability - Busy slots for a resource (?from&to, RFC3339)
+func GetResourceAvailability(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	resource := findBookableResourceFromParam(c, gdb)
+	if resource == nil {
+		return
+	}
+
+	from, to, ok := bookingRangeFromQuery(c)
+	if !ok {
+		return
+	}
+
+	bookings, err := db.ListBookings(gdb, db.BookingFilters{
+		ResourceID: &resource.ID,
+		Statuses:   []db.BookingStatus{db.BookingPending, db.BookingConfirmed},
+		From:       &from,
+		To:         &to,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookings"})
+		return
+	}
+
+	// Only expose when the resource is taken, not who booked it
+	busy := make([]gin.H, len(bookings))
+	for i, b := range bookings {
+		busy[i] = gin.H{"startsAt": b.StartsAt, "endsAt": b.EndsAt, "status": b.Status}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"resource": transformBookableResource(resource),
+		"from":     from,
+		"to":       to,
+		"busy":     busy,
+	})
+}
+
+// GET /api/bookable-resources/:id/calendar.ics - iCalendar feed of confirmed bookings
+func GetResourceCalendar(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	resource := findBookableResourceFromParam(c, gdb)
+	if resource == nil {
+		return
+	}
+
+	center, err := db.FindCenterByID(gdb, resource.CenterID)
+	if err != nil || center == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center"})
+		return
+	}
+
+	// Recent history plus everything upcoming
+	from := time.Now().AddDate(0, 0, -30)
+	bookings, err := db.ListBookings(gdb, db.BookingFilters{
+		ResourceID: &resource.ID,
+		Statuses:   []db.BookingStatus{db.BookingConfirmed},
+		From:       &from,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookings"})
+		return
+	}
+
+	cal := ical.Calendar{
+		ProdID: "-//Community Centres Platform//Bookings//EN",
+		Name:   fmt.Sprintf("%s - %s", resource.Name, center.Name),
+		Events: make([]ical.Event, len(bookings)),
+	}
+	for i, b := range bookings {
+		cal.Events[i] = ical.Event{
+			UID:      b.ID.String() + "@bookings",
+			Summary:  resource.Name + " booked",
+			Location: center.Name + ", " + center.Location,
+			Status:   "CONFIRMED",
+			Start:    b.StartsAt,
+			End:      b.EndsAt,
+			Stamp:    b.UpdatedAt,
+		}
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, resource.ID))
+	c.Status(http.StatusOK)
+	if err := cal.Write(c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// POST /api/bookable-resources/:id/bookings - Reserve a time slot
+func CreateBooking(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	resource := findBookableResourceFromParam(c, gdb)
+	if resource == nil {
+		return
+	}
+	if !resource.Active {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "resource is not available for booking"})
+		return
+	}
+
+	userID, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req createBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	center, err := db.FindCenterByID(gdb, resource.CenterID)
+	if err != nil || center == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center"})
+		return
+	}
+	now := time.Now()
+	schedule, err := hours.Load(gdb, center, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load opening hours"})
+		return
+	}
+
+	if err := booking.ValidateSlot(req.StartsAt, req.EndsAt, now, schedule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := booking.ValidateAttendees(resource, req.Attendees); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	b := db.Booking{
+		ResourceID: resource.ID,
+		CenterID:   resource.CenterID,
+		UserID:     userID,
+		StartsAt:   req.StartsAt.UTC(),
+		EndsAt:     req.EndsAt.UTC(),
+		Status:     booking.InitialStatus(resource),
+		Purpose:    strings.TrimSpace(req.Purpose),
+		Attendees:  req.Attendees,
+	}
+	if err := db.CreateBooking(gdb, &b); err != nil {
+		if errors.Is(err, db.ErrBookingConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": "the resource is already booked for this time"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create booking"})
+		return
+	}
+	b.Resource = *resource
+
+	emitBookingUpdate(c, &b, "created")
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Booking created successfully",
+		"booking": transformBooking(&b),
+	})
+}
+
+// GET /api/centers/:id/bookings - List a center's bookings (ADMIN or the hub's CENTER_MANAGER)
+func ListCenterBookings(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	filters := db.BookingFilters{CenterID: &center.ID}
+	if status := strings.ToUpper(c.Query("status")); status != "" {
+		filters.Statuses = []db.BookingStatus{db.BookingStatus(status)}
+	}
+	if c.Query("from") != "" || c.Query("to") != "" {
+		from, to, ok := bookingRangeFromQuery(c)
+		if !ok {
+			return
+		}
+		filters.From, filters.To = &from, &to
+	}
+
+	bookings, err := db.ListBookings(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookings"})
+		return
+	}
+
+	transformed := make([]gin.H, len(bookings))
+	for i := range bookings {
+		transformed[i] = transformBooking(&bookings[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bookings": transformed, "total": len(transformed)})
+}
+
+// GET /api/bookings/mine - List the current user's bookings
+func ListMyBookings(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	userID, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	bookings, err := db.ListBookings(gdb, db.BookingFilters{UserID: &userID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch bookings"})
+		return
+	}
+
+	transformed := make([]gin.H, len(bookings))
+	for i := range bookings {
+		transformed[i] = transformBooking(&bookings[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bookings": transformed, "total": len(transformed)})
+}
+
+// PATCH /api/bookings/:id/approve - Confirm a pending booking (ADMIN or the hub's CENTER_MANAGER)
+func ApproveBooking(c *gin.Context) {
+	decideBooking(c, db.BookingConfirmed, "approved")
+}
+
+// PATCH /api/bookings/:id/reject - Reject a pending booking (ADMIN or the hub's CENTER_MANAGER)
+func RejectBooking(c *gin.Context) {
+	decideBooking(c, db.BookingRejected, "rejected")
+}
+
+// PATCH /api/bookings/:id/cancel - Cancel a booking (the booker, ADMIN or the hub's CENTER_MANAGER)
+func CancelBooking(c *gin.Context) {
+	decideBooking(c, db.BookingCancelled, "cancelled")
+}
+
+// decideBooking applies a status change after checking permissions and the
+// allowed transitions. Bookers may only cancel their own bookings.
+func decideBooking(c *gin.Context, status db.BookingStatus, action string) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	bookingID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid booking id"})
+		return
+	}
+
+	b, err := db.FindBookingByID(gdb, bookingID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch booking"})
+		return
+	}
+	if b == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "booking not found"})
+		return
+	}
+
+	manages, err := canManageCenterID(c, gdb, b.CenterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center"})
+		return
+	}
+	isBooker := b.UserID.String() == ctxutil.UserIDFrom(c)
+	if !manages && !(isBooker && status == db.BookingCancelled) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you cannot change this booking"})
+		return
+	}
+
+	if err := booking.CheckTransition(b.Status, status); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "status": b.Status})
+		return
+	}
+
+	var req bookingDecisionRequest
+	_ = c.ShouldBindJSON(&req) // notes are optional
+
+	var reviewer *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		reviewer = &uid
+	}
+	if err := db.UpdateBookingStatus(gdb, b, status, reviewer, req.Notes); err != nil {
+		if errors.Is(err, db.ErrBookingStatusChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update booking"})
+		return
+	}
+
+	emitBookingUpdate(c, b, action)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Booking " + action + " successfully",
+		"booking": transformBooking(b),
+	})
+}
+
+// canManageCenterID loads a center and checks canManageCenter
+func canManageCenterID(c *gin.Context, gdb *gorm.DB, centerID uuid.UUID) (bool, error) {
+	center, err := db.FindCenterByID(gdb, centerID)
+	if err != nil {
+		return false, err
+	}
+	return center != nil && canManageCenter(c, center), nil
+}
+
+// findBookableResourceFromParam loads the resource named by the :id
+// parameter, writing the error response and returning nil when it cannot
+func findBookableResourceFromParam(c *gin.Context, gdb *gorm.DB) *db.BookableResource {
+	resourceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid resource id"})
+		return nil
+	}
+
+	resource, err := db.FindBookableResourceByID(gdb, resourceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch resource"})
+		return nil
+	}
+	if resource == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "resource not found"})
+		return nil
+	}
+	return resource
+}
+
+// bookingRangeFromQuery parses ?from and ?to (RFC3339), defaulting to the
+// next 14 days
+func bookingRangeFromQuery(c *gin.Context) (time.Time, time.Time, bool) {
+	from := time.Now()
+	to := from.AddDate(0, 0, 14)
+	if v := c.Query("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be an RFC3339 timestamp"})
+			return time.Time{}, time.Time{}, false
+		}
+		from = t
+	}
+	if v := c.Query("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be an RFC3339 timestamp"})
+			return time.Time{}, time.Time{}, false
+		}
+		to = t
+	}
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+func emitBookingUpdate(c *gin.Context, b *db.Booking, action string) {
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitBookingUpdate(b.CenterID.String(), b.UserID.String(), gin.H{
+			"id":         b.ID,
+			"resourceId": b.ResourceID,
+			"centerId":   b.CenterID,
+			"startsAt":   b.StartsAt,
+			"endsAt":     b.EndsAt,
+			"status":     b.Status,
+			"action":     action,
+		})
+	}
+}
+
+func applyBookableResourceRequest(resource *db.BookableResource, req *bookableResourceRequest) {
+	resource.Name = strings.TrimSpace(req.Name)
+	if req.Kind != "" {
+		resource.Kind = req.Kind
+	}
+	resource.Description = req.Description
+	resource.Capacity = req.Capacity
+	if req.RequiresApproval != nil {
+		resource.RequiresApproval = *req.RequiresApproval
+	}
+	if req.Active != nil {
+		resource.Active = *req.Active
+	}
+}
+
+func transformBookableResource(r *db.BookableResource) gin.H {
+	return gin.H{
+		"id":               r.ID,
+		"centerId":         r.CenterID,
+		"name":             r.Name,
+		"kind":             r.Kind,
+		"description":      r.Description,
+		"capacity":         r.Capacity,
+		"requiresApproval": r.RequiresApproval,
+		"active":           r.Active,
+		"createdAt":        r.CreatedAt,
+		"updatedAt":        r.UpdatedAt,
+	}
+}
+
+func transformBooking(b *db.Booking) gin.H {
+	return gin.H{
+		"id":           b.ID,
+		"resourceId":   b.ResourceID,
+		"resourceName": b.Resource.Name,
+		"centerId":     b.CenterID,
+		"userId":       b.UserID,
+		"startsAt":     b.StartsAt,
+		"endsAt":       b.EndsAt,
+		"status":       b.Status,
+		"purpose":      b.Purpose,
+		"attendees":    b.Attendees,
+		"reviewedBy":   b.ReviewedBy,
+		"notes":        b.Notes,
+		"createdAt":    b.CreatedAt,
+		"updatedAt":    b.UpdatedAt,
+	}
+}
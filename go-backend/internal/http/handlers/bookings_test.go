@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBookingDecisionConflict(t *testing.T) {
+	f := newHubFixture(t)
+	now := time.Now()
+	resource, booking := uuid.NewString(), uuid.NewString()
+	f.fake.insert("bookable_resources", map[string]interface{}{
+		"id": resource, "center_id": f.hubA, "name": "Board room", "kind": "ROOM", "requires_approval": true,
+		"active": true, "created_at": now, "updated_at": now,
+	})
+	f.fake.insert("resource_bookings", map[string]interface{}{
+		"id": booking, "resource_id": resource, "center_id": f.hubA, "user_id": f.founder,
+		"starts_at": now.Add(24 * time.Hour), "ends_at": now.Add(26 * time.Hour), "status": "PENDING",
+		"created_at": now, "updated_at": now,
+	})
+
+	// The booker cancels while the manager approves
+	f.fake.race("resource_bookings", booking, map[string]interface{}{"status": "CANCELLED"})
+	if w := f.do(t, f.managerA, "PATCH", "/api/bookings/"+booking+"/approve", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected a stale approval to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := f.fake.row("resource_bookings", booking)["status"]; got != "CANCELLED" {
+		t.Fatalf("expected the cancellation to stand, got %v", got)
+	}
+
+	if w := f.do(t, f.managerA, "PATCH", "/api/bookings/"+booking+"/approve", nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected approving a cancelled booking to conflict, got %d", w.Code)
+	}
+}
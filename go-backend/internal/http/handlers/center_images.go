@@ -0,0 +1,426 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/media"
+	"communitycentresplatform/go-backend/internal/storage"
+)
+
+// maxGalleryImages caps a center's photo gallery
+const maxGalleryImages = 30
+
+type centerImageRequest struct {
+	Caption *string `json:"caption" binding:"omitempty,max=500"`
+}
+
+type galleryOrderRequest struct {
+	ImageIDs []string `json:"imageIds" binding:"required"`
+}
+
+// POST /api/centers/:id/images - Upload a cover, logo or gallery photo (multipart "file", "role", "caption")
+func UploadCenterImage(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+	store := ctxutil.StorageFrom(c)
+	if store == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "storage unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+	if !parseUploadForm(c) {
+		return
+	}
+
+	role := db.CenterImageGallery
+	if raw := strings.TrimSpace(c.PostForm("role")); raw != "" {
+		role = db.CenterImageRole(strings.ToUpper(raw))
+	}
+	sizes := media.PhotoSizes
+	switch role {
+	case db.CenterImageCover:
+	case db.CenterImageLogo:
+		sizes = media.LogoSizes
+	case db.CenterImageGallery:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "role must be COVER, LOGO or GALLERY"})
+		return
+	}
+	var caption *string
+	if raw := strings.TrimSpace(c.PostForm("caption")); raw != "" {
+		if len(raw) > 500 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "caption must be at most 500 characters"})
+			return
+		}
+		caption = &raw
+	}
+
+	position := 0
+	if role == db.CenterImageGallery {
+		count, err := db.CountCenterImages(gdb, center.ID, role)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center images"})
+			return
+		}
+		if count >= maxGalleryImages {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "the gallery is full; delete a photo first"})
+			return
+		}
+		position = int(count)
+	}
+
+	header := formFile(c)
+	if header == nil {
+		return
+	}
+	file, _, kind := openUploadedFile(c, header)
+	if file == nil {
+		return
+	}
+	defer file.Close()
+	if kind != db.MediaImage {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "center images must be JPEG, PNG or GIF"})
+		return
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return
+	}
+	img, format, err := media.DecodeImage(data)
+	switch {
+	case err == nil:
+	case errors.Is(err, image.ErrFormat):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "center images must be JPEG, PNG or GIF"})
+		return
+	case errors.Is(err, media.ErrTooManyPixels):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "image could not be read"})
+		return
+	}
+
+	renditions, err := media.RenderSizes(img, format, sizes)
+	if err != nil {
+		log.Printf("Failed to resize center image: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to resize image"})
+		return
+	}
+	// Renditions share the key of the upload, with the size appended
+	uploadKey := storage.NewKey("centers/"+center.ID.String(), header.Filename)
+	base := strings.TrimSuffix(uploadKey, path.Ext(uploadKey))
+	stored := make(db.ImageRenditions, 0, len(renditions))
+	for _, r := range renditions {
+		ext := ".jpg"
+		if r.ContentType == "image/png" {
+			ext = ".png"
+		}
+		key := base + "-" + r.Size + ext
+		if err := store.Put(c.Request.Context(), key, bytes.NewReader(r.Data), int64(len(r.Data)), r.ContentType); err != nil {
+			log.Printf("Failed to store center image: %v", err)
+			discardStored(c, store, renditionKeys(stored)...)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store image"})
+			return
+		}
+		stored = append(stored, db.ImageRendition{Size: r.Size, Key: key, ContentType: r.ContentType, Width: r.Width, Height: r.Height})
+	}
+
+	uploaderID, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	centerImage := db.CenterImage{
+		CenterID:   center.ID,
+		Role:       role,
+		Caption:    caption,
+		Position:   position,
+		Width:      img.Bounds().Dx(),
+		Height:     img.Bounds().Dy(),
+		Renditions: stored,
+		UploadedBy: uploaderID,
+	}
+	replaced, err := db.CreateCenterImage(gdb, &centerImage)
+	if err != nil {
+		discardStored(c, store, renditionKeys(stored)...)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save image"})
+		return
+	}
+	for _, old := range replaced {
+		discardStored(c, store, renditionKeys(old.Renditions)...)
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Image uploaded successfully",
+		"image":   transformCenterImage(&centerImage),
+	})
+}
+
+// GET /api/centers/:id/images/:imageId/:size - Get one size of a center image
+func GetCenterImage(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+	store := ctxutil.StorageFrom(c)
+	if store == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "storage unavailable"})
+		return
+	}
+
+	centerImage := findCenterImageFromParam(c, gdb)
+	if centerImage == nil {
+		return
+	}
+	for _, r := range centerImage.Renditions {
+		if r.Size == c.Param("size") {
+			serveStored(c, store, r.Key, r.ContentType, path.Base(r.Key))
+			return
+		}
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "image size not found"})
+}
+
+// PUT /api/centers/:id/images/:imageId - Update an image's caption
+func UpdateCenterImage(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+	centerImage := findCenterImageFromParam(c, gdb)
+	if centerImage == nil {
+		return
+	}
+
+	var req centerImageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	centerImage.Caption = nil
+	if req.Caption != nil {
+		if trimmed := strings.TrimSpace(*req.Caption); trimmed != "" {
+			centerImage.Caption = &trimmed
+		}
+	}
+	if err := db.SaveCenterImage(gdb, centerImage); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update image"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Image updated successfully",
+		"image":   transformCenterImage(centerImage),
+	})
+}
+
+// PUT /api/centers/:id/gallery - Reorder the gallery; imageIds lists every gallery photo in the new order
+func ReorderCenterGallery(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req galleryOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	gallery, err := db.ListCenterImages(gdb, []uuid.UUID{center.ID}, []db.CenterImageRole{db.CenterImageGallery})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center images"})
+		return
+	}
+	byID := make(map[uuid.UUID]*db.CenterImage, len(gallery))
+	for i := range gallery {
+		byID[gallery[i].ID] = &gallery[i]
+	}
+	if len(req.ImageIDs) != len(gallery) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "imageIds must list every gallery photo once"})
+		return
+	}
+	ids := make([]uuid.UUID, len(req.ImageIDs))
+	ordered := make([]gin.H, len(req.ImageIDs))
+	for i, raw := range req.ImageIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid image id"})
+			return
+		}
+		centerImage, ok := byID[id]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "imageIds must list every gallery photo once"})
+			return
+		}
+		delete(byID, id)
+		centerImage.Position = i
+		ids[i] = id
+		ordered[i] = transformCenterImage(centerImage)
+	}
+	if err := db.ReorderCenterGallery(gdb, center.ID, ids); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to reorder gallery"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Gallery reordered successfully", "gallery": ordered})
+}
+
+// DELETE /api/centers/:id/images/:imageId - Delete a center image
+func DeleteCenterImage(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+	centerImage := findCenterImageFromParam(c, gdb)
+	if centerImage == nil {
+		return
+	}
+
+	if err := db.DeleteCenterImage(gdb, centerImage.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete image"})
+		return
+	}
+	discardStored(c, ctxutil.StorageFrom(c), renditionKeys(centerImage.Renditions)...)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Image deleted successfully"})
+}
+
+// findCenterImageFromParam loads the image named by the :id and :imageId
+// parameters. It writes the error response and returns nil when it cannot.
+func findCenterImageFromParam(c *gin.Context, gdb *gorm.DB) *db.CenterImage {
+	centerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid center id"})
+		return nil
+	}
+	imageID, err := uuid.Parse(c.Param("imageId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid image id"})
+		return nil
+	}
+	centerImage, err := db.FindCenterImage(gdb, centerID, imageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch image"})
+		return nil
+	}
+	if centerImage == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "image not found"})
+		return nil
+	}
+	return centerImage
+}
+
+// centerImageSets groups images by center into responsive image sets:
+// cover and logo (or nil) and the ordered gallery
+func centerImageSets(images []db.CenterImage) map[uuid.UUID]gin.H {
+	sets := make(map[uuid.UUID]gin.H)
+	for i := range images {
+		img := &images[i]
+		set, ok := sets[img.CenterID]
+		if !ok {
+			set = emptyCenterImageSet()
+			sets[img.CenterID] = set
+		}
+		switch img.Role {
+		case db.CenterImageCover:
+			set["cover"] = transformCenterImage(img)
+		case db.CenterImageLogo:
+			set["logo"] = transformCenterImage(img)
+		case db.CenterImageGallery:
+			set["gallery"] = append(set["gallery"].([]gin.H), transformCenterImage(img))
+		}
+	}
+	return sets
+}
+
+// emptyCenterImageSet is the image set of a center without images
+func emptyCenterImageSet() gin.H {
+	return gin.H{"cover": nil, "logo": nil, "gallery": []gin.H{}}
+}
+
+// transformCenterImage renders an image as a responsive set: src is the
+// largest size and srcset lists every size by width
+func transformCenterImage(img *db.CenterImage) gin.H {
+	sizes := make([]gin.H, len(img.Renditions))
+	srcset := make([]string, len(img.Renditions))
+	src := ""
+	for i, r := range img.Renditions {
+		url := "/api/centers/" + img.CenterID.String() + "/images/" + img.ID.String() + "/" + r.Size
+		sizes[i] = gin.H{"size": r.Size, "url": url, "width": r.Width, "height": r.Height}
+		srcset[i] = url + " " + strconv.Itoa(r.Width) + "w"
+		src = url
+	}
+	return gin.H{
+		"id":        img.ID,
+		"role":      img.Role,
+		"caption":   img.Caption,
+		"position":  img.Position,
+		"width":     img.Width,
+		"height":    img.Height,
+		"src":       src,
+		"srcset":    strings.Join(srcset, ", "),
+		"sizes":     sizes,
+		"createdAt": img.CreatedAt,
+	}
+}
+
+func renditionKeys(renditions db.ImageRenditions) []string {
+	keys := make([]string, len(renditions))
+	for i, r := range renditions {
+		keys[i] = r.Key
+	}
+	return keys
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCenterImages(t *testing.T) {
+	f := newHubFixture(t)
+	photo := testPNG(t)
+
+	w := f.upload(t, f.managerB, "/api/centers/"+f.hubA+"/images", "cover.png", photo, map[string]string{"role": "COVER"})
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("expected another hub's manager to be refused, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := f.fake.writeCount("center_images"); n != 0 {
+		t.Fatalf("expected no writes to center_images, got %d", n)
+	}
+
+	w = f.upload(t, f.managerA, "/api/centers/"+f.hubA+"/images", "cover.png", photo, map[string]string{"role": "COVER"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected the manager to upload a cover, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Image struct {
+			Srcset string `json:"srcset"`
+			Sizes  []struct {
+				Size  string `json:"size"`
+				Width int    `json:"width"`
+			} `json:"sizes"`
+		} `json:"image"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	// 640px wide: a 480px small size, and the original size as medium
+	sizes := created.Image.Sizes
+	if len(sizes) != 2 || sizes[0].Width != 480 || sizes[1].Size != "medium" || sizes[1].Width != 640 ||
+		!strings.HasSuffix(created.Image.Srcset, "/medium 640w") {
+		t.Fatalf("unexpected image set %s", w.Body.String())
+	}
+
+	// The center page returns the image set and each size is public
+	imageID := uuid.NewString()
+	if err := f.blob.Put(context.Background(), "centers/logo-small.png", bytes.NewReader(photo), int64(len(photo)), "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	f.fake.insert("center_images", map[string]interface{}{
+		"id": imageID, "center_id": f.hubA, "role": "LOGO", "caption": nil, "position": 0, "width": 96, "height": 72,
+		"renditions":  `[{"size":"small","key":"centers/logo-small.png","contentType":"image/png","width":96,"height":72}]`,
+		"uploaded_by": f.managerA, "created_at": time.Now(), "updated_at": time.Now(),
+	})
+	w = f.do(t, "", "GET", "/api/centers/"+f.hubA, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected the center, got %d: %s", w.Code, w.Body.String())
+	}
+	var got struct {
+		Center struct {
+			Images struct {
+				Logo struct {
+					Src string `json:"src"`
+				} `json:"logo"`
+				Gallery []interface{} `json:"gallery"`
+			} `json:"images"`
+		} `json:"center"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if got.Center.Images.Logo.Src == "" || got.Center.Images.Gallery == nil {
+		t.Fatalf("expected a logo and an empty gallery, got %s", w.Body.String())
+	}
+	if w := f.do(t, "", "GET", got.Center.Images.Logo.Src, nil); w.Code != http.StatusOK || w.Body.Len() != len(photo) {
+		t.Fatalf("expected the logo to be served, got %d", w.Code)
+	}
+	if w := f.do(t, "", "GET", "/api/centers/"+f.hubA+"/images/"+imageID+"/large", nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected a missing size to 404, got %d", w.Code)
+	}
+}
@@ -2,13 +2,18 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"communitycentresplatform/go-backend/internal/ctxutil"
 	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/recommend"
+	"communitycentresplatform/go-backend/internal/taxonomy"
 )
 
 // GET /api/centers - List all centers with filtering
@@ -19,28 +24,8 @@ func ListCenters(c *gin.Context) {
 		return
 	}
 
-	// Parse query parameters
-	searchQuery := c.Query("searchQuery")
-	servicesStr := c.Query("services")
-	locationsStr := c.Query("locations")
-	verificationStatus := c.Query("verificationStatus")
 	connectionStatus := c.Query("connectionStatus")
-
-	// Build filters
-	filters := db.CenterFilters{
-		SearchQuery:        searchQuery,
-		VerificationStatus: verificationStatus,
-	}
-
-	// Parse services (comma-separated)
-	if servicesStr != "" {
-		filters.Services = strings.Split(servicesStr, ",")
-	}
-
-	// Parse locations (comma-separated)
-	if locationsStr != "" {
-		filters.Locations = strings.Split(locationsStr, ",")
-	}
+	filters := centerFiltersFromQuery(c, gdb)
 
 	// Fetch centers
 	centers, err := db.ListCenters(gdb, filters)
@@ -49,6 +34,18 @@ func ListCenters(c *gin.Context) {
 		return
 	}
 
+	// Cover and logo for the cards; the gallery is only on the center page
+	centerIDs := make([]uuid.UUID, len(centers))
+	for i, center := range centers {
+		centerIDs[i] = center.ID
+	}
+	images, err := db.ListCenterImages(gdb, centerIDs, []db.CenterImageRole{db.CenterImageCover, db.CenterImageLogo})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center images"})
+		return
+	}
+	imageSets := centerImageSets(images)
+
 	// Transform to match Node.js response format
 	transformedCenters := make([]gin.H, len(centers))
 	for i, center := range centers {
@@ -69,6 +66,12 @@ func ListCenters(c *gin.Context) {
 			addedBy = "admin"
 		}
 
+		imageSet, ok := imageSets[center.ID]
+		if !ok {
+			imageSet = emptyCenterImageSet()
+		}
+		delete(imageSet, "gallery")
+
 		transformedCenters[i] = gin.H{
 			"id":          center.ID,
 			"name":        center.Name,
@@ -80,6 +83,7 @@ func ListCenters(c *gin.Context) {
 			"verified":    center.Verified,
 			"connections": connectionIDs,
 			"addedBy":     addedBy,
+			"images":      imageSet,
 			"contactInfo": gin.H{
 				"phone":   center.Phone,
 				"email":   center.Email,
@@ -103,7 +107,73 @@ func ListCenters(c *gin.Context) {
 		filteredCenters = filtered
 	}
 
-	c.JSON(http.StatusOK, gin.H{"centers": filteredCenters})
+	// Facet counts for the filter panel (connectionStatus is not reflected)
+	facets, err := db.CountCenterFacets(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch facets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"centers": filteredCenters, "facets": facets})
+}
+
+// GET /api/centers/facets - Facet counts for the center filter panel
+func GetCenterFacets(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	facets, err := db.CountCenterFacets(gdb, centerFiltersFromQuery(c, gdb))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch facets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"facets": facets})
+}
+
+// centerFiltersFromQuery builds center filters from the list query parameters
+func centerFiltersFromQuery(c *gin.Context, gdb *gorm.DB) db.CenterFilters {
+	// Parse query parameters
+	searchQuery := c.Query("searchQuery")
+	servicesStr := c.Query("services")
+	locationsStr := c.Query("locations")
+	verificationStatus := c.Query("verificationStatus")
+
+	// Build filters
+	filters := db.CenterFilters{
+		SearchQuery:        searchQuery,
+		VerificationStatus: verificationStatus,
+	}
+
+	// Parse services (comma-separated), mapping variants onto taxonomy names
+	if servicesStr != "" {
+		filters.Services = strings.Split(servicesStr, ",")
+		if idx, err := taxonomy.Load(gdb); err == nil && !idx.Empty(db.TaxonomyService) {
+			for i, s := range filters.Services {
+				if entry, ok := idx.Resolve(db.TaxonomyService, s); ok {
+					filters.Services[i] = entry.Name
+				}
+			}
+		}
+	}
+
+	// Parse locations (comma-separated)
+	if locationsStr != "" {
+		filters.Locations = strings.Split(locationsStr, ",")
+	}
+
+	// Only centers open right now (or at ?openAt=RFC3339)
+	if t, err := time.Parse(time.RFC3339, c.Query("openAt")); err == nil {
+		filters.OpenAt = &t
+	} else if c.Query("openNow") == "true" {
+		now := time.Now()
+		filters.OpenAt = &now
+	}
+
+	return filters
 }
 
 // GET /api/centers/:id - Get single center by ID
@@ -165,6 +235,16 @@ func GetCenter(c *gin.Context) {
 		addedBy = "admin"
 	}
 
+	images, err := db.ListCenterImages(gdb, []uuid.UUID{center.ID}, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center images"})
+		return
+	}
+	imageSet, ok := centerImageSets(images)[center.ID]
+	if !ok {
+		imageSet = emptyCenterImageSet()
+	}
+
 	transformedCenter := gin.H{
 		"id":               center.ID,
 		"name":             center.Name,
@@ -177,6 +257,10 @@ func GetCenter(c *gin.Context) {
 		"connections":      connectionIDs,
 		"connectedCenters": connectedCenters,
 		"addedBy":          addedBy,
+		"timeZone":         center.TimeZone,
+		"capacity":         center.Capacity,
+		"workspaceSeats":   center.WorkspaceSeats,
+		"images":           imageSet,
 		"contactInfo": gin.H{
 			"phone":   center.Phone,
 			"email":   center.Email,
@@ -214,6 +298,21 @@ func CreateCenter(c *gin.Context) {
 		return
 	}
 
+	// Validate services and resources against the taxonomy
+	services, resources, unknownServices, unknownResources, err := canonicalizeCenterTerms(gdb, req.Services, req.Resources)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load taxonomy"})
+		return
+	}
+	if len(unknownServices) > 0 || len(unknownResources) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":            "unknown services or resources",
+			"unknownServices":  unknownServices,
+			"unknownResources": unknownResources,
+		})
+		return
+	}
+
 	userID := ctxutil.UserIDFrom(c)
 	role := ctxutil.RoleFrom(c)
 
@@ -258,8 +357,8 @@ func CreateCenter(c *gin.Context) {
 		Latitude:    req.Latitude,
 		Longitude:   req.Longitude,
 		Description: req.Description,
-		Services:    db.StringArray(req.Services),
-		Resources:   db.StringArray(req.Resources),
+		Services:    db.StringArray(services),
+		Resources:   db.StringArray(resources),
 		AddedBy:     userID,
 		Verified:    role == "ADMIN", // Only ADMIN-created centers are auto-verified
 		ManagerID:   managerID,
@@ -393,7 +492,218 @@ func ConnectCenters(c *gin.Context) {
 	})
 }
 
-// PUT /api/centers - Update center (placeholder for future implementation)
+// PUT /api/centers/:id - Update center (ADMIN or the hub's CENTER_MANAGER)
 func UpdateCenter(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "not implemented yet"})
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	centerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid center id"})
+		return
+	}
+
+	center, err := db.FindCenterByID(gdb, centerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center"})
+		return
+	}
+	if center == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "center not found"})
+		return
+	}
+
+	// Verify user is CENTER_MANAGER of this hub
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req centerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	// Validate services and resources against the taxonomy
+	services, resources, unknownServices, unknownResources, err := canonicalizeCenterTerms(gdb, req.Services, req.Resources)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load taxonomy"})
+		return
+	}
+	if len(unknownServices) > 0 || len(unknownResources) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":            "unknown services or resources",
+			"unknownServices":  unknownServices,
+			"unknownResources": unknownResources,
+		})
+		return
+	}
+
+	center.Name = req.Name
+	center.Location = req.Location
+	center.Latitude = req.Latitude
+	center.Longitude = req.Longitude
+	center.Description = req.Description
+	center.Services = db.StringArray(services)
+	center.Resources = db.StringArray(resources)
+	center.Phone, center.Email, center.Website = nil, nil, nil
+	if req.Phone != "" {
+		center.Phone = &req.Phone
+	}
+	if req.Email != "" {
+		center.Email = &req.Email
+	}
+	if req.Website != "" {
+		center.Website = &req.Website
+	}
+
+	if err := db.UpdateCenter(gdb, center); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update center"})
+		return
+	}
+
+	// Emit real-time event
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitCenterUpdate(center.ID.String(), gin.H{
+			"id":     center.ID,
+			"name":   center.Name,
+			"action": "updated",
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Center updated successfully",
+		"center": gin.H{
+			"id":          center.ID,
+			"name":        center.Name,
+			"location":    center.Location,
+			"coordinates": gin.H{"lat": center.Latitude, "lng": center.Longitude},
+			"services":    center.Services,
+			"resources":   center.Resources,
+			"description": center.Description,
+			"verified":    center.Verified,
+			"contactInfo": gin.H{
+				"phone":   center.Phone,
+				"email":   center.Email,
+				"website": center.Website,
+			},
+		},
+	})
+}
+
+// canManageCenter reports whether the caller is an admin or the hub's manager
+func canManageCenter(c *gin.Context, center *db.CommunityCenter) bool {
+	if ctxutil.RoleFrom(c) == string(db.RoleAdmin) {
+		return true
+	}
+	uid, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	return err == nil && center.ManagerID != nil && *center.ManagerID == uid
+}
+
+// GET /api/centers/:id/recommendations - Suggest unconnected hubs to partner with
+func GetCenterRecommendations(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	centerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid center id"})
+		return
+	}
+
+	limit := 10
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 50 {
+			limit = parsed
+		}
+	}
+
+	center, err := db.FindCenterByID(gdb, centerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center"})
+		return
+	}
+	if center == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "center not found"})
+		return
+	}
+
+	// Exclude hubs this center is already connected to
+	connections, err := db.ListConnectionsForCenter(gdb, center.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch connections"})
+		return
+	}
+	exclude := make(map[uuid.UUID]struct{}, len(connections))
+	for _, conn := range connections {
+		if conn.CenterAID == center.ID {
+			exclude[conn.CenterBID] = struct{}{}
+		} else {
+			exclude[conn.CenterAID] = struct{}{}
+		}
+	}
+
+	shared, err := db.CountSharedEntrepreneurs(gdb, center.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch shared entrepreneurs"})
+		return
+	}
+
+	others, err := db.ListCenters(gdb, db.CenterFilters{VerificationStatus: c.Query("verificationStatus")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch centers"})
+		return
+	}
+
+	candidates := make([]recommend.Candidate, len(others))
+	for i, other := range others {
+		candidates[i] = recommend.Candidate{Hub: recommendHub(&other), SharedEntrepreneurs: shared[other.ID]}
+	}
+
+	ranked := recommend.Rank(recommendHub(center), candidates, exclude, limit)
+
+	recommendations := make([]gin.H, len(ranked))
+	for i, r := range ranked {
+		recommendations[i] = gin.H{
+			"center": gin.H{
+				"id":          r.Hub.ID,
+				"name":        r.Hub.Name,
+				"coordinates": gin.H{"lat": r.Hub.Latitude, "lng": r.Hub.Longitude},
+				"services":    r.Hub.Services,
+				"resources":   r.Hub.Resources,
+			},
+			"score": r.Score,
+			"breakdown": gin.H{
+				"services":      r.ServiceScore,
+				"resources":     r.ResourceScore,
+				"proximity":     r.ProximityScore,
+				"entrepreneurs": r.EntrepreneurScore,
+			},
+			"distanceKm":             r.DistanceKm,
+			"complementaryServices":  r.ComplementaryServices,
+			"complementaryResources": r.ComplementaryResources,
+			"sharedEntrepreneurs":    r.SharedEntrepreneurs,
+			"reasons":                r.Reasons,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recommendations": recommendations})
+}
+
+func recommendHub(center *db.CommunityCenter) recommend.Hub {
+	return recommend.Hub{
+		ID:        center.ID,
+		Name:      center.Name,
+		Latitude:  center.Latitude,
+		Longitude: center.Longitude,
+		Services:  center.Services,
+		Resources: center.Resources,
+	}
 }
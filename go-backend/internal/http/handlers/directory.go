@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/directory"
+)
+
+// GET /api/directory/entrepreneurs - Search verified entrepreneurs (public; signed-in users see more)
+func GetEntrepreneurDirectory(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	filters := db.DirectoryFilters{
+		SearchQuery:  c.Query("q"),
+		BusinessType: c.Query("businessType"),
+		Visibilities: []db.Visibility{db.VisibilityPublic},
+	}
+	if raw := c.Query("hubId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+			return
+		}
+		filters.HubID = &id
+	}
+	signedIn := ctxutil.UserIDFrom(c) != ""
+	if signedIn {
+		filters.Visibilities = append(filters.Visibilities, db.VisibilityNetwork)
+	}
+
+	page, limit := paginationFromQuery(c)
+	filters.Limit, filters.Offset = limit, (page-1)*limit
+	entrepreneurs, err := db.ListDirectoryEntrepreneurs(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch entrepreneurs"})
+		return
+	}
+	total, err := db.CountDirectoryEntrepreneurs(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count entrepreneurs"})
+		return
+	}
+
+	managed := map[uuid.UUID]bool{}
+	if signedIn {
+		ids, err := callerManagedCenterIDs(c, gdb)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hubs"})
+			return
+		}
+		for _, id := range ids {
+			managed[id] = true
+		}
+	}
+
+	transformed := make([]gin.H, len(entrepreneurs))
+	for i := range entrepreneurs {
+		e := &entrepreneurs[i]
+		transformed[i] = transformDirectoryEntrepreneur(e, audienceWithin(c, e, managed))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entrepreneurs": transformed,
+		"pagination": gin.H{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+// GET /api/directory/entrepreneurs/:id - Get a directory profile (public; signed-in users see more)
+func GetDirectoryEntrepreneur(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	entrepreneurID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entrepreneur id"})
+		return
+	}
+
+	var entrepreneur db.Entrepreneur
+	if err := gdb.Preload("User").Preload("Enrollments.Hub").First(&entrepreneur, "id = ?", entrepreneurID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entrepreneur not found"})
+		return
+	}
+	audience, err := entrepreneurAudience(c, gdb, &entrepreneur)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	// Hidden profiles look the same as missing ones
+	if !directory.Listed(&entrepreneur, audience) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entrepreneur not found"})
+		return
+	}
+
+	items, err := visiblePortfolio(gdb, &entrepreneur, audience)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch portfolio"})
+		return
+	}
+	out := transformDirectoryEntrepreneur(&entrepreneur, audience)
+	out["portfolio"] = items
+	c.JSON(http.StatusOK, gin.H{"entrepreneur": out})
+}
+
+// entrepreneurAudience works out how much of an entrepreneur's profile the
+// caller may see
+func entrepreneurAudience(c *gin.Context, gdb *gorm.DB, e *db.Entrepreneur) (directory.Audience, error) {
+	if ctxutil.UserIDFrom(c) == "" {
+		return directory.AudiencePublic, nil
+	}
+	ids, err := callerManagedCenterIDs(c, gdb)
+	if err != nil {
+		return directory.AudiencePublic, err
+	}
+	managed := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		managed[id] = true
+	}
+	if e.Enrollments == nil {
+		if err := gdb.Where("entrepreneur_id = ?", e.ID).Find(&e.Enrollments).Error; err != nil {
+			return directory.AudiencePublic, err
+		}
+	}
+	return audienceWithin(c, e, managed), nil
+}
+
+// audienceWithin classifies a signed-in caller given the hubs they manage and
+// the entrepreneur's loaded enrollments; any enrollment, even a pending one,
+// lets the hub's manager see private details
+func audienceWithin(c *gin.Context, e *db.Entrepreneur, managed map[uuid.UUID]bool) directory.Audience {
+	uid, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		return directory.AudiencePublic
+	}
+	if ctxutil.RoleFrom(c) == string(db.RoleAdmin) || e.UserID == uid {
+		return directory.AudienceOwner
+	}
+	for _, enrollment := range e.Enrollments {
+		if managed[enrollment.HubID] {
+			return directory.AudienceHub
+		}
+	}
+	return directory.AudienceMember
+}
+
+// transformDirectoryEntrepreneur renders a directory entry, revealing contact
+// details only as the entrepreneur's settings allow. Hubs are those the
+// entrepreneur is actively enrolled with or has graduated from.
+func transformDirectoryEntrepreneur(e *db.Entrepreneur, audience directory.Audience) gin.H {
+	hubs := []gin.H{}
+	for _, enrollment := range e.Enrollments {
+		if enrollment.Status != db.EnrollmentActive && enrollment.Status != db.EnrollmentCompleted {
+			continue
+		}
+		if enrollment.Hub.ID == uuid.Nil {
+			continue
+		}
+		hubs = append(hubs, gin.H{
+			"id":       enrollment.Hub.ID,
+			"name":     enrollment.Hub.Name,
+			"location": enrollment.Hub.Location,
+			"status":   enrollment.Status,
+		})
+	}
+	contact := directory.ContactFor(e, audience)
+	out := gin.H{
+		"id":           e.ID,
+		"businessName": e.BusinessName,
+		"businessType": e.BusinessType,
+		"description":  e.Description,
+		"verified":     e.Verified,
+		"phone":        contact.Phone,
+		"email":        contact.Email,
+		"website":      contact.Website,
+		"hubs":         hubs,
+		"createdAt":    e.CreatedAt,
+	}
+	if audience >= directory.AudienceMember && e.User.ID != uuid.Nil {
+		out["founderName"] = e.User.Name
+	}
+	return out
+}
+
+// transformPrivacySettings renders an entrepreneur's settings for their own
+// profile views
+func transformPrivacySettings(e *db.Entrepreneur) gin.H {
+	s := directory.SettingsOf(e)
+	return gin.H{
+		"profile": s.Profile,
+		"phone":   s.Phone,
+		"email":   s.Email,
+		"website": s.Website,
+	}
+}
@@ -1,19 +1,23 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"communitycentresplatform/go-backend/internal/ctxutil"
 	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/lifecycle"
 )
 
 type enrollmentRequest struct {
-	HubID          string `json:"hubId" binding:"required"`
-	EntrepreneurID string `json:"entrepreneurId" binding:"required"`
+	HubID          string  `json:"hubId" binding:"required"`
+	EntrepreneurID string  `json:"entrepreneurId" binding:"required"`
+	CohortID       *string `json:"cohortId"` // Optional cohort of one of the hub's programs
 }
 
 // POST /api/enrollments - Create enrollment (CENTER_MANAGER creates, ENTREPRENEUR requests)
@@ -71,11 +75,44 @@ func CreateEnrollment(c *gin.Context) {
 	} else if role != string(db.RoleCenterManager) && role != string(db.RoleAdmin) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
 		return
+	} else if !canManageCenter(c, &hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only enroll entrepreneurs in hubs you manage"})
+		return
+	}
+
+	// Hubs that run an application form select entrepreneurs through it
+	if role == string(db.RoleEntrepreneur) {
+		form, err := db.FindOpenApplicationForm(gdb, hubID, time.Now())
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check application forms"})
+			return
+		}
+		if form != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": "this hub enrolls through its application form", "formId": form.ID})
+			return
+		}
 	}
 
-	// Check for existing enrollment
+	// Resolve the cohort, checking its application window and capacity
+	var cohortID *uuid.UUID
+	if req.CohortID != nil && *req.CohortID != "" {
+		ch, status, msg := admitToCohort(gdb, *req.CohortID, hubID, canManageCenter(c, &hub))
+		if status != 0 {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
+		cohortID = &ch.ID
+	}
+
+	// Check for existing enrollment (per cohort, so alumni can join a later cohort)
 	var existing db.HubEnrollment
-	if err := gdb.Where("hub_id = ? AND entrepreneur_id = ?", hubID, entrepreneurID).First(&existing).Error; err == nil {
+	existingQuery := gdb.Where("hub_id = ? AND entrepreneur_id = ?", hubID, entrepreneurID)
+	if cohortID != nil {
+		existingQuery = existingQuery.Where("cohort_id = ?", *cohortID)
+	} else {
+		existingQuery = existingQuery.Where("cohort_id IS NULL")
+	}
+	if err := existingQuery.First(&existing).Error; err == nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "enrollment already exists"})
 		return
 	}
@@ -93,11 +130,22 @@ func CreateEnrollment(c *gin.Context) {
 	enrollment := db.HubEnrollment{
 		HubID:          hubID,
 		EntrepreneurID: entrepreneurID,
+		CohortID:       cohortID,
 		Status:         initialStatus,
 		EnrollmentDate: enrollmentDate,
 	}
 
-	if err := gdb.Create(&enrollment).Error; err != nil {
+	var actor *uuid.UUID
+	if uid, err := uuid.Parse(userID); err == nil {
+		actor = &uid
+	}
+	err = gdb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&enrollment).Error; err != nil {
+			return err
+		}
+		return lifecycle.RecordInitial(tx, db.StatusEntityEnrollment, enrollment.ID, string(enrollment.Status), actor, nil)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create enrollment"})
 		return
 	}
@@ -122,6 +170,7 @@ func CreateEnrollment(c *gin.Context) {
 			"id":             enrollment.ID,
 			"hubId":          enrollment.HubID,
 			"entrepreneurId": enrollment.EntrepreneurID,
+			"cohortId":       enrollment.CohortID,
 			"status":         enrollment.Status,
 			"enrollmentDate": enrollment.EnrollmentDate,
 			"createdAt":      enrollment.CreatedAt,
@@ -159,6 +208,16 @@ func GetHubEnrollments(c *gin.Context) {
 		return
 	}
 
+	allowed, err := canManageCenterID(c, gdb, hubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only view enrollments for hubs you manage"})
+		return
+	}
+
 	// Optional status filter
 	status := c.Query("status")
 
@@ -180,6 +239,7 @@ func GetHubEnrollments(c *gin.Context) {
 			"id":             e.ID,
 			"hubId":          e.HubID,
 			"entrepreneurId": e.EntrepreneurID,
+			"cohortId":       e.CohortID,
 			"status":         e.Status,
 			"enrollmentDate": e.EnrollmentDate,
 			"completionDate": e.CompletionDate,
@@ -247,6 +307,16 @@ func GetEntrepreneurEnrollments(c *gin.Context) {
 		query = query.Where("status = ?", status)
 	}
 
+	// Managers only see enrollments in their own hubs
+	if role == string(db.RoleCenterManager) {
+		hubIDs, err := callerManagedCenterIDs(c, gdb)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch enrollments"})
+			return
+		}
+		query = query.Where("hub_id IN ?", append(hubIDs, uuid.Nil))
+	}
+
 	var enrollments []db.HubEnrollment
 	if err := query.Order("created_at DESC").Find(&enrollments).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch enrollments"})
@@ -260,6 +330,7 @@ func GetEntrepreneurEnrollments(c *gin.Context) {
 			"id":             e.ID,
 			"hubId":          e.HubID,
 			"entrepreneurId": e.EntrepreneurID,
+			"cohortId":       e.CohortID,
 			"status":         e.Status,
 			"enrollmentDate": e.EnrollmentDate,
 			"completionDate": e.CompletionDate,
@@ -302,7 +373,8 @@ func UpdateEnrollmentStatus(c *gin.Context) {
 	}
 
 	var req struct {
-		Status string `json:"status" binding:"required"`
+		Status string  `json:"status" binding:"required"`
+		Reason *string `json:"reason"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
@@ -311,8 +383,7 @@ func UpdateEnrollmentStatus(c *gin.Context) {
 
 	// Validate status
 	newStatus := db.EnrollmentStatus(req.Status)
-	if newStatus != db.EnrollmentPending && newStatus != db.EnrollmentActive &&
-	   newStatus != db.EnrollmentCompleted && newStatus != db.EnrollmentSuspended {
+	if !lifecycle.Enrollments.Valid(newStatus) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status value"})
 		return
 	}
@@ -323,24 +394,30 @@ func UpdateEnrollmentStatus(c *gin.Context) {
 		return
 	}
 
-	// Update status and related dates
-	enrollment.Status = newStatus
-
-	// Handle status-specific updates
-	switch newStatus {
-	case db.EnrollmentActive:
-		if enrollment.EnrollmentDate == nil {
-			now := time.Now()
-			enrollment.EnrollmentDate = &now
-		}
-	case db.EnrollmentCompleted:
-		if enrollment.CompletionDate == nil {
-			now := time.Now()
-			enrollment.CompletionDate = &now
-		}
+	allowed, err := canManageCenterID(c, gdb, enrollment.HubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only manage enrollments for hubs you manage"})
+		return
 	}
 
-	if err := gdb.Save(&enrollment).Error; err != nil {
+	var actor *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		actor = &uid
+	}
+	previous := enrollment.Status
+	if err := lifecycle.TransitionEnrollment(gdb, &enrollment, newStatus, actor, req.Reason); err != nil {
+		if errors.Is(err, lifecycle.ErrIllegalTransition) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   err.Error(),
+				"status":  previous,
+				"allowed": lifecycle.Enrollments.Allowed(previous),
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update enrollment status"})
 		return
 	}
@@ -364,6 +441,7 @@ func UpdateEnrollmentStatus(c *gin.Context) {
 			"id":             enrollment.ID,
 			"hubId":          enrollment.HubID,
 			"entrepreneurId": enrollment.EntrepreneurID,
+			"cohortId":       enrollment.CohortID,
 			"status":         enrollment.Status,
 			"enrollmentDate": enrollment.EnrollmentDate,
 			"completionDate": enrollment.CompletionDate,
@@ -405,6 +483,9 @@ func GetEnrollment(c *gin.Context) {
 	} else if role != string(db.RoleAdmin) && role != string(db.RoleCenterManager) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
 		return
+	} else if !canManageCenter(c, &enrollment.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to view this enrollment"})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -412,6 +493,7 @@ func GetEnrollment(c *gin.Context) {
 			"id":             enrollment.ID,
 			"hubId":          enrollment.HubID,
 			"entrepreneurId": enrollment.EntrepreneurID,
+			"cohortId":       enrollment.CohortID,
 			"status":         enrollment.Status,
 			"enrollmentDate": enrollment.EnrollmentDate,
 			"completionDate": enrollment.CompletionDate,
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestEnrollmentStatusConflict(t *testing.T) {
+	f := newHubFixture(t)
+
+	// The enrollment is declined between our read and write
+	f.fake.race("hub_enrollments", f.enrollment, map[string]interface{}{"status": "SUSPENDED"})
+	w := f.do(t, f.managerA, "PATCH", "/api/enrollments/"+f.enrollment+"/status", gin.H{"status": "ACTIVE"})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected a stale status change to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := f.fake.row("hub_enrollments", f.enrollment)["status"]; got != "SUSPENDED" {
+		t.Fatalf("expected the other change to stand, got %v", got)
+	}
+	if n := f.fake.writeCount("status_transitions"); n != 0 {
+		t.Fatalf("expected no history for a refused change, got %d writes", n)
+	}
+}
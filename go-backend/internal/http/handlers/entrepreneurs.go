@@ -8,15 +8,26 @@ import (
 
 	"communitycentresplatform/go-backend/internal/ctxutil"
 	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/directory"
 )
 
 type entrepreneurRequest struct {
-	BusinessName string  `json:"businessName" binding:"required,min=2"`
-	BusinessType string  `json:"businessType" binding:"required,min=2"`
-	Description  string  `json:"description" binding:"required,min=10"`
-	Phone        *string `json:"phone"`
-	Email        *string `json:"email"`
-	Website      *string `json:"website"`
+	BusinessName string          `json:"businessName" binding:"required,min=2"`
+	BusinessType string          `json:"businessType" binding:"required,min=2"`
+	Description  string          `json:"description" binding:"required,min=10"`
+	Phone        *string         `json:"phone"`
+	Email        *string         `json:"email"`
+	Website      *string         `json:"website"`
+	Privacy      *privacyRequest `json:"privacy"` // Omit to keep the current settings
+}
+
+// privacyRequest sets who may see the profile and each contact detail:
+// PUBLIC, NETWORK or PRIVATE
+type privacyRequest struct {
+	Profile *string `json:"profile"`
+	Phone   *string `json:"phone"`
+	Email   *string `json:"email"`
+	Website *string `json:"website"`
 }
 
 // POST /api/entrepreneurs - Create entrepreneur profile (ENTREPRENEUR role required)
@@ -60,6 +71,11 @@ func CreateEntrepreneur(c *gin.Context) {
 		Website:      req.Website,
 		Verified:     false,
 	}
+	directory.Defaults.Apply(&entrepreneur)
+	if msg := applyPrivacyRequest(&entrepreneur, req.Privacy); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
 
 	if err := gdb.Create(&entrepreneur).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create entrepreneur profile"})
@@ -81,12 +97,13 @@ func CreateEntrepreneur(c *gin.Context) {
 			"email":        entrepreneur.Email,
 			"website":      entrepreneur.Website,
 			"verified":     entrepreneur.Verified,
+			"privacy":      transformPrivacySettings(&entrepreneur),
 			"createdAt":    entrepreneur.CreatedAt,
 		},
 	})
 }
 
-// GET /api/entrepreneurs/:id - Get entrepreneur details (authenticated users)
+// GET /api/entrepreneurs/:id - Get entrepreneur details (authenticated users; contact details per privacy settings)
 func GetEntrepreneur(c *gin.Context) {
 	gdb := ctxutil.DBFrom(c)
 	if gdb == nil {
@@ -107,6 +124,18 @@ func GetEntrepreneur(c *gin.Context) {
 		return
 	}
 
+	// Reveal the profile and contact details according to the entrepreneur's
+	// privacy settings; hidden profiles look the same as missing ones
+	audience, err := entrepreneurAudience(c, gdb, &entrepreneur)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	if !directory.Listed(&entrepreneur, audience) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entrepreneur not found"})
+		return
+	}
+
 	// Get enrollment count
 	var enrollmentCount int64
 	gdb.Model(&db.HubEnrollment{}).Where("entrepreneur_id = ?", entrepreneurID).Count(&enrollmentCount)
@@ -115,27 +144,41 @@ func GetEntrepreneur(c *gin.Context) {
 	var servicesCount int64
 	gdb.Model(&db.ServiceProvision{}).Where("entrepreneur_id = ?", entrepreneurID).Count(&servicesCount)
 
-	c.JSON(http.StatusOK, gin.H{
-		"entrepreneur": gin.H{
-			"id":              entrepreneur.ID,
-			"userId":          entrepreneur.UserID,
-			"businessName":    entrepreneur.BusinessName,
-			"businessType":    entrepreneur.BusinessType,
-			"description":     entrepreneur.Description,
-			"phone":           entrepreneur.Phone,
-			"email":           entrepreneur.Email,
-			"website":         entrepreneur.Website,
-			"verified":        entrepreneur.Verified,
-			"enrollmentCount": enrollmentCount,
-			"servicesCount":   servicesCount,
-			"createdAt":       entrepreneur.CreatedAt,
-			"user": gin.H{
-				"id":    entrepreneur.User.ID,
-				"name":  entrepreneur.User.Name,
-				"email": entrepreneur.User.Email,
-			},
-		},
-	})
+	contact := directory.ContactFor(&entrepreneur, audience)
+	user := gin.H{
+		"id":   entrepreneur.User.ID,
+		"name": entrepreneur.User.Name,
+	}
+	if directory.CanSee(directory.SettingsOf(&entrepreneur).Email, audience) {
+		user["email"] = entrepreneur.User.Email
+	}
+
+	response := gin.H{
+		"id":              entrepreneur.ID,
+		"userId":          entrepreneur.UserID,
+		"businessName":    entrepreneur.BusinessName,
+		"businessType":    entrepreneur.BusinessType,
+		"description":     entrepreneur.Description,
+		"phone":           contact.Phone,
+		"email":           contact.Email,
+		"website":         contact.Website,
+		"verified":        entrepreneur.Verified,
+		"enrollmentCount": enrollmentCount,
+		"servicesCount":   servicesCount,
+		"createdAt":       entrepreneur.CreatedAt,
+		"user":            user,
+	}
+	if audience == directory.AudienceOwner {
+		response["privacy"] = transformPrivacySettings(&entrepreneur)
+	}
+	portfolioItems, err := visiblePortfolio(gdb, &entrepreneur, audience)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch portfolio"})
+		return
+	}
+	response["portfolio"] = portfolioItems
+
+	c.JSON(http.StatusOK, gin.H{"entrepreneur": response})
 }
 
 // PUT /api/entrepreneurs/:id - Update entrepreneur profile (owner or ADMIN)
@@ -182,6 +225,10 @@ func UpdateEntrepreneur(c *gin.Context) {
 	entrepreneur.Phone = req.Phone
 	entrepreneur.Email = req.Email
 	entrepreneur.Website = req.Website
+	if msg := applyPrivacyRequest(&entrepreneur, req.Privacy); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
 
 	if err := gdb.Save(&entrepreneur).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update entrepreneur profile"})
@@ -200,6 +247,7 @@ func UpdateEntrepreneur(c *gin.Context) {
 			"email":        entrepreneur.Email,
 			"website":      entrepreneur.Website,
 			"verified":     entrepreneur.Verified,
+			"privacy":      transformPrivacySettings(&entrepreneur),
 			"updatedAt":    entrepreneur.UpdatedAt,
 		},
 	})
@@ -363,3 +411,32 @@ func VerifyEntrepreneur(c *gin.Context) {
 		"entrepreneur": entrepreneur,
 	})
 }
+
+// applyPrivacyRequest copies any privacy settings in the request onto the
+// entrepreneur, returning a message when one is invalid
+func applyPrivacyRequest(e *db.Entrepreneur, req *privacyRequest) string {
+	if req == nil {
+		return ""
+	}
+	settings := directory.SettingsOf(e)
+	for _, field := range []struct {
+		value *string
+		dst   *db.Visibility
+	}{
+		{req.Profile, &settings.Profile},
+		{req.Phone, &settings.Phone},
+		{req.Email, &settings.Email},
+		{req.Website, &settings.Website},
+	} {
+		if field.value == nil {
+			continue
+		}
+		v, err := directory.ParseVisibility(*field.value)
+		if err != nil {
+			return err.Error()
+		}
+		*field.dst = v
+	}
+	settings.Apply(e)
+	return ""
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestEntrepreneurContactPrivacy(t *testing.T) {
+	f := newHubFixture(t)
+
+	contact := func(w *httptest.ResponseRecorder) (phone *string, privacy bool) {
+		var body struct {
+			Entrepreneur struct {
+				Phone   *string                `json:"phone"`
+				Privacy map[string]interface{} `json:"privacy"`
+			} `json:"entrepreneur"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return body.Entrepreneur.Phone, body.Entrepreneur.Privacy != nil
+	}
+
+	// Phones default to private: visible to the owner and the hubs they applied to
+	f.fake.row("entrepreneurs", f.entrepreneur)["verified"] = true
+	path := "/api/entrepreneurs/" + f.entrepreneur
+	for _, tc := range []struct {
+		name      string
+		user      string
+		wantPhone bool
+	}{
+		{"owner", f.founder, true},
+		{"enrolling hub manager", f.managerA, true},
+		{"other hub manager", f.managerB, false},
+		{"other entrepreneur", f.otherFounder, false},
+	} {
+		w := f.do(t, tc.user, "GET", path, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", tc.name, w.Code, w.Body.String())
+		}
+		phone, privacy := contact(w)
+		if (phone != nil) != tc.wantPhone {
+			t.Errorf("%s: phone visible = %v, want %v", tc.name, phone != nil, tc.wantPhone)
+		}
+		if privacy != (tc.user == f.founder) {
+			t.Errorf("%s: privacy settings visible = %v", tc.name, privacy)
+		}
+	}
+
+	// Unverified profiles stay out of the directory except for their hubs
+	f.fake.row("entrepreneurs", f.entrepreneur)["verified"] = false
+	dir := "/api/directory/entrepreneurs/" + f.entrepreneur
+	if w := f.do(t, "", "GET", dir, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected anonymous visitors not to find an unverified profile, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerA, "GET", dir, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected the enrolling hub to see the profile, got %d: %s", w.Code, w.Body.String())
+	}
+	f.check(t, []accessCase{
+		{"owner sees unverified profile", f.founder, "GET", path, nil, http.StatusOK},
+		{"enrolling hub sees unverified profile", f.managerA, "GET", path, nil, http.StatusOK},
+		{"other hub misses unverified profile", f.managerB, "GET", path, nil, http.StatusNotFound},
+		{"other entrepreneur misses unverified profile", f.otherFounder, "GET", path, nil, http.StatusNotFound},
+	})
+}
@@ -8,7 +8,10 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
     "communitycentresplatform/go-backend/internal/auth"
+    "communitycentresplatform/go-backend/internal/db"
     "communitycentresplatform/go-backend/internal/events"
     "communitycentresplatform/go-backend/internal/ctxutil"
 )
@@ -41,6 +44,9 @@ func GetEvents(c *gin.Context) {
         c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "invalid or expired token"})
         return
     }
+    // the permission helpers read the caller from the context, as after AuthMiddleware
+    c.Set(ctxutil.KeyUserID, claims.UserID)
+    c.Set(ctxutil.KeyRole, claims.Role)
 
     // initial hello
     fmt.Fprintf(c.Writer, "data: %s\n\n", "connected")
@@ -58,8 +64,9 @@ func GetEvents(c *gin.Context) {
     // backlog replay via Last-Event-ID
     if last := c.Request.Header.Get("Last-Event-ID"); last != "" {
         if id, err := strconv.ParseInt(last, 10, 64); err == nil {
+            mayReplay := replayFilter(c, ctxutil.DBFrom(c))
             for _, ev := range br.GetSince(id) {
-                c.Writer.Write(events.ToSSE(ev))
+                if mayReplay(ev) { c.Writer.Write(events.ToSSE(ev)) }
             }
             flusher.Flush()
         }
@@ -82,4 +89,51 @@ func GetEvents(c *gin.Context) {
 	})
 }
 
+// replayFilter decides which backlog events a reconnecting client is sent:
+// those it could have followed live, judged as JoinThread and JoinCenter do.
+// Lookups are cached since a backlog repeats the same threads and centers.
+func replayFilter(c *gin.Context, gdb *gorm.DB) func(events.Event) bool {
+	threads, centers := map[string]bool{}, map[string]bool{}
+	canFollowThread := func(id string) bool {
+		if ok, seen := threads[id]; seen {
+			return ok
+		}
+		ok := false
+		if threadID, err := uuid.Parse(id); err == nil {
+			if thread, err := db.FindThreadByID(gdb, threadID); err == nil && thread != nil {
+				ok = canAccessThread(c, thread)
+			}
+		}
+		threads[id] = ok
+		return ok
+	}
+	canFollowCenter := func(id string) bool {
+		if ok, seen := centers[id]; seen {
+			return ok
+		}
+		ok := false
+		if centerID, err := uuid.Parse(id); err == nil {
+			ok, _ = canManageCenterID(c, gdb, centerID)
+		}
+		centers[id] = ok
+		return ok
+	}
 
+	return func(ev events.Event) bool {
+		if gdb == nil {
+			return false
+		}
+		if ev.To.UserID != "" && ev.To.UserID == ctxutil.UserIDFrom(c) {
+			return true
+		}
+		if ev.To.ThreadID != "" && canFollowThread(ev.To.ThreadID) {
+			return true
+		}
+		for _, id := range ev.To.CenterIDs {
+			if canFollowCenter(id) {
+				return true
+			}
+		}
+		return false
+	}
+}
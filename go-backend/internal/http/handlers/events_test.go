@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"communitycentresplatform/go-backend/internal/auth"
+)
+
+const testJWTSecret = "test-secret"
+
+// streamRecorder lets gin stream into a recorder, which cannot notice a
+// closed connection
+type streamRecorder struct{ *httptest.ResponseRecorder }
+
+func (streamRecorder) CloseNotify() <-chan bool { return make(chan bool) }
+
+func TestEventReplayFollowsAccess(t *testing.T) {
+	f, thread, _ := newThreadFixture(t, time.Now())
+	f.broker.EmitNewMessage(thread, map[string]string{"content": "Budget attached"})
+	f.broker.EmitBookingUpdate(f.hubA, f.founder, map[string]string{"status": "CONFIRMED"})
+	f.broker.EmitCenterUpdate(f.hubC, map[string]string{"id": f.hubC})
+
+	// replay reconnects as the user from the start of the backlog and stops
+	// once the backlog is written
+	replay := func(user string) string {
+		token, err := auth.SignJWT(testJWTSecret, time.Hour, user, "", f.roleOf(user), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req := httptest.NewRequest("GET", "/api/events/?token="+token, nil).WithContext(ctx)
+		req.Header.Set("Last-Event-ID", "0")
+		w := streamRecorder{httptest.NewRecorder()}
+		f.gdb.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	for _, tc := range []struct {
+		name string
+		user string
+		want []string
+	}{
+		{"thread participant", f.managerB, []string{"new-message"}},
+		{"booking hub manager", f.managerA, []string{"new-message", "booking-updated"}},
+		{"booker", f.founder, []string{"booking-updated"}},
+		{"outsider hub manager", f.managerC, []string{"center-updated"}},
+		{"admin", f.admin, []string{"new-message", "booking-updated", "center-updated"}},
+	} {
+		body := replay(tc.user)
+		for _, event := range []string{"new-message", "booking-updated", "center-updated"} {
+			want := false
+			for _, w := range tc.want {
+				want = want || w == event
+			}
+			if got := strings.Contains(body, "event: "+event+"\n"); got != want {
+				t.Errorf("%s: replayed %s = %v, want %v", tc.name, event, got, want)
+			}
+		}
+	}
+}
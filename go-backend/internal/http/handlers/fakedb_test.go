@@ -0,0 +1,806 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/events"
+	"communitycentresplatform/go-backend/internal/storage"
+)
+
+// fakeDB is a minimal in-memory stand-in for Postgres, good enough to drive
+// handlers through gorm in tests. SELECTs are answered from seeded rows using
+// a small WHERE evaluator (=, <>, IN, IS NULL, AND/OR). Writes are recorded
+// for assertions and applied as far as they are understood: INSERTs add rows,
+// UPDATEs set plain values and DELETEs remove rows, each reporting the rows
+// its WHERE matched so guarded updates can be exercised.
+type fakeDB struct {
+	mu     sync.Mutex
+	tables map[string][]map[string]interface{}
+	writes []string
+	races  []fakeRace
+}
+
+// fakeRace is a change another request makes to a row just before the next
+// write to its table
+type fakeRace struct {
+	table, id string
+	changes   map[string]interface{}
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{tables: map[string][]map[string]interface{}{}}
+}
+
+// insert seeds a row; every row of a table should set the same columns
+func (f *fakeDB) insert(table string, row map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.tables[table] = append(f.tables[table], row)
+}
+
+// race changes the row with the given id just before the next write to its
+// table, as if a concurrent request had got there first
+func (f *fakeDB) race(table, id string, changes map[string]interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.races = append(f.races, fakeRace{table: table, id: id, changes: changes})
+}
+
+// row returns the row of table with the given id, or nil
+func (f *fakeDB) row(table, id string) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, row := range f.tables[table] {
+		if fmt.Sprint(row["id"]) == id {
+			return row
+		}
+	}
+	return nil
+}
+
+// writeCount returns how many INSERT/UPDATE/DELETE statements touched table
+func (f *fakeDB) writeCount(table string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, w := range f.writes {
+		if strings.Contains(w, `"`+table+`"`) {
+			n++
+		}
+	}
+	return n
+}
+
+// gorm opens a gorm handle backed by the fake
+func (f *fakeDB) gorm(t *testing.T) *gorm.DB {
+	t.Helper()
+	sqlDB := sql.OpenDB(fakeConnector{f})
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:                 logger.Discard,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("open fake db: %v", err)
+	}
+	return gdb
+}
+
+type fakeConnector struct{ f *fakeDB }
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{c.f}, nil }
+func (c fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, fmt.Errorf("use the connector") }
+
+type fakeConn struct{ f *fakeDB }
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	if !isWrite(query) {
+		return driver.RowsAffected(1), nil
+	}
+	c.f.writes = append(c.f.writes, query)
+	return driver.RowsAffected(c.f.apply(query, args)), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.f.mu.Lock()
+	defer c.f.mu.Unlock()
+	if isWrite(query) {
+		// INSERT ... RETURNING and friends
+		c.f.writes = append(c.f.writes, query)
+		c.f.apply(query, args)
+		return &fakeRows{}, nil
+	}
+	return c.f.selectRows(query, args)
+}
+
+func isWrite(query string) bool {
+	q := strings.ToUpper(strings.TrimSpace(query))
+	return strings.HasPrefix(q, "INSERT") || strings.HasPrefix(q, "UPDATE") || strings.HasPrefix(q, "DELETE")
+}
+
+var (
+	fromRe  = regexp.MustCompile(`(?i)\bFROM\s+"?(\w+)"?`)
+	limitRe = regexp.MustCompile(`(?i)\bLIMIT\s+(\$\d+|\d+)`)
+	tailRe  = regexp.MustCompile(`(?i)\s+(ORDER BY|LIMIT|GROUP BY|OFFSET|FOR UPDATE)\b`)
+	atomRe  = regexp.MustCompile(`(?i)^(?:"?\w+"?\.)?"?(\w+)"?\s*(=|<>|IN|IS NOT NULL|IS NULL)\s*(.*)$`)
+	paramRe = regexp.MustCompile(`\$(\d+)`)
+
+	insertRe = regexp.MustCompile(`(?is)^INSERT INTO "?(\w+)"? \(([^)]*)\) VALUES (.*?)(?: ON CONFLICT(?: \(([^)]*)\))? DO (NOTHING|UPDATE SET (.*?)))?(?: RETURNING .*)?$`)
+	updateRe = regexp.MustCompile(`(?is)^UPDATE "?(\w+)"? SET (.*?) WHERE (.*)$`)
+	deleteRe = regexp.MustCompile(`(?is)^DELETE FROM "?(\w+)"?(?: WHERE (.*))?$`)
+	setRe    = regexp.MustCompile(`(?is)^"?(\w+)"?\s*=\s*(.*)$`)
+)
+
+// apply carries out a write on the seeded rows and returns how many rows it
+// affected. Statements it cannot parse change nothing and report one row.
+func (f *fakeDB) apply(query string, args []driver.NamedValue) int64 {
+	query = strings.TrimSpace(query)
+	if m := insertRe.FindStringSubmatch(query); m != nil {
+		f.runRaces(m[1])
+		return f.applyInsert(m[1], splitColumns(m[2]), m[3], m[4], m[5], m[6], args)
+	}
+	if m := updateRe.FindStringSubmatch(query); m != nil {
+		f.runRaces(m[1])
+		var n int64
+		for _, row := range f.tables[m[1]] {
+			if evalWhere(m[3], row, args) {
+				assign(row, row, m[2], args)
+				n++
+			}
+		}
+		return n
+	}
+	if m := deleteRe.FindStringSubmatch(query); m != nil {
+		f.runRaces(m[1])
+		var kept []map[string]interface{}
+		for _, row := range f.tables[m[1]] {
+			if !evalWhere(m[2], row, args) {
+				kept = append(kept, row)
+			}
+		}
+		n := int64(len(f.tables[m[1]]) - len(kept))
+		f.tables[m[1]] = kept
+		return n
+	}
+	return 1
+}
+
+// runRaces applies the pending races on table
+func (f *fakeDB) runRaces(table string) {
+	var pending []fakeRace
+	for _, r := range f.races {
+		if r.table != table {
+			pending = append(pending, r)
+			continue
+		}
+		for _, row := range f.tables[table] {
+			if fmt.Sprint(row["id"]) == r.id {
+				for col, v := range r.changes {
+					row[col] = v
+				}
+			}
+		}
+	}
+	f.races = pending
+}
+
+// applyInsert adds each VALUES row, honouring ON CONFLICT against the target
+// columns, the primary key, or else every inserted column
+func (f *fakeDB) applyInsert(table string, columns []string, values, target, action, set string, args []driver.NamedValue) int64 {
+	keys := splitColumns(target)
+	if target == "" {
+		keys = columns
+		if slices.Contains(columns, "id") {
+			keys = []string{"id"}
+		}
+	}
+	var n int64
+	for _, group := range splitTopLevel(values, ",") {
+		items := strings.Split(trimParens(group), ",")
+		row := map[string]interface{}{}
+		for i, col := range columns {
+			if i < len(items) {
+				row[col] = literalValue(items[i], args)
+			}
+		}
+		var existing map[string]interface{}
+		if action != "" {
+			for _, other := range f.tables[table] {
+				same := true
+				for _, k := range keys {
+					same = same && fmt.Sprint(other[k]) == fmt.Sprint(row[k])
+				}
+				if same {
+					existing = other
+				}
+			}
+		}
+		switch {
+		case existing == nil:
+			f.tables[table] = append(f.tables[table], row)
+		case strings.EqualFold(action, "NOTHING"):
+			continue
+		default:
+			assign(existing, row, set, args)
+		}
+		n++
+	}
+	return n
+}
+
+// assign applies SET assignments to row; only parameters, literals and
+// excluded.column are understood, anything else is left unchanged
+func assign(row, excluded map[string]interface{}, set string, args []driver.NamedValue) {
+	for _, a := range splitTopLevel(set, ",") {
+		m := setRe.FindStringSubmatch(strings.TrimSpace(a))
+		if m == nil {
+			continue
+		}
+		expr := strings.TrimSpace(m[2])
+		if e := strings.ToLower(strings.ReplaceAll(expr, `"`, "")); strings.HasPrefix(e, "excluded.") {
+			row[m[1]] = excluded[strings.TrimPrefix(e, "excluded.")]
+		} else if paramRe.FindString(expr) == expr || !strings.ContainsAny(expr, "$( ") {
+			row[m[1]] = literalValue(expr, args)
+		}
+	}
+}
+
+// literalValue resolves a VALUES or SET item to the value it stores
+func literalValue(s string, args []driver.NamedValue) interface{} {
+	s = strings.TrimSpace(s)
+	if m := paramRe.FindStringSubmatch(s); m != nil && m[0] == s {
+		n, _ := strconv.Atoi(m[1])
+		if n >= 1 && n <= len(args) {
+			return args[n-1].Value
+		}
+		return nil
+	}
+	if strings.EqualFold(s, "NULL") || strings.EqualFold(s, "DEFAULT") {
+		return nil
+	}
+	return strings.Trim(s, "'")
+}
+
+func splitColumns(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var cols []string
+	for _, col := range strings.Split(s, ",") {
+		cols = append(cols, strings.Trim(strings.TrimSpace(col), `"`))
+	}
+	return cols
+}
+
+func (f *fakeDB) selectRows(query string, args []driver.NamedValue) (driver.Rows, error) {
+	m := fromRe.FindStringSubmatch(query)
+	if m == nil {
+		return &fakeRows{}, nil
+	}
+	table := m[1]
+
+	var where string
+	if i := strings.Index(strings.ToUpper(query), " WHERE "); i >= 0 {
+		where = query[i+len(" WHERE "):]
+		if loc := tailRe.FindStringIndex(where); loc != nil {
+			where = where[:loc[0]]
+		}
+	}
+
+	var matched []map[string]interface{}
+	for _, row := range f.tables[table] {
+		if evalWhere(where, row, args) {
+			matched = append(matched, row)
+		}
+	}
+	if lm := limitRe.FindStringSubmatch(query); lm != nil {
+		if n, err := strconv.Atoi(resolveLiteral(lm[1], args)); err == nil && n < len(matched) {
+			matched = matched[:n]
+		}
+	}
+
+	selectList := query[len("SELECT "):fromRe.FindStringIndex(query)[0]]
+	if strings.Contains(strings.ToLower(selectList), "count(") {
+		return &fakeRows{columns: []string{"count"}, values: [][]driver.Value{{int64(len(matched))}}}, nil
+	}
+
+	columns := f.columnsOf(table)
+	if s := strings.TrimSpace(selectList); s != "*" && !strings.HasSuffix(s, ".*") {
+		columns = nil
+		for _, col := range strings.Split(s, ",") {
+			col = strings.TrimSpace(col)
+			if i := strings.LastIndex(col, "."); i >= 0 {
+				col = col[i+1:]
+			}
+			columns = append(columns, strings.Trim(col, `"`))
+		}
+	}
+
+	rows := &fakeRows{columns: columns}
+	for _, row := range matched {
+		values := make([]driver.Value, len(columns))
+		for i, col := range columns {
+			values[i] = row[col]
+		}
+		rows.values = append(rows.values, values)
+	}
+	return rows, nil
+}
+
+func (f *fakeDB) columnsOf(table string) []string {
+	seen := map[string]bool{}
+	for _, row := range f.tables[table] {
+		for col := range row {
+			seen[col] = true
+		}
+	}
+	cols := make([]string, 0, len(seen))
+	for col := range seen {
+		cols = append(cols, col)
+	}
+	sort.Strings(cols)
+	return cols
+}
+
+// evalWhere evaluates a conjunction of (possibly parenthesised) disjunctions,
+// recursing into parenthesised conjunctions such as GORM's grouped Where
+// clauses. Atoms it does not understand are treated as true.
+func evalWhere(where string, row map[string]interface{}, args []driver.NamedValue) bool {
+	for _, conj := range splitTopLevel(where, " AND ") {
+		conj = trimParens(conj)
+		ok := false
+		for _, atom := range splitTopLevel(conj, " OR ") {
+			atom = trimParens(atom)
+			if len(splitTopLevel(atom, " AND ")) > 1 {
+				ok = evalWhere(atom, row, args)
+			} else {
+				ok = evalAtom(atom, row, args)
+			}
+			if ok {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func evalAtom(atom string, row map[string]interface{}, args []driver.NamedValue) bool {
+	m := atomRe.FindStringSubmatch(strings.TrimSpace(atom))
+	if m == nil {
+		return true
+	}
+	value, known := row[m[1]]
+	if !known {
+		return true
+	}
+	actual := fmt.Sprint(value)
+	switch strings.ToUpper(m[2]) {
+	case "IS NULL":
+		return value == nil
+	case "IS NOT NULL":
+		return value != nil
+	case "=":
+		return value != nil && actual == resolveLiteral(m[3], args)
+	case "<>":
+		return value == nil || actual != resolveLiteral(m[3], args)
+	case "IN":
+		list := strings.Trim(strings.TrimSpace(m[3]), "()")
+		for _, item := range strings.Split(list, ",") {
+			if value != nil && actual == resolveLiteral(item, args) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func resolveLiteral(s string, args []driver.NamedValue) string {
+	s = strings.TrimSpace(s)
+	if m := paramRe.FindStringSubmatch(s); m != nil && m[0] == s {
+		n, _ := strconv.Atoi(m[1])
+		if n >= 1 && n <= len(args) {
+			return fmt.Sprint(args[n-1].Value)
+		}
+	}
+	return strings.Trim(s, "'")
+}
+
+func splitTopLevel(s, sep string) []string {
+	var parts []string
+	depth, start := 0, 0
+	upper := strings.ToUpper(s)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		if depth == 0 && strings.HasPrefix(upper[i:], sep) {
+			parts = append(parts, s[start:i])
+			start = i + len(sep)
+			i += len(sep) - 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func trimParens(s string) string {
+	s = strings.TrimSpace(s)
+	for len(s) >= 2 && s[0] == '(' && s[len(s)-1] == ')' && balanced(s[1:len(s)-1]) {
+		s = strings.TrimSpace(s[1 : len(s)-1])
+	}
+	return s
+}
+
+func balanced(s string) bool {
+	depth := 0
+	for _, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return false
+			}
+		}
+	}
+	return depth == 0
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
+
+// hubFixture seeds three hubs with their managers, two entrepreneurs and the
+// records around them, and serves the handlers under test with the caller
+// passed in X-Test-User. Shared by the handler test files.
+type hubFixture struct {
+	fake   *fakeDB
+	gdb    http.Handler
+	blob   storage.Blob
+	broker *events.Broker
+
+	admin, managerA, managerB, managerC, founder, otherFounder string
+	hubA, hubB, hubC                                           string
+	entrepreneur, otherEntrepreneur                            string
+	enrollment                                                 string
+	service, sharedService                                     string
+	investor, deal                                             string
+	mentorUser, mentor, mentorRequest, session                 string
+}
+
+func newHubFixture(t *testing.T) *hubFixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	f := &hubFixture{fake: newFakeDB()}
+	id := func() string { return uuid.NewString() }
+	f.admin, f.managerA, f.managerB, f.managerC, f.founder, f.otherFounder = id(), id(), id(), id(), id(), id()
+	f.hubA, f.hubB, f.hubC = id(), id(), id()
+	f.entrepreneur, f.otherEntrepreneur = id(), id()
+	f.enrollment, f.service, f.sharedService = id(), id(), id()
+	f.investor, f.deal = id(), id()
+	f.mentorUser, f.mentor, f.mentorRequest, f.session = id(), id(), id(), id()
+	now := time.Now()
+
+	for _, u := range []struct{ id, role string }{
+		{f.admin, "ADMIN"}, {f.managerA, "CENTER_MANAGER"}, {f.managerB, "CENTER_MANAGER"},
+		{f.managerC, "CENTER_MANAGER"}, {f.founder, "ENTREPRENEUR"}, {f.otherFounder, "ENTREPRENEUR"},
+		{f.mentorUser, "VISITOR"},
+	} {
+		f.fake.insert("users", map[string]interface{}{
+			"id": u.id, "email": u.id + "@example.com", "name": "User", "role": u.role,
+			"verified": true, "created_at": now, "updated_at": now,
+		})
+	}
+	for _, h := range []struct{ id, manager string }{{f.hubA, f.managerA}, {f.hubB, f.managerB}, {f.hubC, f.managerC}} {
+		f.fake.insert("community_centers", map[string]interface{}{
+			"id": h.id, "name": "Hub", "location": "Kampala", "latitude": 0.3, "longitude": 32.5,
+			"verified": true, "added_by": h.manager, "manager_id": h.manager, "created_at": now, "updated_at": now,
+		})
+	}
+	for _, e := range []struct{ id, user string }{{f.entrepreneur, f.founder}, {f.otherEntrepreneur, f.otherFounder}} {
+		f.fake.insert("entrepreneurs", map[string]interface{}{
+			"id": e.id, "user_id": e.user, "business_name": "Biz", "business_type": "Agri",
+			"phone": "+256 700 000000", "verified": false, "created_at": now, "updated_at": now,
+		})
+	}
+	f.fake.insert("hub_enrollments", map[string]interface{}{
+		"id": f.enrollment, "hub_id": f.hubA, "entrepreneur_id": f.entrepreneur, "status": "PENDING",
+		"cohort_id": nil, "created_at": now, "updated_at": now,
+	})
+	for _, s := range []struct {
+		id     string
+		collab interface{}
+	}{{f.service, nil}, {f.sharedService, f.hubB}} {
+		f.fake.insert("service_provisions", map[string]interface{}{
+			"id": s.id, "hub_id": f.hubA, "entrepreneur_id": f.entrepreneur, "service_type": "MENTORSHIP",
+			"description": "Monthly mentoring sessions", "collaborating_hub_id": s.collab, "status": "ACTIVE",
+			"created_at": now, "updated_at": now,
+		})
+	}
+
+	f.fake.insert("investors", map[string]interface{}{
+		"id": f.investor, "name": "Baobab Angels", "slug": "baobab-angels", "type": "ANGEL", "currency": "USD",
+		"active": true, "created_at": now, "updated_at": now,
+	})
+	f.fake.insert("investor_deals", map[string]interface{}{
+		"id": f.deal, "investor_id": f.investor, "entrepreneur_id": f.entrepreneur, "hub_id": f.hubA,
+		"stage": "INTRO", "amount": nil, "created_at": now, "updated_at": now,
+	})
+
+	// A mentor hosted by hub A, already mentoring the entrepreneur
+	f.fake.insert("mentors", map[string]interface{}{
+		"id": f.mentor, "user_id": f.mentorUser, "hub_id": f.hubA, "headline": "Agribusiness mentor",
+		"expertise": nil, "accepting_requests": true, "active": true, "created_at": now, "updated_at": now,
+	})
+	f.fake.insert("mentor_requests", map[string]interface{}{
+		"id": f.mentorRequest, "mentor_id": f.mentor, "entrepreneur_id": f.entrepreneur, "topic": "Pricing",
+		"status": "ACCEPTED", "created_at": now, "updated_at": now,
+	})
+	f.fake.insert("mentoring_sessions", map[string]interface{}{
+		"id": f.session, "request_id": f.mentorRequest, "mentor_id": f.mentor, "entrepreneur_id": f.entrepreneur,
+		"hub_id": f.hubA, "scheduled_at": now.Add(-time.Hour), "duration_minutes": 45, "status": "SCHEDULED",
+		"service_provision_id": nil, "completed_at": nil, "created_at": now, "updated_at": now,
+	})
+
+	gdb := f.fake.gorm(t)
+	blob, err := storage.NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	broker := events.NewBroker()
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(ctxutil.KeyDB, gdb)
+		c.Set(ctxutil.KeyStorage, blob)
+		c.Set(ctxutil.KeyBroker, broker)
+		c.Set(ctxutil.KeyJWTSecret, testJWTSecret)
+		c.Set(ctxutil.KeyUserID, c.GetHeader("X-Test-User"))
+		c.Set(ctxutil.KeyRole, c.GetHeader("X-Test-Role"))
+		c.Next()
+	})
+	r.GET("/api/entrepreneurs/:id", GetEntrepreneur)
+	r.GET("/api/directory/entrepreneurs/:id", GetDirectoryEntrepreneur)
+	r.GET("/api/portfolio/entrepreneur/:entrepreneurId", GetEntrepreneurPortfolio)
+	r.PUT("/api/portfolio/:id", UpdatePortfolioItem)
+	r.GET("/api/centers/:id", GetCenter)
+	r.POST("/api/centers/:id/images", UploadCenterImage)
+	r.GET("/api/centers/:id/images/:imageId/:size", GetCenterImage)
+	r.POST("/api/messages/thread-messages/:threadId", SendThreadMessage)
+	r.GET("/api/messages/thread-messages/:threadId", GetThreadMessages)
+	r.GET("/api/events/", GetEvents)
+	r.POST("/api/realtime/join-center", JoinCenter)
+	r.POST("/api/realtime/join-thread", JoinThread)
+	r.GET("/api/messages/thread-messages/:threadId/attachments/:uploadId", GetMessageAttachment)
+	r.POST("/api/messages/thread-messages/:threadId/read", MarkThreadRead)
+	r.PATCH("/api/messages/thread-messages/:threadId/messages/:messageId", EditThreadMessage)
+	r.DELETE("/api/messages/thread-messages/:threadId/messages/:messageId", DeleteThreadMessage)
+	r.GET("/api/messages/thread-messages/:threadId/messages/:messageId/revisions", GetMessageRevisions)
+	r.PATCH("/api/messages/thread-messages/:threadId/subject", RenameThread)
+	r.POST("/api/messages/thread-messages/:threadId/participants", AddThreadParticipants)
+	r.DELETE("/api/messages/thread-messages/:threadId/participants/:centerId", RemoveThreadParticipant)
+	r.PUT("/api/messages/thread-messages/:threadId/preferences", UpdateThreadPreferences)
+	r.POST("/api/uploads", CreateUpload)
+	r.GET("/api/uploads", GetUploads)
+	r.GET("/api/uploads/:id", GetUpload)
+	r.GET("/api/uploads/:id/content", GetUploadContent)
+	r.POST("/api/portfolio/:id/media", UploadPortfolioMedia)
+	r.PATCH("/api/applications/:id/advance", AdvanceApplication)
+	r.PATCH("/api/applications/:id/decision", DecideApplication)
+	r.PATCH("/api/bookings/:id/approve", ApproveBooking)
+	r.PATCH("/api/bookings/:id/cancel", CancelBooking)
+	r.PATCH("/api/metrics/:id/verify", VerifyMetricEntry)
+	r.POST("/api/enrollments", CreateEnrollment)
+	r.GET("/api/enrollments/hub/:hubId", GetHubEnrollments)
+	r.GET("/api/enrollments/entrepreneur/:entrepreneurId", GetEntrepreneurEnrollments)
+	r.GET("/api/enrollments/:id", GetEnrollment)
+	r.GET("/api/enrollments/:id/history", GetEnrollmentHistory)
+	r.PATCH("/api/enrollments/:id/status", UpdateEnrollmentStatus)
+	r.PATCH("/api/enrollments/:id/cohort", AssignEnrollmentCohort)
+	r.POST("/api/services", CreateServiceProvision)
+	r.GET("/api/services/hub/:hubId", GetHubServiceProvisions)
+	r.GET("/api/services/entrepreneur/:entrepreneurId", GetEntrepreneurServices)
+	r.GET("/api/services/:id", GetServiceProvision)
+	r.GET("/api/services/:id/history", GetServiceProvisionHistory)
+	r.PUT("/api/services/:id", UpdateServiceProvision)
+	r.GET("/api/reports/:kind", GetReport)
+	r.GET("/api/deals", GetDeals)
+	r.POST("/api/deals", CreateDeal)
+	r.GET("/api/deals/:id", GetDeal)
+	r.GET("/api/deals/:id/history", GetDealHistory)
+	r.PATCH("/api/deals/:id/stage", UpdateDealStage)
+	r.POST("/api/mentors", CreateMentor)
+	r.PUT("/api/mentors/:id", UpdateMentor)
+	r.GET("/api/mentor-requests/:id", GetMentorRequest)
+	r.PATCH("/api/mentor-requests/:id", RespondMentorRequest)
+	r.POST("/api/mentoring-sessions", CreateMentoringSession)
+	r.GET("/api/mentoring-sessions/:id", GetMentoringSession)
+	r.PATCH("/api/mentoring-sessions/:id/complete", CompleteMentoringSession)
+	f.gdb = r
+	f.blob = blob
+	f.broker = broker
+	return f
+}
+
+func (f *hubFixture) roleOf(user string) string {
+	switch user {
+	case f.admin:
+		return "ADMIN"
+	case f.founder, f.otherFounder:
+		return "ENTREPRENEUR"
+	case f.mentorUser:
+		return "VISITOR"
+	}
+	return "CENTER_MANAGER"
+}
+
+func (f *hubFixture) do(t *testing.T, user, method, path string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		_ = json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", user)
+	req.Header.Set("X-Test-Role", f.roleOf(user))
+	w := httptest.NewRecorder()
+	f.gdb.ServeHTTP(w, req)
+	return w
+}
+
+// accessCase is a request and the status it should get
+type accessCase struct {
+	name, user, method, path string
+	body                     interface{}
+	want                     int
+}
+
+// check runs the cases in order, so earlier ones may change what later see
+func (f *hubFixture) check(t *testing.T, cases []accessCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := f.do(t, tc.user, tc.method, tc.path, tc.body)
+			if w.Code != tc.want {
+				t.Fatalf("%s %s: expected %d, got %d: %s", tc.method, tc.path, tc.want, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// upload posts a multipart file, with any extra form fields
+func (f *hubFixture) upload(t *testing.T, user, path, name string, content []byte, fields map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		mw.WriteField(k, v)
+	}
+	part, _ := mw.CreateFormFile("file", name)
+	part.Write(content)
+	mw.Close()
+	req := httptest.NewRequest("POST", path, &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("X-Test-User", user)
+	req.Header.Set("X-Test-Role", f.roleOf(user))
+	w := httptest.NewRecorder()
+	f.gdb.ServeHTTP(w, req)
+	return w
+}
+
+// seedThread adds a thread between hubs A and B holding one message from A
+func (f *hubFixture) seedThread(at time.Time) (thread, message string) {
+	thread, message = uuid.NewString(), uuid.NewString()
+	f.fake.insert("message_threads", map[string]interface{}{
+		"id": thread, "subject": "MoU draft", "last_activity": at, "message_count": 1, "created_at": at, "updated_at": at,
+	})
+	for _, hub := range []string{f.hubA, f.hubB} {
+		f.fake.insert("message_thread_participants", map[string]interface{}{"message_thread_id": thread, "community_center_id": hub})
+	}
+	f.fake.insert("center_messages", map[string]interface{}{
+		"id": message, "thread_id": thread, "sender_id": f.hubA, "content": "Attached", "created_at": at,
+	})
+	return thread, message
+}
+
+// newThreadFixture is newHubFixture with a thread between hubs A and B
+func newThreadFixture(t *testing.T, at time.Time) (f *hubFixture, thread, message string) {
+	t.Helper()
+	f = newHubFixture(t)
+	thread, message = f.seedThread(at)
+	return f, thread, message
+}
+
+// seedUpload stores a document and records it as attached to an entity
+func (f *hubFixture) seedUpload(t *testing.T, entityType, entityID, key, contentType string, content []byte) string {
+	t.Helper()
+	if err := f.blob.Put(context.Background(), key, bytes.NewReader(content), int64(len(content)), contentType); err != nil {
+		t.Fatal(err)
+	}
+	id := uuid.NewString()
+	f.fake.insert("uploads", map[string]interface{}{
+		"id": id, "uploaded_by": f.managerA, "entity_type": entityType, "entity_id": entityID,
+		"storage_key": key, "thumbnail_key": nil, "file_name": path.Base(key),
+		"content_type": contentType, "kind": "DOCUMENT", "size_bytes": len(content), "created_at": time.Now(),
+	})
+	return id
+}
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewGray(image.Rect(0, 0, 640, 480))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,336 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/hours"
+)
+
+const (
+	defaultNextOpenings = 5
+	maxNextOpenings     = 50
+	openingsHorizonDays = 60
+)
+
+type openingHoursEntry struct {
+	Weekday  int    `json:"weekday" binding:"min=0,max=6"`
+	OpensAt  string `json:"opensAt" binding:"required"`
+	ClosesAt string `json:"closesAt" binding:"required"`
+}
+
+type openingHoursRequest struct {
+	TimeZone       string              `json:"timeZone"`
+	Capacity       *int                `json:"capacity" binding:"omitempty,min=0"`
+	WorkspaceSeats *int                `json:"workspaceSeats" binding:"omitempty,min=0"`
+	Weekly         []openingHoursEntry `json:"weekly" binding:"dive"`
+}
+
+type hoursExceptionRequest struct {
+	Date     string  `json:"date" binding:"required"`
+	Closed   bool    `json:"closed"`
+	OpensAt  *string `json:"opensAt"`
+	ClosesAt *string `json:"closesAt"`
+	Reason   string  `json:"reason"`
+}
+
+// GET /api/centers/:id/hours - Get weekly hours, upcoming exceptions and capacity
+func GetCenterHours(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+
+	weekly, err := db.ListOpeningHours(gdb, center.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch opening hours"})
+		return
+	}
+	now := time.Now()
+	exceptions, err := db.ListHoursExceptions(gdb, center.ID, now.AddDate(0, 0, -1))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch opening hours"})
+		return
+	}
+
+	openNow := false
+	if schedule, err := hours.FromModels(center.TimeZone, weekly, exceptions); err == nil {
+		openNow = schedule.IsOpen(now)
+	}
+
+	transformedWeekly := make([]gin.H, len(weekly))
+	for i, h := range weekly {
+		transformedWeekly[i] = gin.H{"weekday": h.Weekday, "opensAt": h.OpensAt, "closesAt": h.ClosesAt}
+	}
+	transformedExceptions := make([]gin.H, len(exceptions))
+	for i := range exceptions {
+		transformedExceptions[i] = transformHoursException(&exceptions[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"centerId":       center.ID,
+		"timeZone":       center.TimeZone,
+		"capacity":       center.Capacity,
+		"workspaceSeats": center.WorkspaceSeats,
+		"openNow":        openNow,
+		"weekly":         transformedWeekly,
+		"exceptions":     transformedExceptions,
+	})
+}
+
+// GET /api/centers/:id/hours/next - Get the next opening windows (?count=5)
+func GetCenterNextOpenings(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+
+	count := defaultNextOpenings
+	if v := c.Query("count"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid count"})
+			return
+		}
+		count = min(n, maxNextOpenings)
+	}
+
+	now := time.Now()
+	schedule, err := hours.Load(gdb, center, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load opening hours"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"centerId":     center.ID,
+		"timeZone":     center.TimeZone,
+		"openNow":      schedule.IsOpen(now),
+		"nextOpenings": schedule.NextOpenings(now, count, openingsHorizonDays),
+	})
+}
+
+// PUT /api/centers/:id/hours - Replace weekly hours, time zone and capacity
+func UpdateCenterHours(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req openingHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	timeZone := center.TimeZone
+	if req.TimeZone != "" {
+		timeZone = req.TimeZone
+	}
+	if _, err := hours.NewSchedule(timeZone); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Validate each interval and reject overlaps within a day
+	var byDay [7][]hours.Interval
+	rows := make([]db.OpeningHours, 0, len(req.Weekly))
+	for _, entry := range req.Weekly {
+		iv, err := hours.NewInterval(entry.OpensAt, entry.ClosesAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		byDay[entry.Weekday] = append(byDay[entry.Weekday], iv)
+		rows = append(rows, db.OpeningHours{Weekday: entry.Weekday, OpensAt: entry.OpensAt, ClosesAt: entry.ClosesAt})
+	}
+	for day, intervals := range byDay {
+		if hours.Overlaps(intervals) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "overlapping opening hours on " + time.Weekday(day).String()})
+			return
+		}
+	}
+
+	err := gdb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(center).Updates(map[string]interface{}{
+			"time_zone":       timeZone,
+			"capacity":        req.Capacity,
+			"workspace_seats": req.WorkspaceSeats,
+		}).Error; err != nil {
+			return err
+		}
+		return db.ReplaceOpeningHours(tx, center.ID, rows)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update opening hours"})
+		return
+	}
+
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitCenterUpdate(center.ID.String(), gin.H{
+			"id":     center.ID,
+			"name":   center.Name,
+			"action": "hours_updated",
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":        "Opening hours updated successfully",
+		"timeZone":       timeZone,
+		"capacity":       req.Capacity,
+		"workspaceSeats": req.WorkspaceSeats,
+		"weekly":         req.Weekly,
+	})
+}
+
+// POST /api/centers/:id/hours/exceptions - Set a closure or special hours for a date
+func SetCenterHoursException(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req hoursExceptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date must be YYYY-MM-DD"})
+		return
+	}
+
+	exception := db.HoursException{CenterID: center.ID, Date: date, Closed: true, Reason: req.Reason}
+	if !req.Closed {
+		if req.OpensAt == nil || req.ClosesAt == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "opensAt and closesAt are required unless closed"})
+			return
+		}
+		if _, err := hours.NewInterval(*req.OpensAt, *req.ClosesAt); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		exception.Closed = false
+		exception.OpensAt = req.OpensAt
+		exception.ClosesAt = req.ClosesAt
+	}
+
+	if err := db.SaveHoursException(gdb, &exception); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save exception"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Hours exception saved successfully",
+		"exception": transformHoursException(&exception),
+	})
+}
+
+// DELETE /api/centers/:id/hours/exceptions/:exceptionId - Remove a date exception
+func DeleteCenterHoursException(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	center := findCenterFromParam(c, gdb)
+	if center == nil {
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	exceptionID, err := uuid.Parse(c.Param("exceptionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid exception id"})
+		return
+	}
+
+	deleted, err := db.DeleteHoursException(gdb, center.ID, exceptionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete exception"})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{"error": "exception not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Hours exception deleted successfully"})
+}
+
+// findCenterFromParam loads the center named by the :id parameter, writing
+// the error response and returning nil when it cannot
+func findCenterFromParam(c *gin.Context, gdb *gorm.DB) *db.CommunityCenter {
+	centerID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid center id"})
+		return nil
+	}
+
+	center, err := db.FindCenterByID(gdb, centerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center"})
+		return nil
+	}
+	if center == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "center not found"})
+		return nil
+	}
+	return center
+}
+
+func transformHoursException(ex *db.HoursException) gin.H {
+	return gin.H{
+		"id":       ex.ID,
+		"date":     ex.Date.Format("2006-01-02"),
+		"closed":   ex.Closed,
+		"opensAt":  ex.OpensAt,
+		"closesAt": ex.ClosesAt,
+		"reason":   ex.Reason,
+	}
+}
@@ -0,0 +1,632 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/ical"
+)
+
+type hubEventRequest struct {
+	HubID       string    `json:"hubId"`
+	Kind        string    `json:"kind" binding:"omitempty,oneof=WORKSHOP PITCH_DAY TRAINING MEETUP OTHER"`
+	Title       string    `json:"title" binding:"required,min=5,max=255"`
+	Description string    `json:"description"`
+	StartsAt    time.Time `json:"startsAt" binding:"required"`
+	EndsAt      time.Time `json:"endsAt" binding:"required"`
+	Location    string    `json:"location" binding:"max=255"`
+	OnlineURL   *string   `json:"onlineUrl"`
+	Capacity    *int      `json:"capacity" binding:"omitempty,min=1"`
+	Announce    bool      `json:"announce"` // Post an ANNOUNCEMENT activity to the hub feed
+}
+
+type checkInRequest struct {
+	UserID string `json:"userId" binding:"required"`
+}
+
+// GET /api/hub-events - Upcoming events across the network (?hubId&kind&from&to&page&limit)
+func ListHubEvents(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	filters, ok := hubEventFiltersFromQuery(c)
+	if !ok {
+		return
+	}
+	if hubID := c.Query("hubId"); hubID != "" {
+		id, err := uuid.Parse(hubID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+			return
+		}
+		filters.HubID = &id
+	}
+
+	respondWithHubEvents(c, gdb, filters)
+}
+
+// GET /api/hub-events/hub/:id - Upcoming events for a hub
+func GetHubEvents(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	hubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+
+	filters, ok := hubEventFiltersFromQuery(c)
+	if !ok {
+		return
+	}
+	filters.HubID = &hubID
+
+	respondWithHubEvents(c, gdb, filters)
+}
+
+// GET /api/hub-events/:id - Get a single event with RSVP counts
+func GetHubEvent(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	event := findHubEventFromParam(c, gdb)
+	if event == nil {
+		return
+	}
+
+	counts, err := db.CountEventRSVPs(gdb, []uuid.UUID{event.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch rsvps"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"event": transformHubEvent(event, counts[event.ID])})
+}
+
+// POST /api/hub-events - Create an event (ADMIN or the hub's CENTER_MANAGER)
+func CreateHubEvent(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req hubEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	hubID, err := uuid.Parse(req.HubID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+	hub, err := db.FindCenterByID(gdb, hubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if hub == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hub not found"})
+		return
+	}
+	if !canManageCenter(c, hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event must end after it starts"})
+		return
+	}
+
+	creatorID, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	event := db.HubEvent{HubID: hub.ID, Kind: db.EventWorkshop, CreatedBy: creatorID}
+	applyHubEventRequest(&event, &req, hub)
+
+	var announcement *db.HubActivity
+	if req.Announce {
+		announcement = &db.HubActivity{
+			HubID:       hub.ID,
+			Type:        db.ActivityAnnouncement,
+			Title:       event.Title,
+			Description: announcementText(&event, hub),
+			CreatedBy:   creatorID,
+		}
+	}
+
+	if err := db.CreateHubEvent(gdb, &event, announcement); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create event"})
+		return
+	}
+	event.Hub = *hub
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Event created successfully",
+		"event":   transformHubEvent(&event, db.RSVPCounts{}),
+	})
+}
+
+// PUT /api/hub-events/:id - Update an event (ADMIN or the hub's CENTER_MANAGER)
+func UpdateHubEvent(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	event := findHubEventFromParam(c, gdb)
+	if event == nil {
+		return
+	}
+	if !canManageCenter(c, &event.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req hubEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event must end after it starts"})
+		return
+	}
+	applyHubEventRequest(event, &req, &event.Hub)
+
+	promoted, err := db.UpdateHubEvent(gdb, event)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update event"})
+		return
+	}
+
+	counts, _ := db.CountEventRSVPs(gdb, []uuid.UUID{event.ID})
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Event updated successfully",
+		"event":    transformHubEvent(event, counts[event.ID]),
+		"promoted": len(promoted),
+	})
+}
+
+// PATCH /api/hub-events/:id/cancel - Cancel an event (ADMIN or the hub's CENTER_MANAGER)
+func CancelHubEvent(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	event := findHubEventFromParam(c, gdb)
+	if event == nil {
+		return
+	}
+	if !canManageCenter(c, &event.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	if err := gdb.Model(event).Update("cancelled", true).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel event"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Event cancelled successfully"})
+}
+
+// POST /api/hub-events/:id/rsvp - RSVP to an event, joining the waitlist when it is full
+func RSVPHubEvent(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	event := findHubEventFromParam(c, gdb)
+	if event == nil {
+		return
+	}
+	if event.Cancelled {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event has been cancelled"})
+		return
+	}
+	if !event.EndsAt.After(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "event has already ended"})
+		return
+	}
+
+	userID, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	rsvp, err := db.RSVPToEvent(gdb, event.ID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save rsvp"})
+		return
+	}
+
+	message := "You are going to this event"
+	if rsvp.Status == db.RSVPWaitlisted {
+		message = "The event is full; you have been added to the waitlist"
+	}
+	c.JSON(http.StatusOK, gin.H{"message": message, "rsvp": transformRSVP(rsvp)})
+}
+
+// DELETE /api/hub-events/:id/rsvp - Withdraw an RSVP
+func CancelHubEventRSVP(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	event := findHubEventFromParam(c, gdb)
+	if event == nil {
+		return
+	}
+
+	userID, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	rsvp, promoted, err := db.CancelRSVP(gdb, event.ID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel rsvp"})
+		return
+	}
+	if rsvp == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "rsvp not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "RSVP cancelled successfully", "promoted": len(promoted)})
+}
+
+// GET /api/hub-events/:id/attendees - List RSVPs and the waitlist (ADMIN or the hub's CENTER_MANAGER)
+func GetHubEventAttendees(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	event := findHubEventFromParam(c, gdb)
+	if event == nil {
+		return
+	}
+	if !canManageCenter(c, &event.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	rsvps, err := db.ListEventRSVPs(gdb, event.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch rsvps"})
+		return
+	}
+
+	attendees := make([]gin.H, 0, len(rsvps))
+	waitlist := make([]gin.H, 0)
+	for i := range rsvps {
+		entry := transformRSVP(&rsvps[i])
+		entry["userName"] = rsvps[i].User.Name
+		entry["userEmail"] = rsvps[i].User.Email
+		if rsvps[i].Status == db.RSVPWaitlisted {
+			waitlist = append(waitlist, entry)
+		} else {
+			attendees = append(attendees, entry)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"attendees": attendees, "waitlist": waitlist})
+}
+
+// POST /api/hub-events/:id/check-in - Mark an attendee as present (ADMIN or the hub's CENTER_MANAGER)
+func CheckInHubEvent(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	event := findHubEventFromParam(c, gdb)
+	if event == nil {
+		return
+	}
+	if !canManageCenter(c, &event.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req checkInRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	rsvp, err := db.FindEventRSVP(gdb, event.ID, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch rsvp"})
+		return
+	}
+	if rsvp == nil || rsvp.Status != db.RSVPGoing {
+		c.JSON(http.StatusNotFound, gin.H{"error": "user does not have a place at this event"})
+		return
+	}
+
+	if err := db.CheckInRSVP(gdb, rsvp); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check in"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checked in successfully", "rsvp": transformRSVP(rsvp)})
+}
+
+// GET /api/hub-events/:id/event.ics - iCalendar export of a single event
+func GetHubEventICS(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	event := findHubEventFromParam(c, gdb)
+	if event == nil {
+		return
+	}
+
+	writeHubEventsCalendar(c, event.Title, []db.HubEvent{*event}, event.ID.String())
+}
+
+// GET /api/hub-events/hub/:id/calendar.ics - iCalendar feed of a hub's events
+func GetHubEventsCalendar(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	hub := findCenterFromParam(c, gdb)
+	if hub == nil {
+		return
+	}
+
+	// Recent history plus everything upcoming, including cancellations so
+	// subscribed calendars drop them
+	from := time.Now().AddDate(0, 0, -30)
+	events, _, err := db.ListHubEvents(gdb, db.HubEventFilters{HubID: &hub.ID, From: &from, IncludeCancelled: true}, 0, 0)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch events"})
+		return
+	}
+
+	writeHubEventsCalendar(c, hub.Name+" events", events, hub.ID.String())
+}
+
+func respondWithHubEvents(c *gin.Context, gdb *gorm.DB, filters db.HubEventFilters) {
+	page, limit := paginationFromQuery(c)
+	events, total, err := db.ListHubEvents(gdb, filters, limit, (page-1)*limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch events"})
+		return
+	}
+
+	ids := make([]uuid.UUID, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	counts, err := db.CountEventRSVPs(gdb, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch rsvps"})
+		return
+	}
+
+	transformed := make([]gin.H, len(events))
+	for i := range events {
+		transformed[i] = transformHubEvent(&events[i], counts[events[i].ID])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": transformed,
+		"pagination": gin.H{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+// hubEventFiltersFromQuery parses ?kind, ?from and ?to; listings default to
+// events that have not ended yet
+func hubEventFiltersFromQuery(c *gin.Context) (db.HubEventFilters, bool) {
+	now := time.Now()
+	filters := db.HubEventFilters{From: &now, Kind: db.HubEventKind(strings.ToUpper(c.Query("kind")))}
+	for _, param := range []string{"from", "to"} {
+		v := c.Query(param)
+		if v == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": param + " must be an RFC3339 timestamp"})
+			return filters, false
+		}
+		if param == "from" {
+			filters.From = &t
+		} else {
+			filters.To = &t
+		}
+	}
+	return filters, true
+}
+
+// paginationFromQuery parses ?page and ?limit (default 20, max 100)
+func paginationFromQuery(c *gin.Context) (int, int) {
+	page := 1
+	limit := 20
+	if p := c.Query("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+	return page, limit
+}
+
+// findHubEventFromParam loads the event named by the :id parameter, writing
+// the error response and returning nil when it cannot
+func findHubEventFromParam(c *gin.Context, gdb *gorm.DB) *db.HubEvent {
+	eventID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event id"})
+		return nil
+	}
+
+	event, err := db.FindHubEventByID(gdb, eventID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch event"})
+		return nil
+	}
+	if event == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "event not found"})
+		return nil
+	}
+	return event
+}
+
+func applyHubEventRequest(event *db.HubEvent, req *hubEventRequest, hub *db.CommunityCenter) {
+	if req.Kind != "" {
+		event.Kind = db.HubEventKind(req.Kind)
+	}
+	event.Title = strings.TrimSpace(req.Title)
+	event.Description = req.Description
+	event.StartsAt = req.StartsAt.UTC()
+	event.EndsAt = req.EndsAt.UTC()
+	event.Location = strings.TrimSpace(req.Location)
+	if event.Location == "" {
+		event.Location = hub.Location
+	}
+	event.OnlineURL = req.OnlineURL
+	event.Capacity = req.Capacity
+}
+
+// announcementText describes the event for the hub feed in the hub's local time
+func announcementText(event *db.HubEvent, hub *db.CommunityCenter) string {
+	loc, err := time.LoadLocation(hub.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	text := fmt.Sprintf("%s on %s at %s.", strings.ReplaceAll(strings.ToLower(string(event.Kind)), "_", " "),
+		event.StartsAt.In(loc).Format("Mon 2 Jan 2006, 15:04 MST"), event.Location)
+	text = strings.ToUpper(text[:1]) + text[1:]
+	if event.Description != "" {
+		text += " " + event.Description
+	}
+	return text
+}
+
+func writeHubEventsCalendar(c *gin.Context, name string, events []db.HubEvent, filename string) {
+	cal := ical.Calendar{
+		ProdID: "-//Community Centres Platform//Hub Events//EN",
+		Name:   name,
+		Events: make([]ical.Event, len(events)),
+	}
+	for i, e := range events {
+		status := "CONFIRMED"
+		if e.Cancelled {
+			status = "CANCELLED"
+		}
+		description := e.Description
+		if e.OnlineURL != nil && *e.OnlineURL != "" {
+			description = strings.TrimSpace(description + "\n\nJoin online: " + *e.OnlineURL)
+		}
+		cal.Events[i] = ical.Event{
+			UID:         e.ID.String() + "@hub-events",
+			Summary:     e.Title,
+			Description: description,
+			Location:    e.Location,
+			Status:      status,
+			Start:       e.StartsAt,
+			End:         e.EndsAt,
+			Stamp:       e.UpdatedAt,
+		}
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.ics"`, filename))
+	c.Status(http.StatusOK)
+	if err := cal.Write(c.Writer); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+func transformHubEvent(e *db.HubEvent, counts db.RSVPCounts) gin.H {
+	var spotsLeft *int64
+	if e.Capacity != nil {
+		left := max(int64(*e.Capacity)-counts.Going, 0)
+		spotsLeft = &left
+	}
+	return gin.H{
+		"id":          e.ID,
+		"hubId":       e.HubID,
+		"hubName":     e.Hub.Name,
+		"kind":        e.Kind,
+		"title":       e.Title,
+		"description": e.Description,
+		"startsAt":    e.StartsAt,
+		"endsAt":      e.EndsAt,
+		"location":    e.Location,
+		"onlineUrl":   e.OnlineURL,
+		"capacity":    e.Capacity,
+		"spotsLeft":   spotsLeft,
+		"rsvps":       counts,
+		"cancelled":   e.Cancelled,
+		"activityId":  e.ActivityID,
+		"createdAt":   e.CreatedAt,
+		"updatedAt":   e.UpdatedAt,
+	}
+}
+
+func transformRSVP(r *db.EventRSVP) gin.H {
+	return gin.H{
+		"id":          r.ID,
+		"eventId":     r.EventID,
+		"userId":      r.UserID,
+		"status":      r.Status,
+		"checkedInAt": r.CheckedInAt,
+		"createdAt":   r.CreatedAt,
+	}
+}
@@ -0,0 +1,610 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/investors"
+	"communitycentresplatform/go-backend/internal/lifecycle"
+)
+
+type investorRequest struct {
+	Name         string   `json:"name" binding:"required,min=2,max=255"`
+	Type         string   `json:"type"` // Defaults to OTHER
+	Description  string   `json:"description"`
+	FocusSector  *string  `json:"focusSector" binding:"omitempty,max=100"`
+	TicketMin    *float64 `json:"ticketMin"`
+	TicketMax    *float64 `json:"ticketMax"`
+	Currency     string   `json:"currency"` // Defaults to USD
+	Website      *string  `json:"website" binding:"omitempty,max=500"`
+	ContactEmail *string  `json:"contactEmail" binding:"omitempty,email"`
+	Location     *string  `json:"location" binding:"omitempty,max=255"`
+	Active       *bool    `json:"active"`
+}
+
+type dealRequest struct {
+	InvestorID     string   `json:"investorId" binding:"required"`
+	EntrepreneurID string   `json:"entrepreneurId" binding:"required"`
+	HubID          string   `json:"hubId" binding:"required"` // Introducing hub
+	Amount         *float64 `json:"amount" binding:"omitempty,min=0"`
+	Notes          *string  `json:"notes"`
+}
+
+type dealStageRequest struct {
+	Stage  string   `json:"stage" binding:"required"`
+	Amount *float64 `json:"amount" binding:"omitempty,min=0"` // Required to mark a deal FUNDED unless already set
+	Notes  *string  `json:"notes"`
+	Reason *string  `json:"reason"`
+}
+
+// GET /api/investors - Search the investor directory
+func GetInvestors(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	filters := db.InvestorFilters{
+		SearchQuery: c.Query("q"),
+		Type:        db.InvestorType(strings.ToUpper(c.Query("type"))),
+		FocusSector: c.Query("sector"),
+	}
+	if raw := c.Query("ticketSize"); raw != "" {
+		size, err := strconv.ParseFloat(raw, 64)
+		if err != nil || size < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ticketSize"})
+			return
+		}
+		filters.TicketSize = &size
+	}
+	role := ctxutil.RoleFrom(c)
+	filters.IncludeInactive = c.Query("includeInactive") == "true" &&
+		(role == string(db.RoleAdmin) || role == string(db.RoleCenterManager))
+
+	page, limit := paginationFromQuery(c)
+	filters.Limit, filters.Offset = limit, (page-1)*limit
+	list, err := db.ListInvestors(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch investors"})
+		return
+	}
+	total, err := db.CountInvestors(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count investors"})
+		return
+	}
+
+	transformed := make([]gin.H, len(list))
+	for i := range list {
+		transformed[i] = transformInvestor(&list[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"investors": transformed,
+		"pagination": gin.H{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+// GET /api/investors/:id - Get an investor's profile and pipeline counts
+func GetInvestor(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	investor := findInvestorFromParam(c, gdb)
+	if investor == nil {
+		return
+	}
+
+	counts, err := db.CountInvestorDeals(gdb, investor.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch deals"})
+		return
+	}
+
+	out := transformInvestor(investor)
+	out["pipeline"] = counts
+	c.JSON(http.StatusOK, gin.H{"investor": out})
+}
+
+// POST /api/investors - Add an investor to the directory (ADMIN or CENTER_MANAGER)
+func CreateInvestor(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req investorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	investor := db.Investor{Active: true}
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		investor.CreatedBy = &uid
+	}
+	if status, msg := applyInvestorRequest(&investor, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	existing, err := db.FindInvestorBySlug(gdb, investor.Slug)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch investor"})
+		return
+	}
+	if existing != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "investor already listed", "investorId": existing.ID})
+		return
+	}
+
+	if err := db.SaveInvestor(gdb, &investor); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create investor"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Investor created successfully",
+		"investor": transformInvestor(&investor),
+	})
+}
+
+// PUT /api/investors/:id - Update an investor (ADMIN or the manager who listed it)
+func UpdateInvestor(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	investor := findInvestorFromParam(c, gdb)
+	if investor == nil {
+		return
+	}
+	if ctxutil.RoleFrom(c) != string(db.RoleAdmin) &&
+		(investor.CreatedBy == nil || investor.CreatedBy.String() != ctxutil.UserIDFrom(c)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return
+	}
+
+	var req investorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	previousName := investor.Name
+	if status, msg := applyInvestorRequest(investor, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+	existing, err := db.FindInvestorBySlug(gdb, investor.Slug)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch investor"})
+		return
+	}
+	if existing != nil && existing.ID != investor.ID {
+		c.JSON(http.StatusConflict, gin.H{"error": "investor already listed", "investorId": existing.ID})
+		return
+	}
+
+	// Keep the free-text name on linked services in step with the directory
+	err = gdb.Transaction(func(tx *gorm.DB) error {
+		if err := db.SaveInvestor(tx, investor); err != nil {
+			return err
+		}
+		if investor.Name == previousName {
+			return nil
+		}
+		return tx.Model(&db.ServiceProvision{}).Where("investor_id = ?", investor.ID).
+			Update("investor_name", investor.Name).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update investor"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Investor updated successfully",
+		"investor": transformInvestor(investor),
+	})
+}
+
+// GET /api/deals - List deals (admins see all, managers their hubs' introductions, entrepreneurs their own)
+func GetDeals(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	filters := db.DealFilters{Stage: db.DealStage(strings.ToUpper(c.Query("stage")))}
+	if filters.Stage != "" && !lifecycle.Deals.Valid(filters.Stage) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid stage"})
+		return
+	}
+	for param, dst := range map[string]**uuid.UUID{"investorId": &filters.InvestorID, "entrepreneurId": &filters.EntrepreneurID} {
+		if raw := c.Query(param); raw != "" {
+			id, err := uuid.Parse(raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param})
+				return
+			}
+			*dst = &id
+		}
+	}
+	var hubID *uuid.UUID
+	if raw := c.Query("hubId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+			return
+		}
+		hubID = &id
+		filters.HubIDs = []uuid.UUID{id}
+	}
+
+	switch ctxutil.RoleFrom(c) {
+	case string(db.RoleAdmin):
+	case string(db.RoleCenterManager):
+		managed, err := callerManagedCenterIDs(c, gdb)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hubs"})
+			return
+		}
+		if hubID == nil {
+			filters.HubIDs = append([]uuid.UUID{}, managed...)
+			break
+		}
+		allowed := false
+		for _, id := range managed {
+			allowed = allowed || id == *hubID
+		}
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+			return
+		}
+	case string(db.RoleEntrepreneur):
+		entrepreneur := currentEntrepreneur(c, gdb)
+		if entrepreneur == nil {
+			return
+		}
+		filters.EntrepreneurID = &entrepreneur.ID
+	default:
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return
+	}
+
+	deals, err := db.ListDeals(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch deals"})
+		return
+	}
+
+	transformed := make([]gin.H, len(deals))
+	for i := range deals {
+		transformed[i] = transformDeal(&deals[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deals": transformed, "total": len(transformed)})
+}
+
+// POST /api/deals - Introduce an enrolled entrepreneur to an investor (the hub's manager)
+func CreateDeal(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req dealRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	investorID, err := uuid.Parse(req.InvestorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid investor id"})
+		return
+	}
+	entrepreneurID, err := uuid.Parse(req.EntrepreneurID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entrepreneur id"})
+		return
+	}
+	hubID, err := uuid.Parse(req.HubID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+
+	ok, err := canManageCenterID(c, gdb, hubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only introduce entrepreneurs from hubs you manage"})
+		return
+	}
+
+	investor, err := db.FindInvestorByID(gdb, investorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch investor"})
+		return
+	}
+	if investor == nil || !investor.Active {
+		c.JSON(http.StatusNotFound, gin.H{"error": "investor not found"})
+		return
+	}
+
+	enrolled, err := db.HasHubEnrollment(gdb, hubID, entrepreneurID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch enrollment"})
+		return
+	}
+	if !enrolled {
+		c.JSON(http.StatusConflict, gin.H{"error": "entrepreneur is not enrolled at this hub"})
+		return
+	}
+
+	open, err := db.FindOpenDeal(gdb, investorID, entrepreneurID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch deals"})
+		return
+	}
+	if open != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "an open deal with this investor already exists", "dealId": open.ID})
+		return
+	}
+
+	deal := db.Deal{
+		InvestorID:     investorID,
+		EntrepreneurID: entrepreneurID,
+		HubID:          hubID,
+		Stage:          db.DealIntro,
+		Amount:         req.Amount,
+		Notes:          req.Notes,
+	}
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		deal.IntroducedBy = &uid
+	}
+	err = gdb.Transaction(func(tx *gorm.DB) error {
+		if err := db.CreateDeal(tx, &deal); err != nil {
+			return err
+		}
+		return lifecycle.RecordInitial(tx, db.StatusEntityDeal, deal.ID, string(deal.Stage), deal.IntroducedBy, nil)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create deal"})
+		return
+	}
+	deal.Investor = *investor
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Introduction recorded successfully",
+		"deal":    transformDeal(&deal),
+	})
+}
+
+// GET /api/deals/:id - Get a deal
+func GetDeal(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	deal := findDealFromParam(c, gdb)
+	if deal == nil {
+		return
+	}
+	allowed, err := canViewDeal(c, gdb, deal)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to view this deal"})
+		return
+	}
+
+	out := transformDeal(deal)
+	out["allowedStages"] = lifecycle.Deals.Allowed(deal.Stage)
+	c.JSON(http.StatusOK, gin.H{"deal": out})
+}
+
+// PATCH /api/deals/:id/stage - Move a deal through the pipeline (the introducing hub's manager)
+func UpdateDealStage(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	deal := findDealFromParam(c, gdb)
+	if deal == nil {
+		return
+	}
+	if !canManageCenter(c, &deal.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the introducing hub can update this deal"})
+		return
+	}
+
+	var req dealStageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	stage := db.DealStage(strings.ToUpper(req.Stage))
+	if err := lifecycle.Deals.Check(deal.Stage, stage); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "allowed": lifecycle.Deals.Allowed(deal.Stage)})
+		return
+	}
+	if req.Amount != nil {
+		deal.Amount = req.Amount
+	}
+	if stage == db.DealFunded && deal.Amount == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "amount is required to mark a deal funded"})
+		return
+	}
+	if req.Notes != nil {
+		deal.Notes = req.Notes
+	}
+
+	var actor *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		actor = &uid
+	}
+	if err := lifecycle.TransitionDeal(gdb, deal, stage, actor, req.Reason); err != nil {
+		if errors.Is(err, lifecycle.ErrIllegalTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update deal"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Deal updated successfully",
+		"deal":    transformDeal(deal),
+	})
+}
+
+// applyInvestorRequest copies a validated request onto the investor,
+// returning a non-zero status and message when it is rejected
+func applyInvestorRequest(inv *db.Investor, req *investorRequest) (int, string) {
+	inv.Name = strings.Join(strings.Fields(req.Name), " ")
+	inv.Slug = investors.Slug(inv.Name)
+	if inv.Slug == "" {
+		return http.StatusBadRequest, "name must contain letters or digits"
+	}
+	inv.Type = db.InvestorType(strings.ToUpper(req.Type))
+	if inv.Type == "" {
+		inv.Type = db.InvestorOther
+	}
+	inv.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+	if inv.Currency == "" {
+		inv.Currency = "USD"
+	}
+	inv.Description = req.Description
+	inv.FocusSector = req.FocusSector
+	inv.TicketMin = req.TicketMin
+	inv.TicketMax = req.TicketMax
+	inv.Website = req.Website
+	inv.ContactEmail = req.ContactEmail
+	inv.Location = req.Location
+	if req.Active != nil {
+		inv.Active = *req.Active
+	}
+	if err := investors.CheckProfile(inv); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	return 0, ""
+}
+
+// findInvestorFromParam loads the investor named by the :id parameter,
+// writing the error response and returning nil when it cannot
+func findInvestorFromParam(c *gin.Context, gdb *gorm.DB) *db.Investor {
+	investorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid investor id"})
+		return nil
+	}
+	investor, err := db.FindInvestorByID(gdb, investorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch investor"})
+		return nil
+	}
+	if investor == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "investor not found"})
+		return nil
+	}
+	return investor
+}
+
+// findDealFromParam loads the deal named by the :id parameter, writing the
+// error response and returning nil when it cannot
+func findDealFromParam(c *gin.Context, gdb *gorm.DB) *db.Deal {
+	dealID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid deal id"})
+		return nil
+	}
+	deal, err := db.FindDealByID(gdb, dealID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch deal"})
+		return nil
+	}
+	if deal == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "deal not found"})
+		return nil
+	}
+	return deal
+}
+
+func transformInvestor(inv *db.Investor) gin.H {
+	return gin.H{
+		"id":           inv.ID,
+		"name":         inv.Name,
+		"type":         inv.Type,
+		"description":  inv.Description,
+		"focusSector":  inv.FocusSector,
+		"ticketMin":    inv.TicketMin,
+		"ticketMax":    inv.TicketMax,
+		"currency":     inv.Currency,
+		"website":      inv.Website,
+		"contactEmail": inv.ContactEmail,
+		"location":     inv.Location,
+		"active":       inv.Active,
+		"createdAt":    inv.CreatedAt,
+		"updatedAt":    inv.UpdatedAt,
+	}
+}
+
+func transformDeal(d *db.Deal) gin.H {
+	out := gin.H{
+		"id":                 d.ID,
+		"investorId":         d.InvestorID,
+		"entrepreneurId":     d.EntrepreneurID,
+		"hubId":              d.HubID,
+		"stage":              d.Stage,
+		"amount":             d.Amount,
+		"notes":              d.Notes,
+		"introducedBy":       d.IntroducedBy,
+		"serviceProvisionId": d.ServiceProvisionID,
+		"closedAt":           d.ClosedAt,
+		"createdAt":          d.CreatedAt,
+		"updatedAt":          d.UpdatedAt,
+	}
+	if d.Investor.ID != uuid.Nil {
+		out["investorName"] = d.Investor.Name
+		out["currency"] = d.Investor.Currency
+	}
+	if d.Entrepreneur.ID != uuid.Nil {
+		out["businessName"] = d.Entrepreneur.BusinessName
+	}
+	if d.Hub.ID != uuid.Nil {
+		out["hubName"] = d.Hub.Name
+	}
+	return out
+}
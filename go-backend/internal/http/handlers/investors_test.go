@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDealAccess(t *testing.T) {
+	f := newHubFixture(t)
+
+	f.do(t, f.managerB, "PATCH", "/api/deals/"+f.deal+"/stage", gin.H{"stage": "PASSED"})
+	if n := f.fake.writeCount("investor_deals"); n != 0 {
+		t.Fatalf("expected no writes to investor_deals, got %d", n)
+	}
+
+	f.check(t, []accessCase{
+		// Investor deals belong to the introducing hub
+		{"own hub deal", f.managerA, "GET", "/api/deals/" + f.deal, nil, http.StatusOK},
+		{"other hub deal", f.managerB, "GET", "/api/deals/" + f.deal, nil, http.StatusForbidden},
+		{"owner deal", f.founder, "GET", "/api/deals/" + f.deal, nil, http.StatusOK},
+		{"other founder deal", f.otherFounder, "GET", "/api/deals/" + f.deal, nil, http.StatusForbidden},
+		{"other hub deal history", f.managerB, "GET", "/api/deals/" + f.deal + "/history", nil, http.StatusForbidden},
+		{"other hub deals listing", f.managerB, "GET", "/api/deals?hubId=" + f.hubA, nil, http.StatusForbidden},
+		{"introduce from other hub", f.managerB, "POST", "/api/deals", gin.H{
+			"investorId": f.investor, "entrepreneurId": f.entrepreneur, "hubId": f.hubA,
+		}, http.StatusForbidden},
+		{"other hub stage change", f.managerB, "PATCH", "/api/deals/" + f.deal + "/stage", gin.H{"stage": "MEETING"}, http.StatusForbidden},
+		{"skip to funded", f.managerA, "PATCH", "/api/deals/" + f.deal + "/stage", gin.H{"stage": "FUNDED", "amount": 5000}, http.StatusConflict},
+		{"own hub stage change", f.managerA, "PATCH", "/api/deals/" + f.deal + "/stage", gin.H{"stage": "MEETING"}, http.StatusOK},
+	})
+}
+
+func TestDealStageConflict(t *testing.T) {
+	f := newHubFixture(t)
+
+	// Another manager moves the deal on between our read and write
+	f.fake.race("investor_deals", f.deal, map[string]interface{}{"stage": "PASSED"})
+	if w := f.do(t, f.managerA, "PATCH", "/api/deals/"+f.deal+"/stage", gin.H{"stage": "MEETING"}); w.Code != http.StatusConflict {
+		t.Fatalf("expected a stale stage change to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := f.fake.row("investor_deals", f.deal)["stage"]; got != "PASSED" {
+		t.Fatalf("expected the other change to stand, got %v", got)
+	}
+	if n := f.fake.writeCount("status_transitions"); n != 0 {
+		t.Fatalf("expected no history for a refused change, got %d writes", n)
+	}
+}
@@ -0,0 +1,1114 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/lifecycle"
+	"communitycentresplatform/go-backend/internal/mentoring"
+)
+
+// Mentoring access rules. A mentor's side is the mentor's own user plus the
+// managers of their hosting hub (and admins); the mentee's side is the
+// entrepreneur's user. Either side can view and schedule; only the mentor's
+// side accepts requests and completes sessions.
+
+type availabilitySlot struct {
+	Weekday  int    `json:"weekday" binding:"min=0,max=6"`
+	StartsAt string `json:"startsAt" binding:"required"`
+	EndsAt   string `json:"endsAt" binding:"required"`
+}
+
+type mentorProfileRequest struct {
+	UserID            string             `json:"userId"` // Create only
+	HubID             string             `json:"hubId"`  // Create only; the hosting hub
+	Headline          string             `json:"headline" binding:"max=255"`
+	Bio               string             `json:"bio"`
+	Expertise         []string           `json:"expertise"`
+	Availability      []availabilitySlot `json:"availability" binding:"omitempty,dive"` // Omit to keep the current windows
+	AcceptingRequests *bool              `json:"acceptingRequests"`
+	Active            *bool              `json:"active"` // Hub managers only
+}
+
+type mentorRequestRequest struct {
+	Topic   string `json:"topic" binding:"required,min=3,max=255"`
+	Message string `json:"message"`
+}
+
+type mentorResponseRequest struct {
+	Status string  `json:"status" binding:"required"`
+	Note   *string `json:"note"`
+}
+
+type mentoringSessionRequest struct {
+	RequestID       string  `json:"requestId"` // Create only
+	ScheduledAt     string  `json:"scheduledAt" binding:"required"`
+	DurationMinutes int     `json:"durationMinutes" binding:"required"`
+	Location        *string `json:"location" binding:"omitempty,max=500"`
+	Agenda          *string `json:"agenda"`
+	Notes           *string `json:"notes"`
+}
+
+type sessionOutcomeRequest struct {
+	Notes   *string `json:"notes"`
+	Outcome *string `json:"outcome"`
+	Reason  *string `json:"reason"`
+}
+
+// GET /api/mentors - Search the mentor marketplace
+func GetMentors(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	filters := db.MentorFilters{
+		SearchQuery:   c.Query("q"),
+		AcceptingOnly: c.Query("accepting") == "true",
+	}
+	if raw := c.Query("expertise"); raw != "" {
+		tags, err := mentoring.NormalizeExpertise(strings.Split(raw, ","))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		filters.Expertise = tags
+	}
+	if raw := c.Query("hubId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+			return
+		}
+		filters.HubID = &id
+	}
+	role := ctxutil.RoleFrom(c)
+	filters.IncludeInactive = c.Query("includeInactive") == "true" &&
+		(role == string(db.RoleAdmin) || role == string(db.RoleCenterManager))
+
+	page, limit := paginationFromQuery(c)
+	filters.Limit, filters.Offset = limit, (page-1)*limit
+	mentors, err := db.ListMentors(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch mentors"})
+		return
+	}
+	total, err := db.CountMentors(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count mentors"})
+		return
+	}
+
+	transformed := make([]gin.H, len(mentors))
+	for i := range mentors {
+		transformed[i] = transformMentor(&mentors[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"mentors": transformed,
+		"pagination": gin.H{
+			"page":       page,
+			"limit":      limit,
+			"total":      total,
+			"totalPages": (total + int64(limit) - 1) / int64(limit),
+		},
+	})
+}
+
+// GET /api/mentors/me - Get the caller's own mentor profile
+func GetMyMentorProfile(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	userID, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	mentor, err := db.FindMentorByUserID(gdb, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch mentor profile"})
+		return
+	}
+	if mentor == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "mentor profile not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"mentor": transformMentor(mentor)})
+}
+
+// GET /api/mentors/:id - Get a mentor's profile and session counts
+func GetMentor(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	mentor := findMentorFromParam(c, gdb)
+	if mentor == nil {
+		return
+	}
+	if !mentor.Active && !isMentorUser(c, mentor) && !canManageCenter(c, &mentor.Hub) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "mentor not found"})
+		return
+	}
+
+	counts, err := db.CountMentorSessions(gdb, mentor.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch sessions"})
+		return
+	}
+
+	out := transformMentor(mentor)
+	out["sessions"] = counts
+	c.JSON(http.StatusOK, gin.H{"mentor": out})
+}
+
+// POST /api/mentors - Register a user as a mentor hosted by a hub (the hub's manager)
+func CreateMentor(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req mentorProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+	hubID, err := uuid.Parse(req.HubID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+
+	hub, err := db.FindCenterByID(gdb, hubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if hub == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hub not found"})
+		return
+	}
+	if !canManageCenter(c, hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only register mentors for hubs you manage"})
+		return
+	}
+
+	var user db.User
+	if err := gdb.First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch user"})
+		return
+	}
+
+	existing, err := db.FindMentorByUserID(gdb, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch mentor profile"})
+		return
+	}
+	if existing != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "user already has a mentor profile", "mentorId": existing.ID})
+		return
+	}
+
+	mentor := db.Mentor{UserID: userID, HubID: hubID, AcceptingRequests: true, Active: true}
+	if req.Availability == nil {
+		req.Availability = []availabilitySlot{}
+	}
+	slots, status, msg := applyMentorProfileRequest(&mentor, hub, &req, true)
+	if status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		mentor.CreatedBy = &uid
+	}
+
+	if err := db.SaveMentor(gdb, &mentor, slots); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create mentor profile"})
+		return
+	}
+	mentor.User = user
+	mentor.Hub = *hub
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Mentor created successfully",
+		"mentor":  transformMentor(&mentor),
+	})
+}
+
+// PUT /api/mentors/:id - Update a mentor profile (the mentor or their hub's manager)
+func UpdateMentor(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	mentor := findMentorFromParam(c, gdb)
+	if mentor == nil {
+		return
+	}
+	manager := canManageCenter(c, &mentor.Hub)
+	if !manager && !isMentorUser(c, mentor) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to update this mentor"})
+		return
+	}
+
+	var req mentorProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	slots, status, msg := applyMentorProfileRequest(mentor, &mentor.Hub, &req, manager)
+	if status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	if err := db.SaveMentor(gdb, mentor, slots); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update mentor profile"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Mentor updated successfully",
+		"mentor":  transformMentor(mentor),
+	})
+}
+
+// POST /api/mentors/:id/requests - Ask a mentor for mentorship (ENTREPRENEUR)
+func CreateMentorRequest(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	mentor := findMentorFromParam(c, gdb)
+	if mentor == nil {
+		return
+	}
+	if !mentor.Active {
+		c.JSON(http.StatusNotFound, gin.H{"error": "mentor not found"})
+		return
+	}
+	if isMentorUser(c, mentor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "you cannot request yourself as a mentor"})
+		return
+	}
+	if !mentor.AcceptingRequests {
+		c.JSON(http.StatusConflict, gin.H{"error": "mentor is not accepting requests"})
+		return
+	}
+
+	var req mentorRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	entrepreneur := currentEntrepreneur(c, gdb)
+	if entrepreneur == nil {
+		return
+	}
+
+	open, err := db.FindOpenMentorRequest(gdb, mentor.ID, entrepreneur.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch requests"})
+		return
+	}
+	if open != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "you already have an open request with this mentor", "requestId": open.ID})
+		return
+	}
+
+	request := db.MentorRequest{
+		MentorID:       mentor.ID,
+		EntrepreneurID: entrepreneur.ID,
+		Topic:          strings.TrimSpace(req.Topic),
+		Message:        req.Message,
+		Status:         db.MentorRequestPending,
+	}
+	if err := db.CreateMentorRequest(gdb, &request); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create request"})
+		return
+	}
+	request.Mentor = *mentor
+	request.Entrepreneur = *entrepreneur
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Mentor request sent successfully",
+		"request": transformMentorRequest(&request),
+	})
+}
+
+// GET /api/mentor-requests - List requests the caller sent, received or hosts
+func GetMentorRequests(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	status := db.MentorRequestStatus(strings.ToUpper(c.Query("status")))
+	if status != "" && !lifecycle.MentorRequests.Valid(status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+	scope, err := callerMentorshipScope(c, gdb)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch requests"})
+		return
+	}
+
+	requests, err := db.ListMentorRequests(gdb, scope, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch requests"})
+		return
+	}
+
+	transformed := make([]gin.H, len(requests))
+	for i := range requests {
+		transformed[i] = transformMentorRequest(&requests[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"requests": transformed, "total": len(transformed)})
+}
+
+// GET /api/mentor-requests/:id - Get a mentor request
+func GetMentorRequest(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	request := findMentorRequestFromParam(c, gdb)
+	if request == nil {
+		return
+	}
+	mentorSide, menteeSide, err := mentorshipSides(c, gdb, &request.Mentor, request.EntrepreneurID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	if !mentorSide && !menteeSide {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to view this request"})
+		return
+	}
+
+	out := transformMentorRequest(request)
+	out["allowed"] = lifecycle.MentorRequests.Allowed(request.Status)
+	c.JSON(http.StatusOK, gin.H{"request": out})
+}
+
+// PATCH /api/mentor-requests/:id - Accept, decline, withdraw or close a request
+func RespondMentorRequest(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	request := findMentorRequestFromParam(c, gdb)
+	if request == nil {
+		return
+	}
+	mentorSide, menteeSide, err := mentorshipSides(c, gdb, &request.Mentor, request.EntrepreneurID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	if !mentorSide && !menteeSide {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to update this request"})
+		return
+	}
+
+	var req mentorResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	status := db.MentorRequestStatus(strings.ToUpper(req.Status))
+	switch status {
+	case db.MentorRequestAccepted, db.MentorRequestDeclined:
+		if !mentorSide {
+			c.JSON(http.StatusForbidden, gin.H{"error": "only the mentor can accept or decline a request"})
+			return
+		}
+	case db.MentorRequestWithdrawn:
+		if !menteeSide {
+			c.JSON(http.StatusForbidden, gin.H{"error": "only the entrepreneur can withdraw a request"})
+			return
+		}
+	}
+	if err := lifecycle.MentorRequests.Check(request.Status, status); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "allowed": lifecycle.MentorRequests.Allowed(request.Status)})
+		return
+	}
+
+	var responder *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		responder = &uid
+	}
+	if err := lifecycle.TransitionMentorRequest(gdb, request, status, responder, req.Note); err != nil {
+		if errors.Is(err, lifecycle.ErrIllegalTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update request"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Mentor request updated successfully",
+		"request": transformMentorRequest(request),
+	})
+}
+
+// GET /api/mentoring-sessions - List sessions the caller mentors, attends or hosts
+func GetMentoringSessions(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	filters := db.SessionFilters{Status: db.MentoringSessionStatus(strings.ToUpper(c.Query("status")))}
+	if filters.Status != "" && !lifecycle.Sessions.Valid(filters.Status) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status"})
+		return
+	}
+	if raw := c.Query("requestId"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request id"})
+			return
+		}
+		filters.RequestID = &id
+	}
+	for param, dst := range map[string]**time.Time{"from": &filters.From, "to": &filters.To} {
+		if raw := c.Query(param); raw != "" {
+			t, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + param + " date, use ISO8601"})
+				return
+			}
+			*dst = &t
+		}
+	}
+	scope, err := callerMentorshipScope(c, gdb)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch sessions"})
+		return
+	}
+
+	sessions, err := db.ListMentoringSessions(gdb, scope, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch sessions"})
+		return
+	}
+
+	transformed := make([]gin.H, len(sessions))
+	for i := range sessions {
+		transformed[i] = transformMentoringSession(&sessions[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": transformed, "total": len(transformed)})
+}
+
+// POST /api/mentoring-sessions - Schedule a session on an accepted request (either side)
+func CreateMentoringSession(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req mentoringSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	requestID, err := uuid.Parse(req.RequestID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request id"})
+		return
+	}
+
+	request, err := db.FindMentorRequestByID(gdb, requestID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch request"})
+		return
+	}
+	if request == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "mentor request not found"})
+		return
+	}
+	mentorSide, menteeSide, err := mentorshipSides(c, gdb, &request.Mentor, request.EntrepreneurID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	if !mentorSide && !menteeSide {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to schedule sessions for this request"})
+		return
+	}
+	if request.Status != db.MentorRequestAccepted {
+		c.JSON(http.StatusConflict, gin.H{"error": "sessions can only be scheduled on accepted requests"})
+		return
+	}
+
+	session := db.MentoringSession{
+		RequestID:      request.ID,
+		MentorID:       request.MentorID,
+		EntrepreneurID: request.EntrepreneurID,
+		HubID:          request.Mentor.HubID,
+		Status:         db.SessionScheduled,
+	}
+	if status, msg := applySessionRequest(gdb, &session, &request.Mentor, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	var actor *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		actor = &uid
+	}
+	session.CreatedBy = actor
+	err = gdb.Transaction(func(tx *gorm.DB) error {
+		if err := db.CreateMentoringSession(tx, &session); err != nil {
+			return err
+		}
+		return lifecycle.RecordInitial(tx, db.StatusEntitySession, session.ID, string(session.Status), actor, nil)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to schedule session"})
+		return
+	}
+	session.Mentor = request.Mentor
+	session.Entrepreneur = request.Entrepreneur
+	session.Hub = request.Mentor.Hub
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Session scheduled successfully",
+		"session": transformMentoringSession(&session),
+	})
+}
+
+// GET /api/mentoring-sessions/:id - Get a mentoring session
+func GetMentoringSession(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	session, _ := findSessionForCaller(c, gdb, "view")
+	if session == nil {
+		return
+	}
+
+	out := transformMentoringSession(session)
+	out["topic"] = session.Request.Topic
+	out["allowed"] = lifecycle.Sessions.Allowed(session.Status)
+	c.JSON(http.StatusOK, gin.H{"session": out})
+}
+
+// PUT /api/mentoring-sessions/:id - Reschedule a session or edit its details (either side)
+func UpdateMentoringSession(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	session, _ := findSessionForCaller(c, gdb, "update")
+	if session == nil {
+		return
+	}
+	if session.Status != db.SessionScheduled {
+		c.JSON(http.StatusConflict, gin.H{"error": "only scheduled sessions can be changed"})
+		return
+	}
+
+	var req mentoringSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if status, msg := applySessionRequest(gdb, session, &session.Mentor, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	if err := db.UpdateMentoringSession(gdb, session); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session updated successfully",
+		"session": transformMentoringSession(session),
+	})
+}
+
+// PATCH /api/mentoring-sessions/:id/complete - Mark a session held and log it as a
+// mentorship service at the hosting hub (the mentor's side)
+func CompleteMentoringSession(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	session, mentorSide := findSessionForCaller(c, gdb, "update")
+	if session == nil {
+		return
+	}
+	if !mentorSide {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the mentor can complete a session"})
+		return
+	}
+
+	var req sessionOutcomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if err := lifecycle.Sessions.Check(session.Status, db.SessionCompleted); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "allowed": lifecycle.Sessions.Allowed(session.Status)})
+		return
+	}
+	if session.ScheduledAt.After(time.Now()) {
+		c.JSON(http.StatusConflict, gin.H{"error": "session has not started yet"})
+		return
+	}
+	if req.Notes != nil {
+		session.Notes = req.Notes
+	}
+	if req.Outcome != nil {
+		session.Outcome = req.Outcome
+	}
+
+	var actor *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		actor = &uid
+	}
+	service := mentoring.ServiceFor(session, session.Mentor.User.Name, session.Request.Topic)
+	err := gdb.Transaction(func(tx *gorm.DB) error {
+		// The guarded transition goes first, so of two concurrent completions
+		// only the one that moved the session records a service
+		if err := lifecycle.TransitionSession(tx, session, db.SessionCompleted, actor, req.Reason); err != nil {
+			return err
+		}
+		if err := tx.Omit(clause.Associations).Create(&service).Error; err != nil {
+			return err
+		}
+		reason := "mentoring session completed"
+		if err := lifecycle.RecordInitial(tx, db.StatusEntityService, service.ID, string(service.Status), actor, &reason); err != nil {
+			return err
+		}
+		session.ServiceProvisionID = &service.ID
+		return tx.Model(&db.MentoringSession{}).Where("id = ?", session.ID).Update("service_provision_id", service.ID).Error
+	})
+	if err != nil {
+		if errors.Is(err, lifecycle.ErrIllegalTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to complete session"})
+		return
+	}
+
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitCenterUpdate(session.HubID.String(), gin.H{
+			"serviceId":      service.ID,
+			"hubId":          session.HubID,
+			"entrepreneurId": session.EntrepreneurID,
+			"serviceType":    service.ServiceType,
+			"action":         "service_created",
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session completed successfully",
+		"session": transformMentoringSession(session),
+	})
+}
+
+// PATCH /api/mentoring-sessions/:id/cancel - Cancel a scheduled session (either side)
+func CancelMentoringSession(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	session, _ := findSessionForCaller(c, gdb, "update")
+	if session == nil {
+		return
+	}
+
+	var req sessionOutcomeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if err := lifecycle.Sessions.Check(session.Status, db.SessionCancelled); err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "allowed": lifecycle.Sessions.Allowed(session.Status)})
+		return
+	}
+
+	var actor *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		actor = &uid
+	}
+	if err := lifecycle.TransitionSession(gdb, session, db.SessionCancelled, actor, req.Reason); err != nil {
+		if errors.Is(err, lifecycle.ErrIllegalTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Session cancelled successfully",
+		"session": transformMentoringSession(session),
+	})
+}
+
+// GET /api/mentoring-sessions/:id/history - Status history of a mentoring session
+func GetMentoringSessionHistory(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	session, _ := findSessionForCaller(c, gdb, "view")
+	if session == nil {
+		return
+	}
+
+	transitions, err := db.ListStatusTransitions(gdb, db.StatusEntitySession, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch status history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sessionId": session.ID,
+		"status":    session.Status,
+		"allowed":   lifecycle.Sessions.Allowed(session.Status),
+		"history":   transformStatusTransitions(transitions),
+	})
+}
+
+// applyMentorProfileRequest copies a validated request onto the profile and
+// returns the availability to store (nil keeps the current windows), or a
+// non-zero status and message when it is rejected. Only hub managers may
+// change whether the profile is active.
+func applyMentorProfileRequest(m *db.Mentor, hub *db.CommunityCenter, req *mentorProfileRequest, manager bool) ([]db.MentorAvailability, int, string) {
+	expertise, err := mentoring.NormalizeExpertise(req.Expertise)
+	if err != nil {
+		return nil, http.StatusBadRequest, err.Error()
+	}
+	if req.Active != nil && !manager {
+		return nil, http.StatusForbidden, "only the hosting hub can activate or deactivate a mentor"
+	}
+
+	var slots []db.MentorAvailability
+	if req.Availability != nil {
+		slots = make([]db.MentorAvailability, len(req.Availability))
+		for i, s := range req.Availability {
+			slots[i] = db.MentorAvailability{Weekday: s.Weekday, StartsAt: s.StartsAt, EndsAt: s.EndsAt}
+		}
+		if _, err := mentoring.Schedule(hub.TimeZone, slots); err != nil {
+			return nil, http.StatusBadRequest, err.Error()
+		}
+	}
+
+	m.Headline = strings.TrimSpace(req.Headline)
+	m.Bio = req.Bio
+	m.Expertise = expertise
+	if req.AcceptingRequests != nil {
+		m.AcceptingRequests = *req.AcceptingRequests
+	}
+	if req.Active != nil {
+		m.Active = *req.Active
+	}
+	return slots, 0, ""
+}
+
+// applySessionRequest validates a session's timing against the mentor's
+// availability and other sessions and copies the request onto it, returning
+// a non-zero status and message when it is rejected
+func applySessionRequest(gdb *gorm.DB, s *db.MentoringSession, mentor *db.Mentor, req *mentoringSessionRequest) (int, string) {
+	start, err := time.Parse(time.RFC3339, req.ScheduledAt)
+	if err != nil {
+		return http.StatusBadRequest, "invalid scheduledAt, use ISO8601"
+	}
+	availability, err := mentoring.Schedule(mentor.Hub.TimeZone, mentor.Availability)
+	if err != nil {
+		// A hub time zone or stored window that no longer parses should not
+		// block scheduling; fall back to no availability check
+		availability = nil
+	}
+	if err := mentoring.CheckSession(availability, start, req.DurationMinutes, time.Now()); err != nil {
+		if errors.Is(err, mentoring.ErrUnavailable) {
+			return http.StatusConflict, err.Error()
+		}
+		return http.StatusBadRequest, err.Error()
+	}
+
+	nearby, err := db.ListMentorSessionsBetween(gdb, mentor.ID,
+		start.Add(-mentoring.MaxSessionMinutes*time.Minute), start.Add(time.Duration(req.DurationMinutes)*time.Minute))
+	if err != nil {
+		return http.StatusInternalServerError, "failed to fetch sessions"
+	}
+	if mentoring.Conflict(nearby, start, req.DurationMinutes, s.ID) != nil {
+		return http.StatusConflict, "mentor already has a session at this time"
+	}
+
+	s.ScheduledAt = start
+	s.DurationMinutes = req.DurationMinutes
+	s.Location = req.Location
+	s.Agenda = req.Agenda
+	if req.Notes != nil {
+		s.Notes = req.Notes
+	}
+	return 0, ""
+}
+
+// isMentorUser reports whether the caller owns the mentor profile
+func isMentorUser(c *gin.Context, m *db.Mentor) bool {
+	uid, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	return err == nil && m.UserID == uid
+}
+
+// mentorshipSides reports whether the caller acts for the mentor (the mentor,
+// their hosting hub's manager or an admin) and for the mentee
+func mentorshipSides(c *gin.Context, gdb *gorm.DB, m *db.Mentor, entrepreneurID uuid.UUID) (bool, bool, error) {
+	mentorSide := isMentorUser(c, m)
+	if !mentorSide {
+		ok, err := canManageCenterID(c, gdb, m.HubID)
+		if err != nil {
+			return false, false, err
+		}
+		mentorSide = ok
+	}
+	menteeSide, err := isEntrepreneurOwner(c, gdb, entrepreneurID)
+	return mentorSide, menteeSide, err
+}
+
+// callerMentorshipScope resolves the requests and sessions the caller may
+// list: their own as mentor or mentee plus those of mentors at hubs they manage
+func callerMentorshipScope(c *gin.Context, gdb *gorm.DB) (db.MentorshipScope, error) {
+	if ctxutil.RoleFrom(c) == string(db.RoleAdmin) {
+		return db.MentorshipScope{All: true}, nil
+	}
+	uid, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		return db.MentorshipScope{}, nil
+	}
+
+	managed, err := callerManagedCenterIDs(c, gdb)
+	if err != nil {
+		return db.MentorshipScope{}, err
+	}
+	mentorIDs, err := db.ListMentorIDs(gdb, uid, managed)
+	if err != nil {
+		return db.MentorshipScope{}, err
+	}
+	var entrepreneurIDs []uuid.UUID
+	if err := gdb.Model(&db.Entrepreneur{}).Where("user_id = ?", uid).Pluck("id", &entrepreneurIDs).Error; err != nil {
+		return db.MentorshipScope{}, err
+	}
+	return db.MentorshipScope{MentorIDs: mentorIDs, EntrepreneurIDs: entrepreneurIDs}, nil
+}
+
+// findMentorFromParam loads the mentor named by the :id parameter, writing
+// the error response and returning nil when it cannot
+func findMentorFromParam(c *gin.Context, gdb *gorm.DB) *db.Mentor {
+	mentorID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mentor id"})
+		return nil
+	}
+	mentor, err := db.FindMentorByID(gdb, mentorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch mentor"})
+		return nil
+	}
+	if mentor == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "mentor not found"})
+		return nil
+	}
+	return mentor
+}
+
+// findMentorRequestFromParam loads the request named by the :id parameter,
+// writing the error response and returning nil when it cannot
+func findMentorRequestFromParam(c *gin.Context, gdb *gorm.DB) *db.MentorRequest {
+	requestID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request id"})
+		return nil
+	}
+	request, err := db.FindMentorRequestByID(gdb, requestID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch request"})
+		return nil
+	}
+	if request == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "mentor request not found"})
+		return nil
+	}
+	return request
+}
+
+// findSessionForCaller loads the session named by the :id parameter and
+// checks the caller is on either side of it, writing the error response and
+// returning nil when they are not. It also reports whether they act for the
+// mentor.
+func findSessionForCaller(c *gin.Context, gdb *gorm.DB, action string) (*db.MentoringSession, bool) {
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+		return nil, false
+	}
+	session, err := db.FindMentoringSessionByID(gdb, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch session"})
+		return nil, false
+	}
+	if session == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return nil, false
+	}
+	mentorSide, menteeSide, err := mentorshipSides(c, gdb, &session.Mentor, session.EntrepreneurID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return nil, false
+	}
+	if !mentorSide && !menteeSide {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to " + action + " this session"})
+		return nil, false
+	}
+	return session, mentorSide
+}
+
+func transformMentor(m *db.Mentor) gin.H {
+	availability := make([]gin.H, len(m.Availability))
+	for i, a := range m.Availability {
+		availability[i] = gin.H{"weekday": a.Weekday, "startsAt": a.StartsAt, "endsAt": a.EndsAt}
+	}
+	out := gin.H{
+		"id":                m.ID,
+		"userId":            m.UserID,
+		"hubId":             m.HubID,
+		"headline":          m.Headline,
+		"bio":               m.Bio,
+		"expertise":         m.Expertise,
+		"availability":      availability,
+		"acceptingRequests": m.AcceptingRequests,
+		"active":            m.Active,
+		"createdAt":         m.CreatedAt,
+		"updatedAt":         m.UpdatedAt,
+	}
+	if m.User.ID != uuid.Nil {
+		out["name"] = m.User.Name
+		out["pictureUrl"] = m.User.PictureURL
+	}
+	if m.Hub.ID != uuid.Nil {
+		out["hubName"] = m.Hub.Name
+		out["timeZone"] = m.Hub.TimeZone
+	}
+	return out
+}
+
+func transformMentorRequest(r *db.MentorRequest) gin.H {
+	out := gin.H{
+		"id":             r.ID,
+		"mentorId":       r.MentorID,
+		"entrepreneurId": r.EntrepreneurID,
+		"topic":          r.Topic,
+		"message":        r.Message,
+		"status":         r.Status,
+		"responseNote":   r.ResponseNote,
+		"respondedAt":    r.RespondedAt,
+		"createdAt":      r.CreatedAt,
+		"updatedAt":      r.UpdatedAt,
+	}
+	if r.Mentor.User.ID != uuid.Nil {
+		out["mentorName"] = r.Mentor.User.Name
+	}
+	if r.Mentor.Hub.ID != uuid.Nil {
+		out["hubName"] = r.Mentor.Hub.Name
+	}
+	if r.Entrepreneur.ID != uuid.Nil {
+		out["businessName"] = r.Entrepreneur.BusinessName
+	}
+	return out
+}
+
+func transformMentoringSession(s *db.MentoringSession) gin.H {
+	out := gin.H{
+		"id":                 s.ID,
+		"requestId":          s.RequestID,
+		"mentorId":           s.MentorID,
+		"entrepreneurId":     s.EntrepreneurID,
+		"hubId":              s.HubID,
+		"scheduledAt":        s.ScheduledAt,
+		"durationMinutes":    s.DurationMinutes,
+		"location":           s.Location,
+		"agenda":             s.Agenda,
+		"notes":              s.Notes,
+		"outcome":            s.Outcome,
+		"status":             s.Status,
+		"serviceProvisionId": s.ServiceProvisionID,
+		"completedAt":        s.CompletedAt,
+		"createdAt":          s.CreatedAt,
+		"updatedAt":          s.UpdatedAt,
+	}
+	if s.Mentor.User.ID != uuid.Nil {
+		out["mentorName"] = s.Mentor.User.Name
+	}
+	if s.Entrepreneur.ID != uuid.Nil {
+		out["businessName"] = s.Entrepreneur.BusinessName
+	}
+	if s.Hub.ID != uuid.Nil {
+		out["hubName"] = s.Hub.Name
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMentorAccess(t *testing.T) {
+	f := newHubFixture(t)
+
+	f.do(t, f.managerB, "PATCH", "/api/mentoring-sessions/"+f.session+"/complete", gin.H{})
+	f.do(t, f.founder, "PATCH", "/api/mentoring-sessions/"+f.session+"/complete", gin.H{})
+	f.do(t, f.otherFounder, "PATCH", "/api/mentor-requests/"+f.mentorRequest, gin.H{"status": "CLOSED"})
+	for _, table := range []string{"mentor_requests", "mentoring_sessions", "service_provisions"} {
+		if n := f.fake.writeCount(table); n != 0 {
+			t.Fatalf("expected no writes to %s, got %d", table, n)
+		}
+	}
+
+	f.check(t, []accessCase{
+		// Mentors are managed by their hosting hub; sessions are shared by both sides
+		{"register mentor at other hub", f.managerB, "POST", "/api/mentors", gin.H{"userId": f.otherFounder, "hubId": f.hubA}, http.StatusForbidden},
+		{"other hub edits mentor", f.managerB, "PUT", "/api/mentors/" + f.mentor, gin.H{"headline": "Hijacked"}, http.StatusForbidden},
+		{"founder edits mentor", f.founder, "PUT", "/api/mentors/" + f.mentor, gin.H{"headline": "Hijacked"}, http.StatusForbidden},
+		{"mentor edits own profile", f.mentorUser, "PUT", "/api/mentors/" + f.mentor, gin.H{"headline": "Agritech mentor", "expertise": []string{"Pricing"}}, http.StatusOK},
+		{"mentor deactivates self", f.mentorUser, "PUT", "/api/mentors/" + f.mentor, gin.H{"active": false}, http.StatusForbidden},
+		{"other hub mentor request", f.managerB, "GET", "/api/mentor-requests/" + f.mentorRequest, nil, http.StatusForbidden},
+		{"mentee mentor request", f.founder, "GET", "/api/mentor-requests/" + f.mentorRequest, nil, http.StatusOK},
+		{"other founder closes request", f.otherFounder, "PATCH", "/api/mentor-requests/" + f.mentorRequest, gin.H{"status": "CLOSED"}, http.StatusForbidden},
+		{"mentee withdraws accepted", f.founder, "PATCH", "/api/mentor-requests/" + f.mentorRequest, gin.H{"status": "WITHDRAWN"}, http.StatusConflict},
+		{"other founder schedules", f.otherFounder, "POST", "/api/mentoring-sessions", gin.H{
+			"requestId": f.mentorRequest, "scheduledAt": time.Now().Add(48 * time.Hour).Format(time.RFC3339), "durationMinutes": 60,
+		}, http.StatusForbidden},
+		{"mentee schedules", f.founder, "POST", "/api/mentoring-sessions", gin.H{
+			"requestId": f.mentorRequest, "scheduledAt": time.Now().Add(48 * time.Hour).Format(time.RFC3339), "durationMinutes": 60,
+		}, http.StatusCreated},
+		{"mentor session", f.mentorUser, "GET", "/api/mentoring-sessions/" + f.session, nil, http.StatusOK},
+		{"hosting hub session", f.managerA, "GET", "/api/mentoring-sessions/" + f.session, nil, http.StatusOK},
+		{"other hub session", f.managerB, "GET", "/api/mentoring-sessions/" + f.session, nil, http.StatusForbidden},
+		{"other founder session", f.otherFounder, "GET", "/api/mentoring-sessions/" + f.session, nil, http.StatusForbidden},
+		{"mentee completes session", f.founder, "PATCH", "/api/mentoring-sessions/" + f.session + "/complete", gin.H{}, http.StatusForbidden},
+		{"other hub completes session", f.managerB, "PATCH", "/api/mentoring-sessions/" + f.session + "/complete", gin.H{}, http.StatusForbidden},
+		{"mentor completes session", f.mentorUser, "PATCH", "/api/mentoring-sessions/" + f.session + "/complete", gin.H{"outcome": "Pricing model drafted"}, http.StatusOK},
+	})
+}
+
+func TestCompletedSessionLogsService(t *testing.T) {
+	f := newHubFixture(t)
+
+	w := f.do(t, f.managerA, "PATCH", "/api/mentoring-sessions/"+f.session+"/complete", gin.H{"notes": "Reviewed costings"})
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected hosting hub manager to complete the session, got %d: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Session struct {
+			Status             string  `json:"status"`
+			ServiceProvisionID *string `json:"serviceProvisionId"`
+		} `json:"session"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &body)
+	if body.Session.Status != "COMPLETED" || body.Session.ServiceProvisionID == nil {
+		t.Fatalf("expected a completed session linked to a service, got %+v", body.Session)
+	}
+	if n := f.fake.writeCount("service_provisions"); n != 1 {
+		t.Fatalf("expected one mentorship service to be logged, got %d writes", n)
+	}
+}
+
+func TestSessionCompleteConflict(t *testing.T) {
+	f := newHubFixture(t)
+
+	// The mentor completes the session while the hub manager is doing so
+	f.fake.race("mentoring_sessions", f.session, map[string]interface{}{"status": "COMPLETED"})
+	w := f.do(t, f.managerA, "PATCH", "/api/mentoring-sessions/"+f.session+"/complete", gin.H{})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected the second completion to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := f.fake.writeCount("service_provisions"); n != 0 {
+		t.Fatalf("expected no second mentorship service, got %d writes", n)
+	}
+}
+
+func TestMentorRequestResponseConflict(t *testing.T) {
+	f := newHubFixture(t)
+	f.fake.row("mentor_requests", f.mentorRequest)["status"] = "PENDING"
+
+	// The mentee withdraws while the mentor is accepting
+	f.fake.race("mentor_requests", f.mentorRequest, map[string]interface{}{"status": "WITHDRAWN"})
+	w := f.do(t, f.mentorUser, "PATCH", "/api/mentor-requests/"+f.mentorRequest, gin.H{"status": "ACCEPTED"})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected the stale acceptance to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if status := f.fake.row("mentor_requests", f.mentorRequest)["status"]; status != "WITHDRAWN" {
+		t.Fatalf("expected the withdrawal to stand, got %v", status)
+	}
+}
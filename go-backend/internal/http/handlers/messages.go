@@ -1,16 +1,45 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/cursor"
 	"communitycentresplatform/go-backend/internal/db"
 )
 
+const (
+	defaultMessagePage    = 50
+	maxMessagePage        = 200
+	maxMessageAttachments = 10
+	maxMessageUploadBytes = 50 << 20 // All attachments of one message together
+	messageEditWindow     = 15 * time.Minute
+)
+
+// platformSenderName is shown for messages admins send as the platform
+// rather than as a center
+const platformSenderName = "Community Centres Platform"
+
+var errMessageTooLarge = errors.New("attachments may not exceed 50 MB in total")
+
+type threadMessageRequest struct {
+	Content        string   `json:"content"`
+	UploadIDs      []string `json:"uploadIds"`      // Files already sent to POST /api/uploads
+	SenderCenterID string   `json:"senderCenterId"` // Required when the caller could send as several centers
+}
+
 // GET /api/messages/contact - Get contact messages (Admin only)
 func GetContactMessages(c *gin.Context) {
 	gdb := ctxutil.DBFrom(c)
@@ -135,7 +164,7 @@ func SendContactMessage(c *gin.Context) {
 	})
 }
 
-// GET /api/messages/threads/:centerId - Get message threads for a center
+// GET /api/messages/threads/:centerId - Get message threads for a center (?archived=true for the ones it archived)
 func GetThreadsForCenter(c *gin.Context) {
 	gdb := ctxutil.DBFrom(c)
 	if gdb == nil {
@@ -169,10 +198,31 @@ func GetThreadsForCenter(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch threads"})
 		return
 	}
+	counts, err := db.CountUnread(gdb, []uuid.UUID{centerID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count unread messages"})
+		return
+	}
+	unread := make(map[uuid.UUID]int64, len(counts))
+	for _, count := range counts {
+		unread[count.ThreadID] = count.Unread
+	}
+	states, err := threadStates(gdb, centerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch thread settings"})
+		return
+	}
 
 	// Transform to match Node.js format
-	transformed := make([]gin.H, len(threads))
-	for i, thread := range threads {
+	archivedOnly := c.Query("archived") == "true"
+	now := time.Now()
+	transformed := make([]gin.H, 0, len(threads))
+	for _, thread := range threads {
+		state := states[thread.ID]
+		if state.IsArchived(thread.LastActivity) != archivedOnly {
+			continue
+		}
+
 		// Get participant IDs and names
 		participantIDs := make([]uuid.UUID, len(thread.Participants))
 		participantNames := make([]string, len(thread.Participants))
@@ -189,14 +239,17 @@ func GetThreadsForCenter(c *gin.Context) {
 				"id":         msg.ID,
 				"threadId":   msg.ThreadID,
 				"senderId":   msg.SenderID,
-				"senderName": msg.Sender.Name,
+				"senderName": senderName(&msg),
+				"senderType": senderType(&msg),
 				"content":    msg.Content,
 				"timestamp":  msg.CreatedAt,
-				"read":       msg.Read,
+				"editedAt":   msg.EditedAt,
+				"deleted":    msg.DeletedAt != nil,
+				"read":       (msg.SenderID != nil && *msg.SenderID == centerID) || unread[thread.ID] == 0, // By this center
 			}
 		}
 
-		transformed[i] = gin.H{
+		transformed = append(transformed, gin.H{
 			"id":               thread.ID,
 			"participants":     participantIDs,
 			"participantNames": participantNames,
@@ -204,13 +257,17 @@ func GetThreadsForCenter(c *gin.Context) {
 			"lastMessage":      lastMessage,
 			"lastActivity":     thread.LastActivity,
 			"messageCount":     thread.MessageCount,
-		}
+			"unreadCount":      unread[thread.ID],
+			"archived":         archivedOnly,
+			"muted":            state.IsMuted(now),
+			"mutedUntil":       state.MutedUntil,
+		})
 	}
 
 	c.JSON(http.StatusOK, gin.H{"threads": transformed})
 }
 
-// GET /api/messages/threads/:threadId/messages - Get messages in a thread
+// GET /api/messages/threads/:threadId/messages - Get a page of messages in a thread, newest first (?before= or ?after= cursor, ?limit=)
 func GetThreadMessages(c *gin.Context) {
 	gdb := ctxutil.DBFrom(c)
 	if gdb == nil {
@@ -225,9 +282,6 @@ func GetThreadMessages(c *gin.Context) {
 		return
 	}
 
-	userID := ctxutil.UserIDFrom(c)
-	role := ctxutil.RoleFrom(c)
-
 	// Verify thread exists
 	thread, err := db.FindThreadByID(gdb, threadID)
 	if err != nil || thread == nil {
@@ -235,150 +289,272 @@ func GetThreadMessages(c *gin.Context) {
 		return
 	}
 
-	// Verify access (admin or participant's manager)
-	hasAccess := role == "ADMIN"
-	if !hasAccess {
-		uid, _ := uuid.Parse(userID)
-		for _, participant := range thread.Participants {
-			if participant.ManagerID != nil && *participant.ManagerID == uid {
-				hasAccess = true
-				break
-			}
-		}
+	if !canAccessThread(c, thread) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "thread not found or access denied"})
+		return
 	}
 
-	if !hasAccess {
-		c.JSON(http.StatusForbidden, gin.H{"error": "thread not found or access denied"})
+	page, ok := messagePageFromQuery(c)
+	if !ok {
 		return
 	}
 
 	// Get messages
-	messages, err := db.ListMessagesInThread(gdb, threadID, 0, 0)
+	messages, hasMore, err := db.ListMessagesInThread(gdb, threadID, page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch messages"})
 		return
 	}
 
+	ids := make([]uuid.UUID, len(messages))
+	for i := range messages {
+		ids[i] = messages[i].ID
+	}
+	attachments, err := attachmentsFor(c, gdb, db.UploadForMessage, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch attachments"})
+		return
+	}
+	cursors, err := db.ListReadCursors(gdb, []uuid.UUID{threadID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch read receipts"})
+		return
+	}
+
+	lastRead := make(map[uuid.UUID]time.Time, len(cursors))
+	for _, cursor := range cursors {
+		lastRead[cursor.CenterID] = cursor.LastReadAt
+	}
+
 	// Transform to match Node.js format
 	transformed := make([]gin.H, len(messages))
 	for i, msg := range messages {
+		read, readBy := messageReceipts(&msg, thread, lastRead)
+		files := attachments[msg.ID]
+		if msg.DeletedAt != nil {
+			files = []gin.H{}
+		}
 		transformed[i] = gin.H{
-			"id":         msg.ID,
-			"threadId":   msg.ThreadID,
-			"senderId":   msg.SenderID,
-			"senderName": msg.Sender.Name,
-			"content":    msg.Content,
-			"timestamp":  msg.CreatedAt,
-			"read":       msg.Read,
+			"id":          msg.ID,
+			"threadId":    msg.ThreadID,
+			"senderId":    msg.SenderID,
+			"senderName":  senderName(&msg),
+			"senderType":  senderType(&msg),
+			"content":     msg.Content,
+			"timestamp":   msg.CreatedAt,
+			"editedAt":    msg.EditedAt,
+			"deleted":     msg.DeletedAt != nil,
+			"read":        read,
+			"readBy":      readBy,
+			"attachments": files,
 		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{"messages": transformed})
+	// History pages continue from the oldest message shown; catching up
+	// continues from the newest, even when there was nothing new yet
+	var nextCursor *string
+	switch {
+	case page.After != nil && len(messages) > 0:
+		next := cursor.Encode(cursor.Position{At: messages[0].CreatedAt, ID: messages[0].ID})
+		nextCursor = &next
+	case page.After != nil:
+		next := c.Query("after")
+		nextCursor = &next
+	case hasMore:
+		oldest := messages[len(messages)-1]
+		next := cursor.Encode(cursor.Position{At: oldest.CreatedAt, ID: oldest.ID})
+		nextCursor = &next
+	}
+
+	c.JSON(http.StatusOK, gin.H{"messages": transformed, "hasMore": hasMore, "nextCursor": nextCursor})
 }
 
-// POST /api/messages/threads/:threadId/messages - Send message to thread
-func SendThreadMessage(c *gin.Context) {
+// GET /api/messages/thread-messages/:threadId/attachments/:uploadId - Download a message attachment (?variant=thumbnail for the preview)
+func GetMessageAttachment(c *gin.Context) {
 	gdb := ctxutil.DBFrom(c)
 	if gdb == nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
 		return
 	}
+	store := ctxutil.StorageFrom(c)
+	if store == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "storage unavailable"})
+		return
+	}
 
-	threadIDStr := c.Param("threadId")
-	threadID, err := uuid.Parse(threadIDStr)
+	threadID, err := uuid.Parse(c.Param("threadId"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid thread id"})
 		return
 	}
-
-	var req struct {
-		Content string `json:"content" binding:"required,min=1"`
+	uploadID, err := uuid.Parse(c.Param("uploadId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload id"})
+		return
 	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+	// Same checks as GetThreadMessages; deleted messages keep no attachments
+	thread, err := db.FindThreadByID(gdb, threadID)
+	if err != nil || thread == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "thread not found or access denied"})
+		return
+	}
+	if !canAccessThread(c, thread) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "thread not found or access denied"})
 		return
 	}
 
-	userID := ctxutil.UserIDFrom(c)
-	role := ctxutil.RoleFrom(c)
-	uid, _ := uuid.Parse(userID)
+	upload, err := db.FindUploadByID(gdb, uploadID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch file"})
+		return
+	}
+	if upload == nil || upload.EntityType != db.UploadForMessage || upload.EntityID == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		return
+	}
+	var count int64
+	err = gdb.Model(&db.CenterMessage{}).Where("id = ? AND thread_id = ? AND deleted_at IS NULL", *upload.EntityID, threadID).Count(&count).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch message"})
+		return
+	}
+	if count == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "attachment not found"})
+		return
+	}
 
-	// Find a center managed by this user to send from
-	var senderCenter *db.CommunityCenter
-	if role == "ADMIN" {
-		// Admin can send from any verified center
-		centers, _ := db.ListCenters(gdb, db.CenterFilters{VerificationStatus: "verified", Limit: 1})
-		if len(centers) > 0 {
-			senderCenter = &centers[0]
-		}
-	} else {
-		// Find center managed by this user
-		centers, _ := db.ListCenters(gdb, db.CenterFilters{Limit: 100})
-		for _, center := range centers {
-			if center.ManagerID != nil && *center.ManagerID == uid {
-				senderCenter = &center
-				break
-			}
+	key, contentType := upload.StorageKey, upload.ContentType
+	if c.Query("variant") == "thumbnail" {
+		if upload.ThumbnailKey == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "file has no thumbnail"})
+			return
 		}
+		key, contentType = *upload.ThumbnailKey, ""
 	}
+	serveStored(c, store, key, contentType, upload.FileName)
+}
 
-	if senderCenter == nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": "no center found to send message from"})
+// POST /api/messages/threads/:threadId/messages - Send message to thread
+func SendThreadMessage(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	threadIDStr := c.Param("threadId")
+	threadID, err := uuid.Parse(threadIDStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid thread id"})
+		return
+	}
+
+	// JSON, or multipart with "content" and one or more "files"
+	var req threadMessageRequest
+	var files []*multipart.FileHeader
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		if !parseMultipartBody(c, maxMessageUploadBytes, errMessageTooLarge) {
+			return
+		}
+		req.Content = c.PostForm("content")
+		req.UploadIDs = c.PostFormArray("uploadIds")
+		req.SenderCenterID = c.PostForm("senderCenterId")
+		files = c.Request.MultipartForm.File["files"]
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	attachmentCount := len(files) + len(req.UploadIDs)
+	if strings.TrimSpace(req.Content) == "" && attachmentCount == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "content or an attachment is required"})
+		return
+	}
+	if attachmentCount > maxMessageAttachments {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("a message can have at most %d attachments", maxMessageAttachments)})
 		return
 	}
 
-	// Verify thread exists and user has access
+	// Verify thread exists and pick the center the caller sends as
 	thread, err := db.FindThreadByID(gdb, threadID)
 	if err != nil || thread == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "thread not found or access denied"})
 		return
 	}
-
-	// Check if sender's center is a participant
-	isParticipant := false
-	for _, participant := range thread.Participants {
-		if participant.ID == senderCenter.ID {
-			isParticipant = true
-			break
-		}
+	senderCenter, status, msg := messageSender(c, gdb, thread, req.SenderCenterID)
+	if status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
 	}
 
-	if !isParticipant {
-		c.JSON(http.StatusForbidden, gin.H{"error": "thread not found or access denied"})
+	// Files are only stored once the sender is known to be allowed
+	uploadIDs, ok := claimUploads(c, gdb, req.UploadIDs)
+	if !ok {
 		return
 	}
+	var saved []db.Upload
+	if len(files) > 0 {
+		store := ctxutil.StorageFrom(c)
+		if store == nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "storage unavailable"})
+			return
+		}
+		if saved, ok = saveUploads(c, gdb, store, "messages/"+threadID.String(), files); !ok {
+			return
+		}
+		for _, u := range saved {
+			uploadIDs = append(uploadIDs, u.ID)
+		}
+	}
 
 	// Create message (transaction updates thread metadata)
-	message, err := db.CreateMessage(gdb, threadID, senderCenter.ID, req.Content)
+	message, err := db.CreateMessage(gdb, threadID, centerIDOf(senderCenter), callerUserID(c), req.Content, uploadIDs)
 	if err != nil {
+		discardUploads(c, gdb, saved)
+		if errors.Is(err, db.ErrUploadsClaimed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send message"})
 		return
 	}
 
+	// The message is sent; a failed lookup only leaves the listing short
+	attachments, err := attachmentsFor(c, gdb, db.UploadForMessage, []uuid.UUID{message.ID})
+	if err != nil {
+		log.Printf("Failed to fetch attachments of message %s: %v", message.ID, err)
+		attachments = map[uuid.UUID][]gin.H{message.ID: {}}
+	}
+
 	// Emit real-time event
 	if br := ctxutil.BrokerFrom(c); br != nil {
 		br.EmitNewMessage(threadID.String(), gin.H{
-			"id":         message.ID,
-			"threadId":   message.ThreadID,
-			"senderId":   message.SenderID,
-			"senderName": senderCenter.Name,
-			"content":    message.Content,
-			"timestamp":  message.CreatedAt,
-			"read":       message.Read,
+			"id":          message.ID,
+			"threadId":    message.ThreadID,
+			"senderId":    message.SenderID,
+			"senderName":  senderName(message),
+			"senderType":  senderType(message),
+			"content":     message.Content,
+			"timestamp":   message.CreatedAt,
+			"read":        false,
+			"readBy":      []uuid.UUID{},
+			"attachments": attachments[message.ID],
 		})
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
 		"message": "Message sent successfully",
 		"centerMessage": gin.H{
-			"id":         message.ID,
-			"threadId":   message.ThreadID,
-			"senderId":   message.SenderID,
-			"senderName": senderCenter.Name,
-			"content":    message.Content,
-			"timestamp":  message.CreatedAt,
-			"read":       message.Read,
+			"id":          message.ID,
+			"threadId":    message.ThreadID,
+			"senderId":    message.SenderID,
+			"senderName":  senderName(message),
+			"senderType":  senderType(message),
+			"content":     message.Content,
+			"timestamp":   message.CreatedAt,
+			"read":        false,
+			"readBy":      []uuid.UUID{},
+			"attachments": attachments[message.ID],
 		},
 	})
 }
@@ -395,6 +571,7 @@ func CreateThread(c *gin.Context) {
 		ParticipantIDs []string `json:"participantIds" binding:"required,min=2"`
 		Subject        string   `json:"subject" binding:"required,min=5"`
 		InitialMessage string   `json:"initialMessage" binding:"required,min=1"`
+		SenderCenterID string   `json:"senderCenterId"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -402,29 +579,10 @@ func CreateThread(c *gin.Context) {
 		return
 	}
 
-	userID := ctxutil.UserIDFrom(c)
-	role := ctxutil.RoleFrom(c)
-	uid, _ := uuid.Parse(userID)
-
-	// Find sender center
-	var senderCenter *db.CommunityCenter
-	if role == "ADMIN" {
-		centers, _ := db.ListCenters(gdb, db.CenterFilters{VerificationStatus: "verified", Limit: 1})
-		if len(centers) > 0 {
-			senderCenter = &centers[0]
-		}
-	} else {
-		centers, _ := db.ListCenters(gdb, db.CenterFilters{Limit: 100})
-		for _, center := range centers {
-			if center.ManagerID != nil && *center.ManagerID == uid {
-				senderCenter = &center
-				break
-			}
-		}
-	}
-
-	if senderCenter == nil {
-		c.JSON(http.StatusForbidden, gin.H{"error": "no center found to send message from"})
+	// Find sender center; it takes part in the thread it starts
+	senderCenter, status, msg := messageSender(c, gdb, nil, req.SenderCenterID)
+	if status != 0 {
+		c.JSON(status, gin.H{"error": msg})
 		return
 	}
 
@@ -438,6 +596,9 @@ func CreateThread(c *gin.Context) {
 		}
 		participantIDs[i] = id
 	}
+	if senderCenter != nil && !slices.Contains(participantIDs, senderCenter.ID) {
+		participantIDs = append(participantIDs, senderCenter.ID)
+	}
 
 	// Create thread with participants
 	thread, err := db.CreateMessageThread(gdb, req.Subject, participantIDs)
@@ -447,7 +608,7 @@ func CreateThread(c *gin.Context) {
 	}
 
 	// Create initial message
-	message, err := db.CreateMessage(gdb, thread.ID, senderCenter.ID, req.InitialMessage)
+	message, err := db.CreateMessage(gdb, thread.ID, centerIDOf(senderCenter), callerUserID(c), req.InitialMessage, nil)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to send initial message"})
 		return
@@ -470,13 +631,578 @@ func CreateThread(c *gin.Context) {
 				"id":         message.ID,
 				"threadId":   message.ThreadID,
 				"senderId":   message.SenderID,
-				"senderName": senderCenter.Name,
+				"senderName": senderName(message),
+				"senderType": senderType(message),
 				"content":    message.Content,
 				"timestamp":  message.CreatedAt,
-				"read":       message.Read,
+				"read":       false,
+				"readBy":     []uuid.UUID{},
 			},
 			"lastActivity": thread.LastActivity,
 			"messageCount": thread.MessageCount,
 		},
 	})
 }
+
+// PATCH /api/messages/thread-messages/:threadId/messages/:messageId - Edit a message, within messageEditWindow of sending it
+func EditThreadMessage(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req struct {
+		Content string `json:"content" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || strings.TrimSpace(req.Content) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "content is required"})
+		return
+	}
+
+	thread := findAccessibleThreadFromParam(c, gdb)
+	if thread == nil {
+		return
+	}
+	message := findChangeableMessage(c, gdb, thread)
+	if message == nil {
+		return
+	}
+	if !isMessageSender(c, message) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the sender can edit a message"})
+		return
+	}
+	if time.Since(message.CreatedAt) > messageEditWindow {
+		c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("messages can only be edited within %d minutes of sending", int(messageEditWindow.Minutes()))})
+		return
+	}
+
+	if req.Content != message.Content {
+		if err := db.EditMessage(gdb, message, req.Content, callerUserID(c)); err != nil {
+			if errors.Is(err, db.ErrMessageChanged) {
+				c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to edit message"})
+			return
+		}
+	}
+
+	// The edit is saved; a failed lookup only leaves the listing short
+	attachments, err := attachmentsFor(c, gdb, db.UploadForMessage, []uuid.UUID{message.ID})
+	if err != nil {
+		log.Printf("Failed to fetch attachments of message %s: %v", message.ID, err)
+		attachments = map[uuid.UUID][]gin.H{message.ID: {}}
+	}
+	updated := changedMessageJSON(message, attachments[message.ID])
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitMessageUpdated(thread.ID.String(), updated)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Message updated", "centerMessage": updated})
+}
+
+// DELETE /api/messages/thread-messages/:threadId/messages/:messageId - Delete a message, leaving a tombstone (sender or admin)
+func DeleteThreadMessage(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	thread := findAccessibleThreadFromParam(c, gdb)
+	if thread == nil {
+		return
+	}
+	message := findChangeableMessage(c, gdb, thread)
+	if message == nil {
+		return
+	}
+	if !isMessageSender(c, message) && ctxutil.RoleFrom(c) != "ADMIN" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the sender can delete a message"})
+		return
+	}
+
+	if err := db.DeleteMessage(gdb, message, callerUserID(c)); err != nil {
+		if errors.Is(err, db.ErrMessageChanged) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete message"})
+		return
+	}
+	if err := deleteAttachments(c, gdb, db.UploadForMessage, message.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete attachments"})
+		return
+	}
+
+	deleted := changedMessageJSON(message, []gin.H{})
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitMessageDeleted(thread.ID.String(), deleted)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Message deleted", "centerMessage": deleted})
+}
+
+// GET /api/messages/thread-messages/:threadId/messages/:messageId/revisions - A message's earlier contents (Admin only)
+func GetMessageRevisions(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	threadID, err := uuid.Parse(c.Param("threadId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid thread id"})
+		return
+	}
+	messageID, err := uuid.Parse(c.Param("messageId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message id"})
+		return
+	}
+	message, err := db.FindMessageInThread(gdb, threadID, messageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch message"})
+		return
+	}
+	if message == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "message not found"})
+		return
+	}
+
+	revisions, err := db.ListMessageRevisions(gdb, messageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch revisions"})
+		return
+	}
+	transformed := make([]gin.H, len(revisions))
+	for i, r := range revisions {
+		transformed[i] = gin.H{
+			"id":        r.ID,
+			"content":   r.Content,
+			"action":    strings.ToLower(string(r.Action)),
+			"changedBy": r.ChangedBy,
+			"changedAt": r.CreatedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"centerMessage": changedMessageJSON(message, nil), "revisions": transformed})
+}
+
+// POST /api/messages/thread-messages/:threadId/read - Mark a thread read for one of the caller's centers
+func MarkThreadRead(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	threadID, err := uuid.Parse(c.Param("threadId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid thread id"})
+		return
+	}
+
+	// Both fields are optional: the reading center defaults to the caller's
+	// participating center, and the thread is read up to now
+	var req struct {
+		CenterID  string `json:"centerId"`
+		MessageID string `json:"messageId"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+			return
+		}
+	}
+
+	thread, err := db.FindThreadByID(gdb, threadID)
+	if err != nil || thread == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "thread not found or access denied"})
+		return
+	}
+	if !canAccessThread(c, thread) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "thread not found or access denied"})
+		return
+	}
+	reader, status, msg := readingCenter(c, thread, req.CenterID)
+	if reader == nil {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	upTo := time.Now()
+	if req.MessageID != "" {
+		messageID, err := uuid.Parse(req.MessageID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message id"})
+			return
+		}
+		var message db.CenterMessage
+		if err := gdb.Where("id = ? AND thread_id = ?", messageID, threadID).First(&message).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "message not found"})
+			return
+		}
+		upTo = message.CreatedAt
+	}
+
+	readerID, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	cursor, err := db.MarkThreadRead(gdb, threadID, reader.ID, readerID, upTo)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to mark thread as read"})
+		return
+	}
+
+	receipt := gin.H{
+		"threadId":   threadID,
+		"centerId":   reader.ID,
+		"centerName": reader.Name,
+		"lastReadAt": cursor.LastReadAt,
+		"readBy":     cursor.ReadBy,
+	}
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitReadReceipt(threadID.String(), reader.ID.String(), receipt)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Thread marked as read", "receipt": receipt})
+}
+
+// GET /api/messages/unread - Unread message counts across the caller's centers (?centerId= for one); muted threads are left out
+func GetUnreadCounts(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var centerIDs []uuid.UUID
+	if raw := c.Query("centerId"); raw != "" {
+		centerID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid center id"})
+			return
+		}
+		manages, err := canManageCenterID(c, gdb, centerID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+			return
+		}
+		if !manages {
+			c.JSON(http.StatusForbidden, gin.H{"error": "access denied to this center"})
+			return
+		}
+		centerIDs = []uuid.UUID{centerID}
+	} else {
+		managed, err := callerManagedCenterIDs(c, gdb)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch centers"})
+			return
+		}
+		centerIDs = managed
+	}
+
+	counts, err := db.CountUnread(gdb, centerIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to count unread messages"})
+		return
+	}
+	states, err := db.ListThreadStates(gdb, centerIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch thread settings"})
+		return
+	}
+	now := time.Now()
+	muted := make(map[[2]uuid.UUID]bool, len(states))
+	for _, state := range states {
+		muted[[2]uuid.UUID{state.CenterID, state.ThreadID}] = state.IsMuted(now)
+	}
+
+	perCenter := make(map[uuid.UUID]int64, len(centerIDs))
+	threads := make(map[uuid.UUID]int, len(centerIDs))
+	var total int64
+	for _, count := range counts {
+		if muted[[2]uuid.UUID{count.CenterID, count.ThreadID}] {
+			continue
+		}
+		perCenter[count.CenterID] += count.Unread
+		threads[count.CenterID]++
+		total += count.Unread
+	}
+	centers := make([]gin.H, len(centerIDs))
+	for i, id := range centerIDs {
+		centers[i] = gin.H{"centerId": id, "unread": perCenter[id], "unreadThreads": threads[id]}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"total": total, "centers": centers})
+}
+
+// messagePageFromQuery reads the before/after cursors and page size of a
+// thread listing. It writes the error response and returns false when they
+// are invalid.
+func messagePageFromQuery(c *gin.Context) (db.MessagePage, bool) {
+	page := db.MessagePage{Limit: defaultMessagePage}
+	if l := c.Query("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return page, false
+		}
+		page.Limit = min(n, maxMessagePage)
+	}
+	before, after := c.Query("before"), c.Query("after")
+	if before != "" && after != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "use either before or after, not both"})
+		return page, false
+	}
+	if before != "" {
+		pos, err := cursor.Decode(before)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return page, false
+		}
+		page.Before = &pos
+	}
+	if after != "" {
+		pos, err := cursor.Decode(after)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return page, false
+		}
+		page.After = &pos
+	}
+	return page, true
+}
+
+// readingCenter picks the participating center the caller reads a thread
+// as: the requested one, or else the first participant they manage. Admins
+// manage no center, so they must name one. A nil center comes with the
+// status and message to respond with.
+func readingCenter(c *gin.Context, thread *db.MessageThread, requested string) (*db.CommunityCenter, int, string) {
+	isAdmin := ctxutil.RoleFrom(c) == "ADMIN"
+	uid, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	var requestedID uuid.UUID
+	if requested != "" {
+		id, err := uuid.Parse(requested)
+		if err != nil {
+			return nil, http.StatusBadRequest, "invalid center id"
+		}
+		requestedID = id
+	} else if isAdmin {
+		return nil, http.StatusBadRequest, "centerId is required"
+	}
+
+	for i := range thread.Participants {
+		p := &thread.Participants[i]
+		manages := isAdmin || (p.ManagerID != nil && *p.ManagerID == uid)
+		if requested == "" && manages {
+			return p, 0, ""
+		}
+		if p.ID == requestedID {
+			if !manages {
+				return nil, http.StatusForbidden, "you do not manage this center"
+			}
+			return p, 0, ""
+		}
+	}
+	return nil, http.StatusBadRequest, "center is not a participant in this thread"
+}
+
+// messageSender picks the center the caller sends a message as. A requested
+// center must be one they are the manager of, even for admins, and, in an
+// existing thread, a participant. Otherwise managers send as their one
+// eligible center, and admins as the platform, for which the center is nil.
+// A nonzero status comes with the message to respond with.
+func messageSender(c *gin.Context, gdb *gorm.DB, thread *db.MessageThread, requested string) (*db.CommunityCenter, int, string) {
+	if requested != "" {
+		id, err := uuid.Parse(requested)
+		if err != nil {
+			return nil, http.StatusBadRequest, "invalid senderCenterId"
+		}
+		center, err := db.FindCenterByID(gdb, id)
+		if err != nil {
+			return nil, http.StatusInternalServerError, "failed to fetch center"
+		}
+		if center == nil {
+			return nil, http.StatusNotFound, "sender center not found"
+		}
+		// Admins may manage any hub but only speak for their own
+		uid, err := uuid.Parse(ctxutil.UserIDFrom(c))
+		if err != nil || center.ManagerID == nil || *center.ManagerID != uid {
+			return nil, http.StatusForbidden, "you do not manage this center"
+		}
+		if thread != nil && !isThreadParticipant(thread, id) {
+			return nil, http.StatusForbidden, "center is not a participant in this thread"
+		}
+		return center, 0, ""
+	}
+	if ctxutil.RoleFrom(c) == "ADMIN" {
+		return nil, 0, ""
+	}
+
+	managed, err := callerManagedCenterIDs(c, gdb)
+	if err != nil {
+		return nil, http.StatusInternalServerError, "failed to fetch centers"
+	}
+	var eligible []uuid.UUID
+	for _, id := range managed {
+		if thread == nil || isThreadParticipant(thread, id) {
+			eligible = append(eligible, id)
+		}
+	}
+	switch {
+	case len(eligible) == 0 && thread != nil:
+		return nil, http.StatusForbidden, "thread not found or access denied"
+	case len(eligible) == 0:
+		return nil, http.StatusForbidden, "no center found to send message from"
+	case len(eligible) > 1:
+		return nil, http.StatusBadRequest, "senderCenterId is required when you manage more than one center"
+	}
+	center, err := db.FindCenterByID(gdb, eligible[0])
+	if err != nil || center == nil {
+		return nil, http.StatusInternalServerError, "failed to fetch center"
+	}
+	return center, 0, ""
+}
+
+// isThreadParticipant reports whether a center takes part in a thread
+func isThreadParticipant(thread *db.MessageThread, centerID uuid.UUID) bool {
+	for _, p := range thread.Participants {
+		if p.ID == centerID {
+			return true
+		}
+	}
+	return false
+}
+
+// centerIDOf is the sender id stored for a message; nil for the platform
+func centerIDOf(center *db.CommunityCenter) *uuid.UUID {
+	if center == nil {
+		return nil
+	}
+	return &center.ID
+}
+
+// callerUserID is the user recorded as writing a message
+func callerUserID(c *gin.Context) *uuid.UUID {
+	uid, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		return nil
+	}
+	return &uid
+}
+
+// senderName is the name shown for a message's sender
+func senderName(msg *db.CenterMessage) string {
+	switch {
+	case msg.SenderID == nil:
+		return platformSenderName
+	case msg.Sender != nil:
+		return msg.Sender.Name
+	}
+	return ""
+}
+
+// senderType tells centers' messages from platform ones and from system
+// notes recording changes to the thread
+func senderType(msg *db.CenterMessage) string {
+	switch {
+	case msg.Kind == db.MessageKindSystem:
+		return "system"
+	case msg.SenderID == nil:
+		return "platform"
+	}
+	return "center"
+}
+
+// messageReceipts reports which other participants have read a message, and
+// whether all of them have
+func messageReceipts(msg *db.CenterMessage, thread *db.MessageThread, lastRead map[uuid.UUID]time.Time) (bool, []uuid.UUID) {
+	readBy := []uuid.UUID{}
+	recipients := 0
+	for _, p := range thread.Participants {
+		if msg.SenderID != nil && p.ID == *msg.SenderID {
+			continue
+		}
+		recipients++
+		if at, ok := lastRead[p.ID]; ok && !at.Before(msg.CreatedAt) {
+			readBy = append(readBy, p.ID)
+		}
+	}
+	return recipients > 0 && len(readBy) == recipients, readBy
+}
+
+// findChangeableMessage loads the message named by the :messageId parameter
+// from a thread, writing the error response and returning nil when it does
+// not exist or can no longer change: system notes and deleted messages
+func findChangeableMessage(c *gin.Context, gdb *gorm.DB, thread *db.MessageThread) *db.CenterMessage {
+	messageID, err := uuid.Parse(c.Param("messageId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid message id"})
+		return nil
+	}
+	message, err := db.FindMessageInThread(gdb, thread.ID, messageID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch message"})
+		return nil
+	}
+	if message == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "message not found"})
+		return nil
+	}
+	if message.Kind == db.MessageKindSystem {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "system notes cannot be changed"})
+		return nil
+	}
+	if message.DeletedAt != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "message already deleted"})
+		return nil
+	}
+	return message
+}
+
+// isMessageSender reports whether the caller speaks for a message's sender:
+// a manager of the sending center, or an admin for platform messages
+func isMessageSender(c *gin.Context, message *db.CenterMessage) bool {
+	if message.SenderID == nil {
+		return ctxutil.RoleFrom(c) == "ADMIN"
+	}
+	uid, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	return err == nil && message.Sender != nil && message.Sender.ManagerID != nil && *message.Sender.ManagerID == uid
+}
+
+// changedMessageJSON describes an edited or deleted message for clients to
+// update in place; read receipts are unaffected, so they are left out
+func changedMessageJSON(message *db.CenterMessage, attachments []gin.H) gin.H {
+	h := gin.H{
+		"id":         message.ID,
+		"threadId":   message.ThreadID,
+		"senderId":   message.SenderID,
+		"senderName": senderName(message),
+		"senderType": senderType(message),
+		"content":    message.Content,
+		"timestamp":  message.CreatedAt,
+		"editedAt":   message.EditedAt,
+		"deleted":    message.DeletedAt != nil,
+	}
+	if attachments != nil {
+		h["attachments"] = attachments
+	}
+	return h
+}
+
+// canAccessThread reports whether the caller may read a thread: admins, and
+// managers of any participating center
+func canAccessThread(c *gin.Context, thread *db.MessageThread) bool {
+	if ctxutil.RoleFrom(c) == "ADMIN" {
+		return true
+	}
+	uid, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	for _, participant := range thread.Participants {
+		if participant.ManagerID != nil && *participant.ManagerID == uid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestMessageAttachments(t *testing.T) {
+	f, thread, message := newThreadFixture(t, time.Now())
+	uploadID := f.seedUpload(t, "MESSAGE", message, "messages/mou.pdf", "application/pdf", []byte("%PDF-1.7"))
+
+	path := "/api/messages/thread-messages/" + thread + "/attachments/" + uploadID
+	if w := f.do(t, f.managerC, "GET", path, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a non-participant to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerB, "GET", path, nil); w.Code != http.StatusOK || w.Body.String() != "%PDF-1.7" {
+		t.Fatalf("expected a participant to download, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Thread events carry file links, so only participants may follow them
+	join := func(user, threadID string) int {
+		return f.do(t, user, "POST", "/api/realtime/join-thread", map[string]string{"threadId": threadID}).Code
+	}
+	if code := join(f.managerC, thread); code != http.StatusForbidden {
+		t.Fatalf("expected a non-participant not to follow the thread, got %d", code)
+	}
+	if code := join(f.managerC, "bogus"); code != http.StatusBadRequest {
+		t.Fatalf("expected an invalid thread id to be refused, got %d", code)
+	}
+	if code := join(f.managerB, thread); code != http.StatusNoContent {
+		t.Fatalf("expected a participant to follow the thread, got %d", code)
+	}
+
+	send := func(user string) *httptest.ResponseRecorder {
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		mw.WriteField("content", "Budget attached")
+		part, _ := mw.CreateFormFile("files", "budget.png")
+		part.Write(testPNG(t))
+		mw.Close()
+		req := httptest.NewRequest("POST", "/api/messages/thread-messages/"+thread, &buf)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		req.Header.Set("X-Test-User", user)
+		req.Header.Set("X-Test-Role", f.roleOf(user))
+		w := httptest.NewRecorder()
+		f.gdb.ServeHTTP(w, req)
+		return w
+	}
+	if w := send(f.managerC); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a non-participant's message to be refused, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := f.fake.writeCount("uploads"); n != 0 {
+		t.Fatalf("expected no files recorded for a refused message, got %d writes", n)
+	}
+	if w := send(f.managerA); w.Code != http.StatusCreated {
+		t.Fatalf("expected a participant to send a file, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := f.fake.writeCount("uploads"); n < 2 {
+		t.Fatalf("expected the file to be recorded and attached, got %d writes", n)
+	}
+}
+
+func TestThreadReadReceipts(t *testing.T) {
+	now := time.Now()
+	f, thread, _ := newThreadFixture(t, now.Add(-time.Minute))
+	f.fake.insert("thread_read_cursors", map[string]interface{}{
+		"id": uuid.NewString(), "thread_id": thread, "center_id": f.hubB, "last_read_at": now,
+		"read_by": f.managerB, "created_at": now, "updated_at": now,
+	})
+
+	path := "/api/messages/thread-messages/" + thread
+	if w := f.do(t, f.managerC, "POST", path+"/read", nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a non-participant to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerB, "POST", path+"/read", map[string]string{"centerId": f.hubA}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected reading as another hub to be refused, got %d", w.Code)
+	}
+	if n := f.fake.writeCount("thread_read_cursors"); n != 0 {
+		t.Fatalf("expected no cursor writes, got %d", n)
+	}
+	w := f.do(t, f.managerB, "POST", path+"/read", nil)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"centerId":"`+f.hubB+`"`) {
+		t.Fatalf("expected hub B's cursor to move, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Hub A sees its message read by hub B, the only other participant
+	w = f.do(t, f.managerA, "GET", path, nil)
+	var got struct {
+		Messages []struct {
+			Read   bool     `json:"read"`
+			ReadBy []string `json:"readBy"`
+		} `json:"messages"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if len(got.Messages) != 1 || !got.Messages[0].Read || len(got.Messages[0].ReadBy) != 1 || got.Messages[0].ReadBy[0] != f.hubB {
+		t.Fatalf("expected a read receipt from hub B, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestThreadMessagePages(t *testing.T) {
+	now := time.Now()
+	f, thread, _ := newThreadFixture(t, now.Add(-time.Minute))
+	f.fake.insert("center_messages", map[string]interface{}{
+		"id": uuid.NewString(), "thread_id": thread, "sender_id": f.hubB, "content": "Reply", "created_at": now,
+	})
+	path := "/api/messages/thread-messages/" + thread
+
+	type page struct {
+		Messages   []interface{} `json:"messages"`
+		HasMore    bool          `json:"hasMore"`
+		NextCursor *string       `json:"nextCursor"`
+	}
+	w := f.do(t, f.managerA, "GET", path+"?limit=1", nil)
+	var first page
+	_ = json.Unmarshal(w.Body.Bytes(), &first)
+	if w.Code != http.StatusOK || len(first.Messages) != 1 || !first.HasMore || first.NextCursor == nil {
+		t.Fatalf("expected one message and a cursor to older ones, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := f.do(t, f.managerA, "GET", path+"?before="+*first.NextCursor, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected the cursor to be accepted, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Catching up always hands back a cursor to poll from
+	w = f.do(t, f.managerA, "GET", path+"?after="+*first.NextCursor+"&limit=5", nil)
+	var newer page
+	_ = json.Unmarshal(w.Body.Bytes(), &newer)
+	if w.Code != http.StatusOK || newer.HasMore || newer.NextCursor == nil {
+		t.Fatalf("expected a final page with a cursor, got %d: %s", w.Code, w.Body.String())
+	}
+
+	for _, q := range []string{"?before=bogus", "?limit=0", "?before=" + *first.NextCursor + "&after=" + *first.NextCursor} {
+		if w := f.do(t, f.managerA, "GET", path+q, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", q, w.Code)
+		}
+	}
+}
+
+func TestMessageSenderCenter(t *testing.T) {
+	now := time.Now()
+	f, thread, _ := newThreadFixture(t, now)
+
+	// Manager A also runs a second hub taking part in the thread
+	secondHub := uuid.NewString()
+	f.fake.insert("community_centers", map[string]interface{}{
+		"id": secondHub, "name": "Annex", "location": "Gulu", "latitude": 2.7, "longitude": 32.3,
+		"verified": true, "added_by": f.managerA, "manager_id": f.managerA, "created_at": now, "updated_at": now,
+	})
+	f.fake.insert("message_thread_participants", map[string]interface{}{"message_thread_id": thread, "community_center_id": secondHub})
+
+	path := "/api/messages/thread-messages/" + thread
+	if w := f.do(t, f.managerA, "POST", path, map[string]string{"content": "Hello"}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a manager of two participants to name one, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := f.do(t, f.managerA, "POST", path, map[string]string{"content": "Hello", "senderCenterId": f.hubB}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected sending as another manager's hub to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerC, "POST", path, map[string]string{"content": "Hello", "senderCenterId": f.hubC}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a non-participant hub to be refused, got %d", w.Code)
+	}
+	if n := f.fake.writeCount("center_messages"); n != 0 {
+		t.Fatalf("expected no messages from refused senders, got %d writes", n)
+	}
+	w := f.do(t, f.managerA, "POST", path, map[string]string{"content": "Hello", "senderCenterId": secondHub})
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"senderType":"center"`) {
+		t.Fatalf("expected manager A to send as the named hub, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := f.do(t, f.managerB, "POST", path, map[string]string{"content": "Hello"}); w.Code != http.StatusCreated {
+		t.Fatalf("expected a manager of one participant to send as it, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Admins send as the platform, never as a hub someone else runs
+	w = f.do(t, f.admin, "POST", path, map[string]string{"content": "Maintenance tonight"})
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"senderId":null`) ||
+		!strings.Contains(w.Body.String(), `"senderName":"`+platformSenderName+`"`) {
+		t.Fatalf("expected a platform message, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := f.do(t, f.admin, "POST", path, map[string]string{"content": "Hello", "senderCenterId": f.hubB}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected an admin sending as a hub they do not run to be refused, got %d", w.Code)
+	}
+}
+
+func TestMessageEditsAndDeletes(t *testing.T) {
+	now := time.Now()
+	f, thread, message := newThreadFixture(t, now)
+	old := uuid.NewString()
+	f.fake.insert("center_messages", map[string]interface{}{
+		"id": old, "thread_id": thread, "sender_id": f.hubA, "content": "Draft", "created_at": now.Add(-time.Hour),
+	})
+	f.seedUpload(t, "MESSAGE", old, "messages/draft.pdf", "application/pdf", []byte("%PDF-1.7"))
+	f.fake.insert("message_revisions", map[string]interface{}{
+		"id": uuid.NewString(), "message_id": message, "content": "Attachd", "action": "EDIT",
+		"changed_by": f.managerA, "created_at": now,
+	})
+	path := "/api/messages/thread-messages/" + thread + "/messages/"
+
+	if w := f.do(t, f.managerB, "PATCH", path+message, map[string]string{"content": "Changed"}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected another hub's edit to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerA, "PATCH", path+old, map[string]string{"content": "Final"}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected an edit after the window to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerB, "DELETE", path+message, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected another hub's delete to be refused, got %d", w.Code)
+	}
+	if n := f.fake.writeCount("message_revisions"); n != 0 {
+		t.Fatalf("expected no revisions for refused changes, got %d writes", n)
+	}
+
+	w := f.do(t, f.managerA, "PATCH", path+message, map[string]string{"content": "Attached, signed"})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"content":"Attached, signed"`) {
+		t.Fatalf("expected the sender to edit, got %d: %s", w.Code, w.Body.String())
+	}
+	w = f.do(t, f.managerA, "DELETE", path+old, nil)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"deleted":true`) || !strings.Contains(w.Body.String(), `"content":""`) {
+		t.Fatalf("expected the sender to delete any time, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := f.fake.writeCount("message_revisions"); n != 2 {
+		t.Fatalf("expected a revision per change, got %d writes", n)
+	}
+	if n := f.fake.writeCount("uploads"); n != 1 {
+		t.Fatalf("expected the deleted message's files to be removed, got %d writes", n)
+	}
+
+	// Files left on a deleted message are not handed out
+	gone := uuid.NewString()
+	f.fake.insert("center_messages", map[string]interface{}{
+		"id": gone, "thread_id": thread, "sender_id": f.hubA, "content": "", "created_at": now, "deleted_at": now,
+	})
+	uploadID := f.seedUpload(t, "MESSAGE", gone, "messages/gone.pdf", "application/pdf", []byte("%PDF-1.7"))
+	if w := f.do(t, f.managerB, "GET", "/api/uploads/"+uploadID, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected a deleted message's file to 404, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerB, "GET", "/api/uploads?entityType=MESSAGE&entityId="+gone, nil); w.Code != http.StatusNotFound {
+		t.Fatalf("expected a deleted message's files not to be listed, got %d", w.Code)
+	}
+
+	w = f.do(t, f.admin, "GET", path+message+"/revisions", nil)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"content":"Attachd"`) {
+		t.Fatalf("expected admins to see earlier contents, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMessageEditConflict(t *testing.T) {
+	now := time.Now()
+	f, thread, message := newThreadFixture(t, now)
+	path := "/api/messages/thread-messages/" + thread + "/messages/" + message
+
+	// The message is deleted while the sender edits it
+	f.fake.race("center_messages", message, map[string]interface{}{"content": "", "deleted_at": now})
+	if w := f.do(t, f.managerA, "PATCH", path, map[string]string{"content": "Attached, signed"}); w.Code != http.StatusConflict {
+		t.Fatalf("expected an edit of a deleted message to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := f.fake.row("center_messages", message)["content"]; got != "" {
+		t.Fatalf("expected the tombstone to stay blank, got %q", got)
+	}
+
+	// Another edit lands first, so ours would record the wrong earlier text
+	row := f.fake.row("center_messages", message)
+	row["content"], row["deleted_at"] = "Attached", nil
+	f.fake.race("center_messages", message, map[string]interface{}{"content": "Attached, signed"})
+	if w := f.do(t, f.managerA, "DELETE", path, nil); w.Code != http.StatusConflict {
+		t.Fatalf("expected a delete of a changed message to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := f.fake.writeCount("message_revisions"); n != 0 {
+		t.Fatalf("expected no revisions for refused changes, got %d writes", n)
+	}
+}
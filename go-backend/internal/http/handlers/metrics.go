@@ -0,0 +1,615 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/metrics"
+)
+
+type metricTypeRequest struct {
+	Key         string   `json:"key" binding:"required,min=2,max=100"`
+	Name        string   `json:"name" binding:"required,min=2,max=255"`
+	Description string   `json:"description"`
+	ValueType   string   `json:"valueType" binding:"required"`
+	Aggregation string   `json:"aggregation"` // Defaults to SUM, or LATEST for bands
+	Unit        string   `json:"unit" binding:"max=50"`
+	Bands       []string `json:"bands"`
+	Active      *bool    `json:"active"`
+}
+
+type metricEntryRequest struct {
+	MetricTypeID   string   `json:"metricTypeId" binding:"required"`
+	HubID          string   `json:"hubId" binding:"required"`
+	EntrepreneurID string   `json:"entrepreneurId"` // Required for managers; entrepreneurs report for themselves
+	Value          *float64 `json:"value"`
+	Band           *string  `json:"band"`
+	PeriodStart    string   `json:"periodStart" binding:"required"` // YYYY-MM-DD
+	PeriodEnd      string   `json:"periodEnd" binding:"required"`   // YYYY-MM-DD
+	Notes          *string  `json:"notes"`
+}
+
+type metricVerificationRequest struct {
+	Status string  `json:"status" binding:"required"` // VERIFIED or REJECTED
+	Note   *string `json:"note"`
+}
+
+// GET /api/metric-types - List metric types
+func GetMetricTypes(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	types, err := db.ListMetricTypes(gdb, c.Query("includeInactive") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch metric types"})
+		return
+	}
+
+	transformed := make([]gin.H, len(types))
+	for i := range types {
+		transformed[i] = transformMetricType(&types[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"metricTypes": transformed, "total": len(transformed)})
+}
+
+// POST /api/metric-types - Define a metric type (ADMIN only)
+func CreateMetricType(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req metricTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	existing, err := db.FindMetricTypeByKey(gdb, strings.TrimSpace(req.Key))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch metric type"})
+		return
+	}
+	if existing != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "metric key already exists"})
+		return
+	}
+
+	metricType := db.MetricType{Active: true}
+	if status, msg := applyMetricTypeRequest(&metricType, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+	if err := db.SaveMetricType(gdb, &metricType); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create metric type"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":    "Metric type created successfully",
+		"metricType": transformMetricType(&metricType),
+	})
+}
+
+// PUT /api/metric-types/:id - Update a metric type (ADMIN only)
+func UpdateMetricType(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	metricTypeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid metric type id"})
+		return
+	}
+	metricType, err := db.FindMetricTypeByID(gdb, metricTypeID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch metric type"})
+		return
+	}
+	if metricType == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "metric type not found"})
+		return
+	}
+
+	var req metricTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	// Existing entries are keyed to the type's meaning, so it cannot be re-keyed
+	// or switch between numeric and banded values
+	if strings.TrimSpace(req.Key) != metricType.Key || db.MetricValueType(strings.ToUpper(req.ValueType)) != metricType.ValueType {
+		c.JSON(http.StatusConflict, gin.H{"error": "key and valueType cannot be changed"})
+		return
+	}
+
+	if status, msg := applyMetricTypeRequest(metricType, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+	if err := db.SaveMetricType(gdb, metricType); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update metric type"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Metric type updated successfully",
+		"metricType": transformMetricType(metricType),
+	})
+}
+
+// POST /api/metrics - Report a metric value (the entrepreneur, or a manager of the hub)
+func SubmitMetricEntry(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req metricEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	metricTypeID, err := uuid.Parse(req.MetricTypeID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid metric type id"})
+		return
+	}
+	hubID, err := uuid.Parse(req.HubID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+	start, err := time.Parse("2006-01-02", req.PeriodStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid periodStart, expected YYYY-MM-DD"})
+		return
+	}
+	end, err := time.Parse("2006-01-02", req.PeriodEnd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid periodEnd, expected YYYY-MM-DD"})
+		return
+	}
+
+	metricType, err := db.FindMetricTypeByID(gdb, metricTypeID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch metric type"})
+		return
+	}
+	if metricType == nil || !metricType.Active {
+		c.JSON(http.StatusNotFound, gin.H{"error": "metric type not found"})
+		return
+	}
+	if err := metrics.CheckEntry(metricType, req.Value, req.Band, start, end); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	submitter, err := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	entry := db.MetricEntry{
+		MetricTypeID: metricType.ID,
+		HubID:        hubID,
+		Value:        req.Value,
+		Band:         req.Band,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Notes:        req.Notes,
+		Status:       db.MetricPending,
+		SubmittedBy:  submitter,
+	}
+
+	switch ctxutil.RoleFrom(c) {
+	case string(db.RoleEntrepreneur):
+		entrepreneur := currentEntrepreneur(c, gdb)
+		if entrepreneur == nil {
+			return
+		}
+		entry.EntrepreneurID = entrepreneur.ID
+	case string(db.RoleAdmin), string(db.RoleCenterManager):
+		ok, err := canManageCenterID(c, gdb, hubID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+			return
+		}
+		if !ok {
+			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+			return
+		}
+		entrepreneurID, err := uuid.Parse(req.EntrepreneurID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entrepreneur id"})
+			return
+		}
+		// The hub vouches for figures its own staff record
+		now := time.Now()
+		entry.EntrepreneurID = entrepreneurID
+		entry.Status = db.MetricVerified
+		entry.VerifiedBy = &submitter
+		entry.VerifiedAt = &now
+	default:
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return
+	}
+
+	enrolled, err := db.HasHubEnrollment(gdb, hubID, entry.EntrepreneurID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch enrollment"})
+		return
+	}
+	if !enrolled {
+		c.JSON(http.StatusConflict, gin.H{"error": "entrepreneur is not enrolled at this hub"})
+		return
+	}
+
+	if err := db.CreateMetricEntry(gdb, &entry); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record metric"})
+		return
+	}
+	entry.MetricType = *metricType
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Metric recorded successfully",
+		"entry":   transformMetricEntry(&entry),
+	})
+}
+
+// GET /api/metrics/entrepreneur/:id - List an entrepreneur's metric entries
+func GetEntrepreneurMetrics(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	entrepreneurID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entrepreneur id"})
+		return
+	}
+
+	// Managers only see what was reported to the hubs they manage
+	var hubIDs []uuid.UUID
+	switch ctxutil.RoleFrom(c) {
+	case string(db.RoleAdmin):
+	case string(db.RoleCenterManager):
+		if hubIDs, err = callerManagedCenterIDs(c, gdb); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hubs"})
+			return
+		}
+		if hubIDs == nil {
+			hubIDs = []uuid.UUID{}
+		}
+	case string(db.RoleEntrepreneur):
+		owner, err := isEntrepreneurOwner(c, gdb, entrepreneurID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch entrepreneur"})
+			return
+		}
+		if !owner {
+			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+			return
+		}
+	default:
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return
+	}
+
+	entries, err := db.ListEntrepreneurMetricEntries(gdb, entrepreneurID, hubIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch metrics"})
+		return
+	}
+
+	transformed := make([]gin.H, len(entries))
+	for i := range entries {
+		transformed[i] = transformMetricEntry(&entries[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": transformed, "total": len(transformed)})
+}
+
+// GET /api/metrics/hub/:id/pending - List entries awaiting the hub's verification
+func GetPendingHubMetrics(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	hubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+	ok, err := canManageCenterID(c, gdb, hubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return
+	}
+
+	entries, err := db.ListPendingMetricEntries(gdb, hubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch metrics"})
+		return
+	}
+
+	transformed := make([]gin.H, len(entries))
+	for i := range entries {
+		transformed[i] = transformMetricEntry(&entries[i])
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": transformed, "total": len(transformed)})
+}
+
+// PATCH /api/metrics/:id/verify - Verify or reject a pending entry (the hub's manager)
+func VerifyMetricEntry(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	entryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid metric entry id"})
+		return
+	}
+	entry, err := db.FindMetricEntryByID(gdb, entryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch metric entry"})
+		return
+	}
+	if entry == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "metric entry not found"})
+		return
+	}
+
+	ok, err := canManageCenterID(c, gdb, entry.HubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+		return
+	}
+
+	var req metricVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	status := db.MetricEntryStatus(strings.ToUpper(req.Status))
+	if status != db.MetricVerified && status != db.MetricRejected {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be VERIFIED or REJECTED"})
+		return
+	}
+	if entry.Status != db.MetricPending {
+		c.JSON(http.StatusConflict, gin.H{"error": db.ErrMetricEntryReviewed.Error()})
+		return
+	}
+
+	verifier, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	if err := db.ReviewMetricEntry(gdb, entry, status, &verifier, req.Note); err != nil {
+		if errors.Is(err, db.ErrMetricEntryReviewed) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update metric entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Metric entry reviewed successfully",
+		"entry":   transformMetricEntry(entry),
+	})
+}
+
+// GET /api/metrics/summary/hub/:id - Aggregate a hub's verified metrics
+func GetHubMetricsSummary(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	hubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+	filters, ok := metricFiltersFromQuery(c)
+	if !ok {
+		return
+	}
+	filters.HubID = &hubID
+	if filters.IncludeUnverified {
+		if filters.IncludeUnverified, err = canManageCenterID(c, gdb, hubID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+			return
+		}
+	}
+
+	respondMetricsSummary(c, gdb, filters, gin.H{"hubId": hubID})
+}
+
+// GET /api/metrics/summary/program/:id - Aggregate verified metrics of a program's participants
+func GetProgramMetricsSummary(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	program := findProgramFromParam(c, gdb)
+	if program == nil {
+		return
+	}
+	filters, ok := metricFiltersFromQuery(c)
+	if !ok {
+		return
+	}
+	filters.ProgramID = &program.ID
+	if filters.IncludeUnverified {
+		filters.IncludeUnverified = canManageCenter(c, &program.Hub)
+	}
+
+	respondMetricsSummary(c, gdb, filters, gin.H{"programId": program.ID, "hubId": program.HubID})
+}
+
+// GET /api/metrics/summary/network - Aggregate verified metrics across every hub
+func GetNetworkMetricsSummary(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	filters, ok := metricFiltersFromQuery(c)
+	if !ok {
+		return
+	}
+	filters.IncludeUnverified = filters.IncludeUnverified && ctxutil.RoleFrom(c) == string(db.RoleAdmin)
+
+	respondMetricsSummary(c, gdb, filters, gin.H{})
+}
+
+// metricFiltersFromQuery reads from, to and includeUnverified, writing the
+// error response and returning false when they are malformed. Callers decide
+// whether the caller may actually see unverified entries.
+func metricFiltersFromQuery(c *gin.Context) (db.MetricEntryFilters, bool) {
+	var filters db.MetricEntryFilters
+	for name, dst := range map[string]**time.Time{"from": &filters.From, "to": &filters.To} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name + ", expected YYYY-MM-DD"})
+			return filters, false
+		}
+		*dst = &t
+	}
+	filters.IncludeUnverified = c.Query("includeUnverified") == "true"
+	return filters, true
+}
+
+func respondMetricsSummary(c *gin.Context, gdb *gorm.DB, filters db.MetricEntryFilters, scope gin.H) {
+	types, err := db.ListMetricTypes(gdb, false)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch metric types"})
+		return
+	}
+	entries, err := db.ListMetricEntries(gdb, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch metrics"})
+		return
+	}
+
+	scope["from"] = filters.From
+	scope["to"] = filters.To
+	scope["includesUnverified"] = filters.IncludeUnverified
+	scope["metrics"] = metrics.Summarize(types, entries)
+	c.JSON(http.StatusOK, scope)
+}
+
+// applyMetricTypeRequest copies a validated request onto the metric type,
+// returning a non-zero status and message when it is rejected
+func applyMetricTypeRequest(t *db.MetricType, req *metricTypeRequest) (int, string) {
+	t.Key = strings.TrimSpace(req.Key)
+	t.Name = strings.TrimSpace(req.Name)
+	t.Description = req.Description
+	t.ValueType = db.MetricValueType(strings.ToUpper(req.ValueType))
+	t.Unit = req.Unit
+	t.Bands = db.StringArray(req.Bands)
+	t.Aggregation = db.MetricAggregation(strings.ToUpper(req.Aggregation))
+	if t.Aggregation == "" {
+		t.Aggregation = db.MetricSum
+		if t.ValueType == db.MetricBand {
+			t.Aggregation = db.MetricLatest
+		}
+	}
+	if req.Active != nil {
+		t.Active = *req.Active
+	}
+	if err := metrics.CheckType(t); err != nil {
+		return http.StatusBadRequest, err.Error()
+	}
+	return 0, ""
+}
+
+func transformMetricType(t *db.MetricType) gin.H {
+	return gin.H{
+		"id":          t.ID,
+		"key":         t.Key,
+		"name":        t.Name,
+		"description": t.Description,
+		"valueType":   t.ValueType,
+		"aggregation": t.Aggregation,
+		"unit":        t.Unit,
+		"bands":       t.Bands,
+		"active":      t.Active,
+		"createdAt":   t.CreatedAt,
+		"updatedAt":   t.UpdatedAt,
+	}
+}
+
+func transformMetricEntry(e *db.MetricEntry) gin.H {
+	out := gin.H{
+		"id":               e.ID,
+		"metricTypeId":     e.MetricTypeID,
+		"entrepreneurId":   e.EntrepreneurID,
+		"hubId":            e.HubID,
+		"value":            e.Value,
+		"band":             e.Band,
+		"periodStart":      e.PeriodStart.Format("2006-01-02"),
+		"periodEnd":        e.PeriodEnd.Format("2006-01-02"),
+		"notes":            e.Notes,
+		"status":           e.Status,
+		"submittedBy":      e.SubmittedBy,
+		"verifiedBy":       e.VerifiedBy,
+		"verifiedAt":       e.VerifiedAt,
+		"verificationNote": e.VerificationNote,
+		"createdAt":        e.CreatedAt,
+	}
+	if e.MetricType.ID != uuid.Nil {
+		out["metricKey"] = e.MetricType.Key
+		out["metricName"] = e.MetricType.Name
+		out["unit"] = e.MetricType.Unit
+	}
+	if e.Hub.ID != uuid.Nil {
+		out["hubName"] = e.Hub.Name
+	}
+	if e.Entrepreneur.ID != uuid.Nil {
+		out["businessName"] = e.Entrepreneur.BusinessName
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestMetricVerificationConflict(t *testing.T) {
+	f := newHubFixture(t)
+	now := time.Now()
+	metricType, entry := uuid.NewString(), uuid.NewString()
+	f.fake.insert("metric_types", map[string]interface{}{
+		"id": metricType, "key": "jobs_created", "name": "Jobs created", "value_type": "INTEGER",
+		"aggregation": "SUM", "active": true, "created_at": now, "updated_at": now,
+	})
+	f.fake.insert("metric_entries", map[string]interface{}{
+		"id": entry, "metric_type_id": metricType, "entrepreneur_id": f.entrepreneur, "hub_id": f.hubA,
+		"value": 4.0, "period_start": now.AddDate(0, -1, 0), "period_end": now, "status": "PENDING",
+		"submitted_by": f.founder, "created_at": now, "updated_at": now,
+	})
+
+	// Another manager rejects the entry while this one verifies it
+	f.fake.race("metric_entries", entry, map[string]interface{}{"status": "REJECTED"})
+	w := f.do(t, f.managerA, "PATCH", "/api/metrics/"+entry+"/verify", gin.H{"status": "VERIFIED"})
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected a stale verification to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := f.fake.row("metric_entries", entry)["status"]; got != "REJECTED" {
+		t.Fatalf("expected the rejection to stand, got %v", got)
+	}
+}
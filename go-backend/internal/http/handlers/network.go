@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/network"
+)
+
+// GET /api/network/graph - Hub ecosystem as nodes and edges (JSON, GraphML or GEXF)
+func GetNetworkGraph(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	graph, err := network.Load(gdb, c.Query("verifiedOnly") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build network graph"})
+		return
+	}
+
+	// Gephi exports
+	switch strings.ToLower(c.Query("format")) {
+	case "graphml":
+		c.Header("Content-Disposition", `attachment; filename="hub-network.graphml"`)
+		c.Header("Content-Type", "application/graphml+xml")
+		c.Status(http.StatusOK)
+		if err := graph.WriteGraphML(c.Writer); err != nil {
+			c.Error(err)
+		}
+		return
+	case "gexf":
+		c.Header("Content-Disposition", `attachment; filename="hub-network.gexf"`)
+		c.Header("Content-Type", "application/gexf+xml")
+		c.Status(http.StatusOK)
+		if err := graph.WriteGEXF(c.Writer); err != nil {
+			c.Error(err)
+		}
+		return
+	case "", "json":
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid format, use json, graphml or gexf"})
+		return
+	}
+
+	degree := graph.Degree()
+	nodes := make([]gin.H, len(graph.Nodes))
+	for i, n := range graph.Nodes {
+		nodes[i] = gin.H{
+			"id":          n.ID,
+			"name":        n.Name,
+			"location":    n.Location,
+			"coordinates": gin.H{"lat": n.Latitude, "lng": n.Longitude},
+			"verified":    n.Verified,
+			"degree":      degree[n.ID],
+		}
+	}
+
+	edges := make([]gin.H, len(graph.Edges))
+	for i, e := range graph.Edges {
+		edges[i] = gin.H{
+			"id":                e.ID,
+			"source":            e.Source,
+			"target":            e.Target,
+			"collaborationType": e.CollaborationType,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"nodes": nodes, "edges": edges})
+}
+
+// GET /api/network/stats - Degree, components, isolated hubs, bridges and shortest path
+func GetNetworkStats(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	// Optional shortest path endpoints
+	var fromID, toID uuid.UUID
+	fromStr, toStr := c.Query("from"), c.Query("to")
+	if (fromStr == "") != (toStr == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "both from and to are required for shortest path"})
+		return
+	}
+	if fromStr != "" {
+		var err error
+		if fromID, err = uuid.Parse(fromStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from hub id"})
+			return
+		}
+		if toID, err = uuid.Parse(toStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to hub id"})
+			return
+		}
+	}
+
+	graph, err := network.Load(gdb, c.Query("verifiedOnly") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to build network graph"})
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(graph.Nodes))
+	for _, n := range graph.Nodes {
+		names[n.ID] = n.Name
+	}
+	hubRef := func(id uuid.UUID) gin.H {
+		return gin.H{"id": id, "name": names[id]}
+	}
+
+	degree := graph.Degree()
+	degrees := make([]gin.H, len(graph.Nodes))
+	maxDegree := 0
+	for i, n := range graph.Nodes {
+		degrees[i] = gin.H{"id": n.ID, "name": n.Name, "degree": degree[n.ID]}
+		if degree[n.ID] > maxDegree {
+			maxDegree = degree[n.ID]
+		}
+	}
+
+	components := graph.Components()
+	transformedComponents := make([]gin.H, len(components))
+	for i, comp := range components {
+		transformedComponents[i] = gin.H{"size": len(comp), "hubs": comp}
+	}
+
+	isolated := graph.Isolated()
+	transformedIsolated := make([]gin.H, len(isolated))
+	for i, id := range isolated {
+		transformedIsolated[i] = hubRef(id)
+	}
+
+	bridges := graph.Bridges()
+	transformedBridges := make([]gin.H, len(bridges))
+	for i, b := range bridges {
+		transformedBridges[i] = gin.H{
+			"connectionId": b.ID,
+			"source":       hubRef(b.Source),
+			"target":       hubRef(b.Target),
+		}
+	}
+
+	stats := gin.H{
+		"nodeCount":      len(graph.Nodes),
+		"edgeCount":      len(graph.Edges),
+		"density":        graph.Density(),
+		"maxDegree":      maxDegree,
+		"degrees":        degrees,
+		"componentCount": len(components),
+		"components":     transformedComponents,
+		"isolated":       transformedIsolated,
+		"bridges":        transformedBridges,
+	}
+
+	if fromStr != "" {
+		if !graph.Has(fromID) || !graph.Has(toID) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "hub not found"})
+			return
+		}
+		path := graph.ShortestPath(fromID, toID)
+		hops := make([]gin.H, len(path))
+		for i, id := range path {
+			hops[i] = hubRef(id)
+		}
+		shortest := gin.H{"from": fromID, "to": toID, "reachable": path != nil, "path": hops, "length": 0}
+		if path != nil {
+			shortest["length"] = len(path) - 1
+		}
+		stats["shortestPath"] = shortest
+	}
+
+	c.JSON(http.StatusOK, gin.H{"stats": stats})
+}
@@ -0,0 +1,486 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/directory"
+	"communitycentresplatform/go-backend/internal/portfolio"
+)
+
+type portfolioItemRequest struct {
+	EntrepreneurID *string  `json:"entrepreneurId"` // Admins only; others post to their own profile
+	Kind           *string  `json:"kind"`
+	Title          *string  `json:"title"`
+	Description    *string  `json:"description"`
+	Price          *float64 `json:"price"`
+	ClearPrice     bool     `json:"clearPrice"`
+	Currency       *string  `json:"currency"`
+	Visibility     *string  `json:"visibility"`
+	Position       *int     `json:"position"`
+}
+
+// applyPortfolioItemRequest validates req and copies it onto item, returning
+// an error message for the client when the request is invalid
+func applyPortfolioItemRequest(item *db.PortfolioItem, req portfolioItemRequest) string {
+	if req.Kind != nil {
+		kind, err := portfolio.ParseKind(*req.Kind)
+		if err != nil {
+			return err.Error()
+		}
+		item.Kind = kind
+	}
+	if req.Title != nil {
+		item.Title = strings.TrimSpace(*req.Title)
+	}
+	if item.Title == "" || len(item.Title) > 255 {
+		return "title is required and must be at most 255 characters"
+	}
+	if req.Description != nil {
+		item.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.ClearPrice {
+		item.Price = nil
+	} else if req.Price != nil {
+		if *req.Price < 0 {
+			return "price cannot be negative"
+		}
+		item.Price = req.Price
+	}
+	if req.Currency != nil {
+		currency, err := portfolio.NormalizeCurrency(*req.Currency)
+		if err != nil {
+			return err.Error()
+		}
+		item.Currency = currency
+	}
+	if req.Visibility != nil {
+		v, err := directory.ParseVisibility(*req.Visibility)
+		if err != nil {
+			return err.Error()
+		}
+		item.Visibility = v
+	}
+	if req.Position != nil {
+		if *req.Position < 0 {
+			return "position cannot be negative"
+		}
+		item.Position = *req.Position
+	}
+	return ""
+}
+
+// GET /api/portfolio/entrepreneur/:entrepreneurId - List an entrepreneur's portfolio (public; signed-in users see more)
+func GetEntrepreneurPortfolio(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	entrepreneurID, err := uuid.Parse(c.Param("entrepreneurId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entrepreneur id"})
+		return
+	}
+	var entrepreneur db.Entrepreneur
+	if err := gdb.First(&entrepreneur, "id = ?", entrepreneurID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entrepreneur not found"})
+		return
+	}
+	audience, err := entrepreneurAudience(c, gdb, &entrepreneur)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	if !directory.Listed(&entrepreneur, audience) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "entrepreneur not found"})
+		return
+	}
+
+	items, err := visiblePortfolio(gdb, &entrepreneur, audience)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch portfolio"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items, "total": len(items)})
+}
+
+// POST /api/portfolio - Add a portfolio item (the entrepreneur, or ADMIN on their behalf)
+func CreatePortfolioItem(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req portfolioItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+
+	var entrepreneur *db.Entrepreneur
+	if req.EntrepreneurID != nil && ctxutil.RoleFrom(c) == string(db.RoleAdmin) {
+		id, err := uuid.Parse(*req.EntrepreneurID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entrepreneur id"})
+			return
+		}
+		var e db.Entrepreneur
+		if err := gdb.First(&e, "id = ?", id).Error; err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "entrepreneur not found"})
+			return
+		}
+		entrepreneur = &e
+	} else if entrepreneur = currentEntrepreneur(c, gdb); entrepreneur == nil {
+		return
+	}
+
+	creatorID, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	item := db.PortfolioItem{
+		EntrepreneurID: entrepreneur.ID,
+		Kind:           db.PortfolioProduct,
+		Currency:       "UGX",
+		Visibility:     db.VisibilityPublic,
+		CreatedBy:      creatorID,
+	}
+	if msg := applyPortfolioItemRequest(&item, req); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if err := db.SavePortfolioItem(gdb, &item); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create portfolio item"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Portfolio item created successfully",
+		"item":    transformPortfolioItem(&item),
+	})
+}
+
+// GET /api/portfolio/:id - Get a portfolio item (public; signed-in users see more)
+func GetPortfolioItem(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	item := findPortfolioItemForCaller(c, gdb, false)
+	if item == nil {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"item": transformPortfolioItem(item)})
+}
+
+// PUT /api/portfolio/:id - Update a portfolio item (the entrepreneur or ADMIN)
+func UpdatePortfolioItem(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	item := findPortfolioItemForCaller(c, gdb, true)
+	if item == nil {
+		return
+	}
+
+	var req portfolioItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input", "details": err.Error()})
+		return
+	}
+	if msg := applyPortfolioItemRequest(item, req); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if err := db.SavePortfolioItem(gdb, item); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update portfolio item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Portfolio item updated successfully",
+		"item":    transformPortfolioItem(item),
+	})
+}
+
+// DELETE /api/portfolio/:id - Delete a portfolio item and its files (the entrepreneur or ADMIN)
+func DeletePortfolioItem(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	item := findPortfolioItemForCaller(c, gdb, true)
+	if item == nil {
+		return
+	}
+	if err := db.DeletePortfolioItem(gdb, item.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete portfolio item"})
+		return
+	}
+	for i := range item.Media {
+		discardStored(c, ctxutil.StorageFrom(c), portfolioMediaKeys(&item.Media[i])...)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Portfolio item deleted successfully"})
+}
+
+// POST /api/portfolio/:id/media - Upload a file to a portfolio item (multipart "file"; the entrepreneur or ADMIN)
+func UploadPortfolioMedia(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+	store := ctxutil.StorageFrom(c)
+	if store == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "storage unavailable"})
+		return
+	}
+
+	item := findPortfolioItemForCaller(c, gdb, true)
+	if item == nil {
+		return
+	}
+	if len(item.Media) >= portfolio.MaxMediaPerItem {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("an item can have at most %d files", portfolio.MaxMediaPerItem)})
+		return
+	}
+
+	stored := receiveUpload(c, store, "portfolio/"+item.ID.String())
+	if stored == nil {
+		return
+	}
+
+	uploaderID, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	media := db.PortfolioMedia{
+		ItemID:       item.ID,
+		Kind:         stored.Kind,
+		StorageKey:   stored.Key,
+		ThumbnailKey: stored.ThumbnailKey,
+		FileName:     stored.FileName,
+		ContentType:  stored.ContentType,
+		SizeBytes:    stored.Size,
+		Position:     len(item.Media),
+		UploadedBy:   uploaderID,
+	}
+	if caption := strings.TrimSpace(c.PostForm("caption")); caption != "" {
+		media.Caption = &caption
+	}
+	if err := db.CreatePortfolioMedia(gdb, &media); err != nil {
+		discardStored(c, store, stored.keys()...)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save file"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "File uploaded successfully",
+		"media":   transformPortfolioMedia(&media),
+	})
+}
+
+// GET /api/portfolio/:id/media/:mediaId - Download a portfolio file, or its preview with ?variant=thumbnail (public; signed-in users see more)
+func GetPortfolioMedia(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+	store := ctxutil.StorageFrom(c)
+	if store == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "storage unavailable"})
+		return
+	}
+
+	item := findPortfolioItemForCaller(c, gdb, false)
+	if item == nil {
+		return
+	}
+	media := findPortfolioMediaFromParam(c, gdb, item)
+	if media == nil {
+		return
+	}
+
+	key, contentType := media.StorageKey, media.ContentType
+	if c.Query("variant") == "thumbnail" {
+		if media.ThumbnailKey == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "file has no thumbnail"})
+			return
+		}
+		key, contentType = *media.ThumbnailKey, ""
+	}
+	serveStored(c, store, key, contentType, media.FileName)
+}
+
+// DELETE /api/portfolio/:id/media/:mediaId - Remove a portfolio file (the entrepreneur or ADMIN)
+func DeletePortfolioMedia(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	item := findPortfolioItemForCaller(c, gdb, true)
+	if item == nil {
+		return
+	}
+	media := findPortfolioMediaFromParam(c, gdb, item)
+	if media == nil {
+		return
+	}
+	if err := db.DeletePortfolioMedia(gdb, media.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete file"})
+		return
+	}
+	discardStored(c, ctxutil.StorageFrom(c), portfolioMediaKeys(media)...)
+
+	c.JSON(http.StatusOK, gin.H{"message": "File deleted successfully"})
+}
+
+// findPortfolioItemForCaller loads the item named by the :id parameter and
+// checks the caller may see it, or edit it when edit is set. Items the caller
+// may not see are reported as missing. It writes the error response and
+// returns nil when the caller cannot proceed.
+func findPortfolioItemForCaller(c *gin.Context, gdb *gorm.DB, edit bool) *db.PortfolioItem {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid portfolio item id"})
+		return nil
+	}
+	item, err := db.FindPortfolioItemByID(gdb, itemID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch portfolio item"})
+		return nil
+	}
+	if item == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "portfolio item not found"})
+		return nil
+	}
+
+	audience, err := entrepreneurAudience(c, gdb, &item.Entrepreneur)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return nil
+	}
+	if !portfolioItemVisible(&item.Entrepreneur, item, audience) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "portfolio item not found"})
+		return nil
+	}
+	if edit && audience < directory.AudienceOwner {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the entrepreneur can change their portfolio"})
+		return nil
+	}
+	return item
+}
+
+// findPortfolioMediaFromParam loads the :mediaId file of item, writing the
+// error response and returning nil when it cannot
+func findPortfolioMediaFromParam(c *gin.Context, gdb *gorm.DB, item *db.PortfolioItem) *db.PortfolioMedia {
+	mediaID, err := uuid.Parse(c.Param("mediaId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid media id"})
+		return nil
+	}
+	media, err := db.FindPortfolioMedia(gdb, item.ID, mediaID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch file"})
+		return nil
+	}
+	if media == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return nil
+	}
+	return media
+}
+
+// portfolioItemVisible applies the item's own visibility on top of whether
+// the entrepreneur's profile is listed for the audience
+func portfolioItemVisible(e *db.Entrepreneur, item *db.PortfolioItem, audience directory.Audience) bool {
+	return directory.Listed(e, audience) && directory.CanSee(item.Visibility, audience)
+}
+
+// visiblePortfolio renders the entrepreneur's items the audience may see
+func visiblePortfolio(gdb *gorm.DB, e *db.Entrepreneur, audience directory.Audience) ([]gin.H, error) {
+	out := []gin.H{}
+	if !directory.Listed(e, audience) {
+		return out, nil
+	}
+	var visibilities []db.Visibility
+	switch {
+	case audience >= directory.AudienceHub:
+		visibilities = nil
+	case audience == directory.AudienceMember:
+		visibilities = []db.Visibility{db.VisibilityPublic, db.VisibilityNetwork}
+	default:
+		visibilities = []db.Visibility{db.VisibilityPublic}
+	}
+	items, err := db.ListPortfolioItems(gdb, e.ID, visibilities)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		out = append(out, transformPortfolioItem(&items[i]))
+	}
+	return out, nil
+}
+
+func portfolioMediaKeys(m *db.PortfolioMedia) []string {
+	keys := []string{m.StorageKey}
+	if m.ThumbnailKey != nil {
+		keys = append(keys, *m.ThumbnailKey)
+	}
+	return keys
+}
+
+func transformPortfolioItem(item *db.PortfolioItem) gin.H {
+	media := make([]gin.H, len(item.Media))
+	for i := range item.Media {
+		media[i] = transformPortfolioMedia(&item.Media[i])
+	}
+	return gin.H{
+		"id":             item.ID,
+		"entrepreneurId": item.EntrepreneurID,
+		"kind":           item.Kind,
+		"title":          item.Title,
+		"description":    item.Description,
+		"price":          item.Price,
+		"currency":       item.Currency,
+		"visibility":     item.Visibility,
+		"position":       item.Position,
+		"media":          media,
+		"createdAt":      item.CreatedAt,
+		"updatedAt":      item.UpdatedAt,
+	}
+}
+
+func transformPortfolioMedia(m *db.PortfolioMedia) gin.H {
+	url := fmt.Sprintf("/api/portfolio/%s/media/%s", m.ItemID, m.ID)
+	out := gin.H{
+		"id":          m.ID,
+		"kind":        m.Kind,
+		"fileName":    m.FileName,
+		"contentType": m.ContentType,
+		"size":        m.SizeBytes,
+		"caption":     m.Caption,
+		"position":    m.Position,
+		"url":         url,
+		"createdAt":   m.CreatedAt,
+	}
+	if m.ThumbnailKey != nil {
+		out["thumbnailUrl"] = url + "?variant=thumbnail"
+	}
+	return out
+}
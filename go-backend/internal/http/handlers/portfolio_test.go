@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestPortfolioAccess(t *testing.T) {
+	f := newHubFixture(t)
+	f.fake.tables["entrepreneurs"][0]["verified"] = true
+	now := time.Now()
+	public, network := uuid.NewString(), uuid.NewString()
+	for _, it := range []struct{ id, visibility string }{{public, "PUBLIC"}, {network, "NETWORK"}} {
+		f.fake.insert("portfolio_items", map[string]interface{}{
+			"id": it.id, "entrepreneur_id": f.entrepreneur, "kind": "PRODUCT", "title": "Solar dryer",
+			"currency": "UGX", "visibility": it.visibility, "position": 0, "created_by": f.founder,
+			"created_at": now, "updated_at": now,
+		})
+	}
+
+	count := func(user string) int {
+		w := f.do(t, user, "GET", "/api/portfolio/entrepreneur/"+f.entrepreneur, nil)
+		var body struct {
+			Total int `json:"total"`
+		}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return body.Total
+	}
+	if got := count(""); got != 1 {
+		t.Fatalf("expected anonymous visitors to see only the public item, got %d", got)
+	}
+	if got := count(f.otherFounder); got != 2 {
+		t.Fatalf("expected signed-in users to see network items, got %d", got)
+	}
+
+	if w := f.do(t, f.otherFounder, "PUT", "/api/portfolio/"+public, gin.H{"title": "Mine now"}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected another entrepreneur to be forbidden, got %d", w.Code)
+	}
+	if n := f.fake.writeCount("portfolio_items"); n != 0 {
+		t.Fatalf("expected no writes to portfolio_items, got %d", n)
+	}
+	if w := f.do(t, f.founder, "PUT", "/api/portfolio/"+public, gin.H{"price": 250000}); w.Code != http.StatusOK {
+		t.Fatalf("expected the owner to update their item, got %d: %s", w.Code, w.Body.String())
+	}
+
+	upload := func(name string, content []byte) *httptest.ResponseRecorder {
+		return f.upload(t, f.founder, "/api/portfolio/"+public+"/media", name, content, nil)
+	}
+	if w := upload("photo.png", testPNG(t)); w.Code != http.StatusCreated {
+		t.Fatalf("expected the image to upload, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := upload("photo.png", []byte("<html><script>alert(1)</script>")); w.Code != http.StatusUnsupportedMediaType {
+		t.Fatalf("expected disguised HTML to be rejected, got %d", w.Code)
+	}
+	if n := f.fake.writeCount("portfolio_media"); n != 1 {
+		t.Fatalf("expected one media record, got %d writes", n)
+	}
+}
@@ -0,0 +1,901 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/cohort"
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+type programRequest struct {
+	HubID       string `json:"hubId"`
+	Name        string `json:"name" binding:"required,min=2,max=255"`
+	Description string `json:"description"`
+	Curriculum  string `json:"curriculum"`
+	Active      *bool  `json:"active"`
+}
+
+type cohortRequest struct {
+	Name                string     `json:"name" binding:"required,min=2,max=255"`
+	StartDate           string     `json:"startDate" binding:"required"` // YYYY-MM-DD
+	EndDate             string     `json:"endDate" binding:"required"`   // YYYY-MM-DD
+	Capacity            *int       `json:"capacity" binding:"omitempty,min=1"`
+	ApplicationOpensAt  *time.Time `json:"applicationOpensAt"`
+	ApplicationClosesAt *time.Time `json:"applicationClosesAt"`
+}
+
+type milestoneRequest struct {
+	Title       string  `json:"title" binding:"required,min=2,max=255"`
+	Description string  `json:"description"`
+	DueDate     *string `json:"dueDate"` // YYYY-MM-DD
+	Position    int     `json:"position"`
+	Required    *bool   `json:"required"`
+}
+
+type milestoneCompletionRequest struct {
+	Notes *string `json:"notes"`
+}
+
+// GET /api/programs/hub/:id - List a hub's programs and cohorts
+func GetHubPrograms(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	hubID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+
+	programs, err := db.ListPrograms(gdb, hubID, c.Query("includeInactive") == "true")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch programs"})
+		return
+	}
+
+	var cohortIDs []uuid.UUID
+	for _, p := range programs {
+		for _, ch := range p.Cohorts {
+			cohortIDs = append(cohortIDs, ch.ID)
+		}
+	}
+	counts, err := db.CountCohortEnrollments(gdb, cohortIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch cohorts"})
+		return
+	}
+
+	transformed := make([]gin.H, len(programs))
+	for i := range programs {
+		transformed[i] = transformProgram(&programs[i], counts)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"programs": transformed, "total": len(transformed)})
+}
+
+// GET /api/programs/:id - Get a program and its cohorts
+func GetProgram(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	program := findProgramFromParam(c, gdb)
+	if program == nil {
+		return
+	}
+
+	cohortIDs := make([]uuid.UUID, len(program.Cohorts))
+	for i, ch := range program.Cohorts {
+		cohortIDs[i] = ch.ID
+	}
+	counts, err := db.CountCohortEnrollments(gdb, cohortIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch cohorts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"program": transformProgram(program, counts)})
+}
+
+// POST /api/programs - Create a program (ADMIN or the hub's CENTER_MANAGER)
+func CreateProgram(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req programRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	hubID, err := uuid.Parse(req.HubID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+		return
+	}
+	hub, err := db.FindCenterByID(gdb, hubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if hub == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "hub not found"})
+		return
+	}
+	if !canManageCenter(c, hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	program := db.Program{HubID: hub.ID, Active: true}
+	applyProgramRequest(&program, &req)
+
+	if err := db.SaveProgram(gdb, &program); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create program"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Program created successfully",
+		"program": transformProgram(&program, nil),
+	})
+}
+
+// PUT /api/programs/:id - Update a program (ADMIN or the hub's CENTER_MANAGER)
+func UpdateProgram(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	program := findProgramFromParam(c, gdb)
+	if program == nil {
+		return
+	}
+	if !canManageCenter(c, &program.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req programRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	applyProgramRequest(program, &req)
+
+	if err := db.SaveProgram(gdb, program); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update program"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Program updated successfully",
+		"program": transformProgram(program, nil),
+	})
+}
+
+// POST /api/programs/:id/cohorts - Add a cohort to a program (ADMIN or the hub's CENTER_MANAGER)
+func CreateCohort(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	program := findProgramFromParam(c, gdb)
+	if program == nil {
+		return
+	}
+	if !canManageCenter(c, &program.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req cohortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	ch := db.Cohort{ProgramID: program.ID, HubID: program.HubID}
+	if msg := applyCohortRequest(&ch, &req); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	if err := db.SaveCohort(gdb, &ch); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create cohort"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Cohort created successfully",
+		"cohort":  transformCohort(&ch, 0),
+	})
+}
+
+// GET /api/cohorts/:id - Get a cohort with its milestones
+func GetCohort(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	ch := findCohortFromParam(c, gdb)
+	if ch == nil {
+		return
+	}
+
+	counts, err := db.CountCohortEnrollments(gdb, []uuid.UUID{ch.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch cohort"})
+		return
+	}
+
+	result := transformCohort(ch, counts[ch.ID])
+	result["programName"] = ch.Program.Name
+	result["milestones"] = transformMilestones(ch.Milestones)
+	c.JSON(http.StatusOK, gin.H{"cohort": result})
+}
+
+// PUT /api/cohorts/:id - Update a cohort (ADMIN or the hub's CENTER_MANAGER)
+func UpdateCohort(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	ch := findCohortFromParam(c, gdb)
+	if ch == nil {
+		return
+	}
+	if !canManageCenter(c, &ch.Program.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var req cohortRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if msg := applyCohortRequest(ch, &req); msg != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	if err := db.SaveCohort(gdb, ch); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update cohort"})
+		return
+	}
+
+	counts, _ := db.CountCohortEnrollments(gdb, []uuid.UUID{ch.ID})
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Cohort updated successfully",
+		"cohort":  transformCohort(ch, counts[ch.ID]),
+	})
+}
+
+// POST /api/cohorts/:id/milestones - Add a milestone (ADMIN or the hub's CENTER_MANAGER)
+func CreateCohortMilestone(c *gin.Context) {
+	saveCohortMilestone(c, false)
+}
+
+// PUT /api/cohorts/:id/milestones/:milestoneId - Update a milestone (ADMIN or the hub's CENTER_MANAGER)
+func UpdateCohortMilestone(c *gin.Context) {
+	saveCohortMilestone(c, true)
+}
+
+func saveCohortMilestone(c *gin.Context, existing bool) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	ch := findCohortFromParam(c, gdb)
+	if ch == nil {
+		return
+	}
+	if !canManageCenter(c, &ch.Program.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	milestone := &db.CohortMilestone{CohortID: ch.ID, Required: true, Position: len(ch.Milestones)}
+	if existing {
+		milestone = findMilestoneFromParam(c, gdb, ch)
+		if milestone == nil {
+			return
+		}
+	}
+
+	var req milestoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	milestone.Title = strings.TrimSpace(req.Title)
+	milestone.Description = req.Description
+	if req.Position != 0 || existing {
+		milestone.Position = req.Position
+	}
+	if req.Required != nil {
+		milestone.Required = *req.Required
+	}
+	milestone.DueDate = nil
+	if req.DueDate != nil && *req.DueDate != "" {
+		due, err := time.Parse("2006-01-02", *req.DueDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "dueDate must be YYYY-MM-DD"})
+			return
+		}
+		milestone.DueDate = &due
+	}
+
+	if err := db.SaveCohortMilestone(gdb, milestone); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save milestone"})
+		return
+	}
+
+	status, message := http.StatusCreated, "Milestone created successfully"
+	if existing {
+		status, message = http.StatusOK, "Milestone updated successfully"
+	}
+	c.JSON(status, gin.H{"message": message, "milestone": transformMilestones([]db.CohortMilestone{*milestone})[0]})
+}
+
+// DELETE /api/cohorts/:id/milestones/:milestoneId - Remove a milestone (ADMIN or the hub's CENTER_MANAGER)
+func DeleteCohortMilestone(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	ch := findCohortFromParam(c, gdb)
+	if ch == nil {
+		return
+	}
+	if !canManageCenter(c, &ch.Program.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	milestone := findMilestoneFromParam(c, gdb, ch)
+	if milestone == nil {
+		return
+	}
+
+	if err := db.DeleteCohortMilestone(gdb, milestone); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete milestone"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Milestone deleted successfully"})
+}
+
+// GET /api/cohorts/:id/progress - Milestone progress for every enrollment (ADMIN or the hub's CENTER_MANAGER)
+func GetCohortProgress(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	ch := findCohortFromParam(c, gdb)
+	if ch == nil {
+		return
+	}
+	if !canManageCenter(c, &ch.Program.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	enrollments, err := db.ListCohortEnrollments(gdb, ch.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch enrollments"})
+		return
+	}
+	progress, err := cohort.ProgressFor(gdb, ch, enrollments)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to compute progress"})
+		return
+	}
+
+	rows := make([]gin.H, len(enrollments))
+	for i, e := range enrollments {
+		rows[i] = gin.H{
+			"enrollmentId":   e.ID,
+			"entrepreneurId": e.EntrepreneurID,
+			"businessName":   e.Entrepreneur.BusinessName,
+			"status":         e.Status,
+			"completionDate": e.CompletionDate,
+			"progress":       progress[e.ID],
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cohortId":    ch.ID,
+		"milestones":  transformMilestones(ch.Milestones),
+		"enrollments": rows,
+	})
+}
+
+// POST /api/cohorts/:id/complete - Close a cohort, completing enrollments that met their milestones (ADMIN or the hub's CENTER_MANAGER)
+func CompleteCohort(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	ch := findCohortFromParam(c, gdb)
+	if ch == nil {
+		return
+	}
+	if !canManageCenter(c, &ch.Program.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+	if ch.CompletedAt != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": cohort.ErrCohortCompleted.Error()})
+		return
+	}
+
+	var actor *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		actor = &uid
+	}
+	completed, incomplete, err := cohort.Close(gdb, ch, actor)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to complete cohort"})
+		return
+	}
+
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitCenterUpdate(ch.HubID.String(), gin.H{
+			"cohortId":             ch.ID,
+			"completedEnrollments": completed,
+			"action":               "cohort_completed",
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":               "Cohort completed successfully",
+		"completedEnrollments":  completed,
+		"incompleteEnrollments": incomplete,
+	})
+}
+
+// PATCH /api/enrollments/:id/cohort - Move an enrollment into a cohort of its hub (ADMIN or the hub's CENTER_MANAGER)
+func AssignEnrollmentCohort(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	enrollmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid enrollment id"})
+		return
+	}
+
+	var req struct {
+		CohortID *string `json:"cohortId"` // null removes the enrollment from its cohort
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	var enrollment db.HubEnrollment
+	if err := gdb.Preload("Hub").First(&enrollment, enrollmentID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "enrollment not found"})
+		return
+	}
+	if !canManageCenter(c, &enrollment.Hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"})
+		return
+	}
+
+	var cohortID *uuid.UUID
+	if req.CohortID != nil && *req.CohortID != "" {
+		id, err := uuid.Parse(*req.CohortID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cohort id"})
+			return
+		}
+		cohortID = &id
+	}
+
+	// Moving an enrollment into the cohort it is in changes nothing
+	unchanged := (cohortID == nil) == (enrollment.CohortID == nil) && (cohortID == nil || *cohortID == *enrollment.CohortID)
+	if !unchanged {
+		if cohortID != nil {
+			if _, status, msg := admitToCohort(gdb, cohortID.String(), enrollment.HubID, true); status != 0 {
+				c.JSON(status, gin.H{"error": msg})
+				return
+			}
+		}
+
+		// One enrollment per entrepreneur and cohort, as when enrolling
+		var existing int64
+		existingQuery := gdb.Model(&db.HubEnrollment{}).
+			Where("hub_id = ? AND entrepreneur_id = ? AND id <> ?", enrollment.HubID, enrollment.EntrepreneurID, enrollment.ID)
+		if cohortID != nil {
+			existingQuery = existingQuery.Where("cohort_id = ?", *cohortID)
+		} else {
+			existingQuery = existingQuery.Where("cohort_id IS NULL")
+		}
+		if err := existingQuery.Count(&existing).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check existing enrollment"})
+			return
+		}
+		if existing > 0 {
+			c.JSON(http.StatusConflict, gin.H{"error": "entrepreneur is already enrolled in this cohort"})
+			return
+		}
+
+		if err := db.MoveEnrollmentToCohort(gdb, &enrollment, cohortID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update enrollment"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Enrollment cohort updated successfully",
+		"enrollment": gin.H{
+			"id":       enrollment.ID,
+			"hubId":    enrollment.HubID,
+			"cohortId": cohortID,
+			"status":   enrollment.Status,
+		},
+	})
+}
+
+// GET /api/enrollments/:id/milestones - Milestones and progress for an enrollment
+func GetEnrollmentMilestones(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	enrollment, ch := findCohortEnrollment(c, gdb, false)
+	if enrollment == nil {
+		return
+	}
+
+	completions, err := db.ListMilestoneCompletions(gdb, []uuid.UUID{enrollment.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch milestones"})
+		return
+	}
+	byMilestone := make(map[uuid.UUID]db.MilestoneCompletion, len(completions))
+	done := make(map[uuid.UUID]bool, len(completions))
+	for _, comp := range completions {
+		byMilestone[comp.MilestoneID] = comp
+		done[comp.MilestoneID] = true
+	}
+
+	milestones := transformMilestones(ch.Milestones)
+	for i, m := range ch.Milestones {
+		if comp, ok := byMilestone[m.ID]; ok {
+			milestones[i]["completedAt"] = comp.CompletedAt
+			milestones[i]["notes"] = comp.Notes
+		} else {
+			milestones[i]["completedAt"] = nil
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"enrollmentId": enrollment.ID,
+		"cohortId":     ch.ID,
+		"status":       enrollment.Status,
+		"milestones":   milestones,
+		"progress":     cohort.Compute(ch.Milestones, done),
+	})
+}
+
+// POST /api/enrollments/:id/milestones/:milestoneId/complete - Mark a milestone complete (ADMIN or the hub's CENTER_MANAGER)
+func CompleteEnrollmentMilestone(c *gin.Context) {
+	setEnrollmentMilestone(c, true)
+}
+
+// DELETE /api/enrollments/:id/milestones/:milestoneId/complete - Unmark a milestone (ADMIN or the hub's CENTER_MANAGER)
+func UncompleteEnrollmentMilestone(c *gin.Context) {
+	setEnrollmentMilestone(c, false)
+}
+
+func setEnrollmentMilestone(c *gin.Context, complete bool) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	enrollment, ch := findCohortEnrollment(c, gdb, true)
+	if enrollment == nil {
+		return
+	}
+	milestone := findMilestoneFromParam(c, gdb, ch)
+	if milestone == nil {
+		return
+	}
+
+	var by *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		by = &uid
+	}
+	if complete {
+		var req milestoneCompletionRequest
+		_ = c.ShouldBindJSON(&req) // notes are optional
+		if err := db.CompleteMilestone(gdb, enrollment.ID, milestone.ID, by, req.Notes); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to complete milestone"})
+			return
+		}
+	} else if err := db.UncompleteMilestone(gdb, enrollment.ID, milestone.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update milestone"})
+		return
+	}
+
+	progress, enrollmentCompleted, err := cohort.SyncEnrollment(gdb, ch, enrollment, by)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update enrollment"})
+		return
+	}
+
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		action := "milestone_updated"
+		if enrollmentCompleted {
+			action = "enrollment_status_updated"
+		}
+		br.EmitCenterUpdate(enrollment.HubID.String(), gin.H{
+			"enrollmentId":   enrollment.ID,
+			"entrepreneurId": enrollment.EntrepreneurID,
+			"milestoneId":    milestone.ID,
+			"status":         enrollment.Status,
+			"action":         action,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":             "Milestone updated successfully",
+		"progress":            progress,
+		"enrollmentStatus":    enrollment.Status,
+		"enrollmentCompleted": enrollmentCompleted,
+	})
+}
+
+// admitToCohort loads a cohort of the hub and checks there is room for one
+// more enrollment. It returns a non-zero status and message when the cohort
+// cannot take the enrollment.
+func admitToCohort(gdb *gorm.DB, rawID string, hubID uuid.UUID, manager bool) (*db.Cohort, int, string) {
+	cohortID, err := uuid.Parse(rawID)
+	if err != nil {
+		return nil, http.StatusBadRequest, "invalid cohort id"
+	}
+	ch, err := db.FindCohortByID(gdb, cohortID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, "failed to fetch cohort"
+	}
+	if ch == nil || ch.HubID != hubID {
+		return nil, http.StatusNotFound, "cohort not found for this hub"
+	}
+	counts, err := db.CountCohortEnrollments(gdb, []uuid.UUID{ch.ID})
+	if err != nil {
+		return nil, http.StatusInternalServerError, "failed to fetch cohort"
+	}
+	if err := cohort.CheckAdmission(ch, counts[ch.ID], time.Now(), manager); err != nil {
+		return nil, http.StatusConflict, err.Error()
+	}
+	return ch, 0, ""
+}
+
+// findCohortEnrollment loads the enrollment named by :id together with its
+// cohort. Managers of the hub always have access; entrepreneurs only to
+// their own enrollment unless managerOnly is set.
+func findCohortEnrollment(c *gin.Context, gdb *gorm.DB, managerOnly bool) (*db.HubEnrollment, *db.Cohort) {
+	enrollmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid enrollment id"})
+		return nil, nil
+	}
+
+	var enrollment db.HubEnrollment
+	if err := gdb.Preload("Hub").Preload("Entrepreneur").First(&enrollment, enrollmentID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "enrollment not found"})
+		return nil, nil
+	}
+
+	isOwner := enrollment.Entrepreneur.UserID.String() == ctxutil.UserIDFrom(c)
+	if !canManageCenter(c, &enrollment.Hub) && (managerOnly || !isOwner) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to view this enrollment"})
+		return nil, nil
+	}
+
+	if enrollment.CohortID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "enrollment is not part of a cohort"})
+		return nil, nil
+	}
+	ch, err := db.FindCohortByID(gdb, *enrollment.CohortID)
+	if err != nil || ch == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch cohort"})
+		return nil, nil
+	}
+	return &enrollment, ch
+}
+
+// findProgramFromParam loads the program named by the :id parameter, writing
+// the error response and returning nil when it cannot
+func findProgramFromParam(c *gin.Context, gdb *gorm.DB) *db.Program {
+	programID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid program id"})
+		return nil
+	}
+	program, err := db.FindProgramByID(gdb, programID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch program"})
+		return nil
+	}
+	if program == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "program not found"})
+		return nil
+	}
+	return program
+}
+
+// findCohortFromParam loads the cohort named by the :id parameter, writing
+// the error response and returning nil when it cannot
+func findCohortFromParam(c *gin.Context, gdb *gorm.DB) *db.Cohort {
+	cohortID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cohort id"})
+		return nil
+	}
+	ch, err := db.FindCohortByID(gdb, cohortID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch cohort"})
+		return nil
+	}
+	if ch == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "cohort not found"})
+		return nil
+	}
+	return ch
+}
+
+func findMilestoneFromParam(c *gin.Context, gdb *gorm.DB, ch *db.Cohort) *db.CohortMilestone {
+	milestoneID, err := uuid.Parse(c.Param("milestoneId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid milestone id"})
+		return nil
+	}
+	milestone, err := db.FindCohortMilestone(gdb, ch.ID, milestoneID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch milestone"})
+		return nil
+	}
+	if milestone == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "milestone not found"})
+		return nil
+	}
+	return milestone
+}
+
+func applyProgramRequest(program *db.Program, req *programRequest) {
+	program.Name = strings.TrimSpace(req.Name)
+	program.Description = req.Description
+	program.Curriculum = req.Curriculum
+	if req.Active != nil {
+		program.Active = *req.Active
+	}
+}
+
+// applyCohortRequest validates and copies a cohort request, returning an
+// error message when it is rejected
+func applyCohortRequest(ch *db.Cohort, req *cohortRequest) string {
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		return "startDate must be YYYY-MM-DD"
+	}
+	end, err := time.Parse("2006-01-02", req.EndDate)
+	if err != nil {
+		return "endDate must be YYYY-MM-DD"
+	}
+	if end.Before(start) {
+		return "endDate cannot be before startDate"
+	}
+	if req.ApplicationOpensAt != nil && req.ApplicationClosesAt != nil && !req.ApplicationClosesAt.After(*req.ApplicationOpensAt) {
+		return "application window must close after it opens"
+	}
+
+	ch.Name = strings.TrimSpace(req.Name)
+	ch.StartDate = start
+	ch.EndDate = end
+	ch.Capacity = req.Capacity
+	ch.ApplicationOpensAt = req.ApplicationOpensAt
+	ch.ApplicationClosesAt = req.ApplicationClosesAt
+	return ""
+}
+
+func transformProgram(p *db.Program, enrolled map[uuid.UUID]int64) gin.H {
+	cohorts := make([]gin.H, len(p.Cohorts))
+	for i := range p.Cohorts {
+		cohorts[i] = transformCohort(&p.Cohorts[i], enrolled[p.Cohorts[i].ID])
+	}
+	return gin.H{
+		"id":          p.ID,
+		"hubId":       p.HubID,
+		"name":        p.Name,
+		"description": p.Description,
+		"curriculum":  p.Curriculum,
+		"active":      p.Active,
+		"cohorts":     cohorts,
+		"createdAt":   p.CreatedAt,
+		"updatedAt":   p.UpdatedAt,
+	}
+}
+
+func transformCohort(ch *db.Cohort, enrolled int64) gin.H {
+	now := time.Now()
+	return gin.H{
+		"id":                  ch.ID,
+		"programId":           ch.ProgramID,
+		"hubId":               ch.HubID,
+		"name":                ch.Name,
+		"startDate":           ch.StartDate.Format("2006-01-02"),
+		"endDate":             ch.EndDate.Format("2006-01-02"),
+		"capacity":            ch.Capacity,
+		"enrolled":            enrolled,
+		"applicationOpensAt":  ch.ApplicationOpensAt,
+		"applicationClosesAt": ch.ApplicationClosesAt,
+		"applicationsOpen":    cohort.ApplicationsOpen(ch, now),
+		"phase":               cohort.CurrentPhase(ch, now),
+		"completedAt":         ch.CompletedAt,
+	}
+}
+
+func transformMilestones(milestones []db.CohortMilestone) []gin.H {
+	out := make([]gin.H, len(milestones))
+	for i, m := range milestones {
+		var due *string
+		if m.DueDate != nil {
+			s := m.DueDate.Format("2006-01-02")
+			due = &s
+		}
+		out[i] = gin.H{
+			"id":          m.ID,
+			"cohortId":    m.CohortID,
+			"title":       m.Title,
+			"description": m.Description,
+			"dueDate":     due,
+			"position":    m.Position,
+			"required":    m.Required,
+		}
+	}
+	return out
+}
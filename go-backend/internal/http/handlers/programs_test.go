@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestAssignEnrollmentCohort(t *testing.T) {
+	f := newHubFixture(t)
+	now := time.Now()
+	program, current, next, milestone := uuid.NewString(), uuid.NewString(), uuid.NewString(), uuid.NewString()
+	f.fake.insert("programs", map[string]interface{}{
+		"id": program, "hub_id": f.hubA, "name": "Accelerator", "created_at": now, "updated_at": now,
+	})
+	for _, id := range []string{current, next} {
+		f.fake.insert("cohorts", map[string]interface{}{
+			"id": id, "program_id": program, "hub_id": f.hubA, "name": "Cohort", "start_date": now,
+			"end_date": now.AddDate(0, 3, 0), "capacity": nil, "completed_at": nil, "created_at": now, "updated_at": now,
+		})
+	}
+	f.fake.row("hub_enrollments", f.enrollment)["cohort_id"] = current
+	f.fake.insert("cohort_milestones", map[string]interface{}{
+		"id": milestone, "cohort_id": current, "title": "Pitch", "position": 0, "required": true,
+		"created_at": now, "updated_at": now,
+	})
+	f.fake.insert("milestone_completions", map[string]interface{}{
+		"id": uuid.NewString(), "enrollment_id": f.enrollment, "milestone_id": milestone, "completed_at": now, "created_at": now,
+	})
+	f.fake.insert("hub_enrollments", map[string]interface{}{
+		"id": uuid.NewString(), "hub_id": f.hubA, "entrepreneur_id": f.entrepreneur, "status": "ACTIVE",
+		"cohort_id": next, "created_at": now, "updated_at": now,
+	})
+	path := "/api/enrollments/" + f.enrollment + "/cohort"
+
+	if w := f.do(t, f.managerA, "PATCH", path, gin.H{"cohortId": next}); w.Code != http.StatusConflict {
+		t.Fatalf("expected moving into a cohort the entrepreneur is already in to conflict, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := f.fake.writeCount("hub_enrollments") + f.fake.writeCount("milestone_completions"); n != 0 {
+		t.Fatalf("expected a refused move not to write, got %d writes", n)
+	}
+
+	if w := f.do(t, f.managerA, "PATCH", path, gin.H{"cohortId": nil}); w.Code != http.StatusOK {
+		t.Fatalf("expected the enrollment to leave its cohort, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := f.fake.row("hub_enrollments", f.enrollment)["cohort_id"]; got != nil {
+		t.Fatalf("expected no cohort, got %v", got)
+	}
+	if rows := f.fake.tables["milestone_completions"]; len(rows) != 0 {
+		t.Fatalf("expected the old cohort's completions to be cleared, got %d", len(rows))
+	}
+}
@@ -3,16 +3,25 @@ package handlers
 import (
     "net/http"
     "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
     "communitycentresplatform/go-backend/internal/ctxutil"
+    "communitycentresplatform/go-backend/internal/db"
 )
 
-// POST /api/realtime/join-center
+// POST /api/realtime/join-center - The hub's manager only, as center events carry bookings, read receipts and thread changes
 func JoinCenter(c *gin.Context) {
     var req struct{ CenterID string `json:"centerId" binding:"required"` }
     if err := c.ShouldBindJSON(&req); err != nil { c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"}); return }
     userID, _ := c.Get(ctxutil.KeyUserID)
     if userID == nil { c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"}); return }
-    if br := ctxutil.BrokerFrom(c); br != nil { br.SubscribeCenter(userID.(string), req.CenterID) }
+    gdb := ctxutil.DBFrom(c)
+    if gdb == nil { c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"}); return }
+    centerID, err := uuid.Parse(req.CenterID)
+    if err != nil { c.JSON(http.StatusBadRequest, gin.H{"error": "invalid center id"}); return }
+    ok, err := canManageCenterID(c, gdb, centerID)
+    if err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center"}); return }
+    if !ok { c.JSON(http.StatusForbidden, gin.H{"error": "you are not the manager of this hub"}); return }
+    if br := ctxutil.BrokerFrom(c); br != nil { br.SubscribeCenter(userID.(string), centerID.String()) }
     c.Status(http.StatusNoContent)
 }
 
@@ -26,13 +35,20 @@ func LeaveCenter(c *gin.Context) {
     c.Status(http.StatusNoContent)
 }
 
-// POST /api/realtime/join-thread
+// POST /api/realtime/join-thread - Participants only, as thread events carry message content and file links
 func JoinThread(c *gin.Context) {
     var req struct{ ThreadID string `json:"threadId" binding:"required"` }
     if err := c.ShouldBindJSON(&req); err != nil { c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"}); return }
     userID, _ := c.Get(ctxutil.KeyUserID)
     if userID == nil { c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"}); return }
-    if br := ctxutil.BrokerFrom(c); br != nil { br.SubscribeThread(userID.(string), req.ThreadID) }
+    gdb := ctxutil.DBFrom(c)
+    if gdb == nil { c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"}); return }
+    threadID, err := uuid.Parse(req.ThreadID)
+    if err != nil { c.JSON(http.StatusBadRequest, gin.H{"error": "invalid thread id"}); return }
+    thread, err := db.FindThreadByID(gdb, threadID)
+    if err != nil || thread == nil { c.JSON(http.StatusNotFound, gin.H{"error": "thread not found or access denied"}); return }
+    if !canAccessThread(c, thread) { c.JSON(http.StatusForbidden, gin.H{"error": "thread not found or access denied"}); return }
+    if br := ctxutil.BrokerFrom(c); br != nil { br.SubscribeThread(userID.(string), thread.ID.String()) }
     c.Status(http.StatusNoContent)
 }
 
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestJoinCenter(t *testing.T) {
+	f := newHubFixture(t)
+	managerA, managerB, founder := f.broker.AddClient(f.managerA), f.broker.AddClient(f.managerB), f.broker.AddClient(f.founder)
+	f.broker.AddClient(f.admin)
+
+	// Center events carry bookings and thread changes, so only the hub's managers may follow them
+	join := func(centerID string) map[string]string { return map[string]string{"centerId": centerID} }
+	f.check(t, []accessCase{
+		{"other hub manager", f.managerB, "POST", "/api/realtime/join-center", join(f.hubA), http.StatusForbidden},
+		{"entrepreneur", f.founder, "POST", "/api/realtime/join-center", join(f.hubA), http.StatusForbidden},
+		{"invalid center id", f.managerA, "POST", "/api/realtime/join-center", join("bogus"), http.StatusBadRequest},
+		{"hub manager", f.managerA, "POST", "/api/realtime/join-center", join(f.hubA), http.StatusNoContent},
+		{"admin", f.admin, "POST", "/api/realtime/join-center", join(f.hubA), http.StatusNoContent},
+	})
+
+	f.broker.EmitCenterUpdate(f.hubA, map[string]string{"id": f.hubA})
+	if len(managerA.Send) != 1 {
+		t.Fatalf("expected the hub manager to receive the center event, got %d", len(managerA.Send))
+	}
+	if len(managerB.Send)+len(founder.Send) != 0 {
+		t.Fatalf("expected refused subscribers to receive nothing")
+	}
+}
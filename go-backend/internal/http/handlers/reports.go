@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/reporting"
+)
+
+// GET /api/reports - List the available funder reports
+func GetReportDefinitions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"reports": reporting.Definitions, "formats": []string{"json", "csv", "xlsx", "pdf"}})
+}
+
+// GET /api/reports/:kind - Run a report for a date range and hubs (JSON, CSV, XLSX or PDF)
+func GetReport(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	kind := reporting.Kind(c.Param("kind"))
+	if _, ok := reporting.Lookup(kind); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+		return
+	}
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "csv" && format != "xlsx" && format != "pdf" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid format, use json, csv, xlsx or pdf"})
+		return
+	}
+
+	// Defaults to the last complete quarter, the usual donor reporting cycle
+	var params reporting.Params
+	params.From, params.To = reporting.LastQuarter(time.Now().UTC())
+	for name, dst := range map[string]*time.Time{"from": &params.From, "to": &params.To} {
+		if raw := c.Query(name); raw != "" {
+			t, err := time.Parse("2006-01-02", raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name + ", expected YYYY-MM-DD"})
+				return
+			}
+			*dst = t
+		}
+	}
+	if params.From.After(params.To) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return
+	}
+
+	// hubId may repeat or hold a comma-separated list
+	for _, raw := range c.QueryArray("hubId") {
+		for _, part := range strings.Split(raw, ",") {
+			if part = strings.TrimSpace(part); part == "" {
+				continue
+			}
+			hubID, err := uuid.Parse(part)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid hub id"})
+				return
+			}
+			params.HubIDs = append(params.HubIDs, hubID)
+		}
+	}
+
+	// Managers report on their own hubs only, all of them by default
+	if ctxutil.RoleFrom(c) != string(db.RoleAdmin) {
+		managed, err := callerManagedCenterIDs(c, gdb)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hubs"})
+			return
+		}
+		if len(managed) == 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+			return
+		}
+		if len(params.HubIDs) == 0 {
+			params.HubIDs = managed
+		}
+		allowed := map[uuid.UUID]bool{}
+		for _, id := range managed {
+			allowed[id] = true
+		}
+		for _, id := range params.HubIDs {
+			if !allowed[id] {
+				c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+				return
+			}
+		}
+	}
+
+	for _, id := range params.HubIDs {
+		hub, err := db.FindCenterByID(gdb, id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+			return
+		}
+		if hub == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "hub not found"})
+			return
+		}
+		params.HubNames = append(params.HubNames, hub.Name)
+	}
+
+	report, err := reporting.Generate(gdb, kind, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate report"})
+		return
+	}
+
+	var (
+		buf         bytes.Buffer
+		contentType string
+	)
+	switch format {
+	case "json":
+		c.JSON(http.StatusOK, gin.H{"report": report})
+		return
+	case "csv":
+		contentType, err = "text/csv; charset=utf-8", report.WriteCSV(&buf)
+	case "xlsx":
+		contentType, err = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", report.WriteXLSX(&buf)
+	case "pdf":
+		contentType, err = "application/pdf", report.WritePDF(&buf)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export report"})
+		return
+	}
+
+	filename := fmt.Sprintf("%s_%s_%s.%s", kind, params.From.Format("2006-01-02"), params.To.Format("2006-01-02"), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestReportAccess(t *testing.T) {
+	f := newHubFixture(t)
+
+	f.check(t, []accessCase{
+		{"own hub report", f.managerA, "GET", "/api/reports/services-by-type?format=pdf&hubId=" + f.hubA, nil, http.StatusOK},
+		{"own hubs report by default", f.managerA, "GET", "/api/reports/entrepreneurs-served?format=xlsx", nil, http.StatusOK},
+		{"other hub report", f.managerB, "GET", "/api/reports/investor-linkages?hubId=" + f.hubA, nil, http.StatusForbidden},
+		{"mixed hubs report", f.managerB, "GET", "/api/reports/collaborations?hubId=" + f.hubB + "," + f.hubA, nil, http.StatusForbidden},
+		{"network report", f.admin, "GET", "/api/reports/collaborations?format=csv&from=2020-01-01&to=2030-12-31", nil, http.StatusOK},
+	})
+}
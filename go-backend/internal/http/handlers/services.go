@@ -1,14 +1,19 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 
 	"communitycentresplatform/go-backend/internal/ctxutil"
 	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/investors"
+	"communitycentresplatform/go-backend/internal/lifecycle"
 )
 
 type serviceProvisionRequest struct {
@@ -17,6 +22,7 @@ type serviceProvisionRequest struct {
 	ServiceType         string  `json:"serviceType" binding:"required,min=2"`
 	Description         string  `json:"description" binding:"required,min=10"`
 	CollaboratingHubID  *string `json:"collaboratingHubId"`
+	InvestorID          *string `json:"investorId"` // Directory entry; takes precedence over investorName
 	InvestorName        *string `json:"investorName"`
 	InvestorDetails     *string `json:"investorDetails"`
 	StartDate           *string `json:"startDate"` // ISO8601 format
@@ -61,6 +67,10 @@ func CreateServiceProvision(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "hub not found"})
 		return
 	}
+	if !canManageCenter(c, &hub) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only log services for hubs you manage"})
+		return
+	}
 
 	// Verify entrepreneur exists
 	var entrepreneur db.Entrepreneur
@@ -86,6 +96,35 @@ func CreateServiceProvision(c *gin.Context) {
 		collaboratingHubID = &collabID
 	}
 
+	// Link the investor to the directory, by ID or by a matching name
+	investorName := req.InvestorName
+	var investor *db.Investor
+	if req.InvestorID != nil && *req.InvestorID != "" {
+		investorID, err := uuid.Parse(*req.InvestorID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid investor id"})
+			return
+		}
+		if investor, err = db.FindInvestorByID(gdb, investorID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch investor"})
+			return
+		}
+		if investor == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "investor not found"})
+			return
+		}
+	} else if investorName != nil && strings.TrimSpace(*investorName) != "" {
+		if investor, err = db.FindInvestorBySlug(gdb, investors.Slug(*investorName)); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch investor"})
+			return
+		}
+	}
+	var investorID *uuid.UUID
+	if investor != nil {
+		investorID = &investor.ID
+		investorName = &investor.Name
+	}
+
 	// Parse optional start date
 	var startDate *time.Time
 	if req.StartDate != nil && *req.StartDate != "" {
@@ -103,13 +142,24 @@ func CreateServiceProvision(c *gin.Context) {
 		ServiceType:         req.ServiceType,
 		Description:         req.Description,
 		CollaboratingHubID:  collaboratingHubID,
-		InvestorName:        req.InvestorName,
+		InvestorName:        investorName,
+		InvestorID:          investorID,
 		InvestorDetails:     req.InvestorDetails,
 		StartDate:           startDate,
 		Status:              db.ServicePending,
 	}
 
-	if err := gdb.Create(&serviceProvision).Error; err != nil {
+	var actor *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		actor = &uid
+	}
+	err = gdb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&serviceProvision).Error; err != nil {
+			return err
+		}
+		return lifecycle.RecordInitial(tx, db.StatusEntityService, serviceProvision.ID, string(serviceProvision.Status), actor, nil)
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create service provision"})
 		return
 	}
@@ -161,6 +211,9 @@ func CreateServiceProvision(c *gin.Context) {
 	if serviceProvision.InvestorName != nil {
 		response["investorName"] = *serviceProvision.InvestorName
 	}
+	if serviceProvision.InvestorID != nil {
+		response["investorId"] = *serviceProvision.InvestorID
+	}
 	if serviceProvision.InvestorDetails != nil {
 		response["investorDetails"] = *serviceProvision.InvestorDetails
 	}
@@ -191,12 +244,23 @@ func GetHubServiceProvisions(c *gin.Context) {
 		return
 	}
 
+	allowed, err := canManageCenterID(c, gdb, hubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "you can only view services for hubs you manage"})
+		return
+	}
+
 	// Optional filters
 	status := c.Query("status")
 	serviceType := c.Query("serviceType")
 
+	// Includes services the hub co-delivers as the collaborating hub
 	query := gdb.Preload("Hub").Preload("Entrepreneur.User").Preload("CollaboratingHub").
-		Where("hub_id = ?", hubID)
+		Where("hub_id = ? OR collaborating_hub_id = ?", hubID, hubID)
 
 	if status != "" {
 		query = query.Where("status = ?", status)
@@ -247,6 +311,9 @@ func GetHubServiceProvisions(c *gin.Context) {
 		if s.InvestorName != nil {
 			service["investorName"] = *s.InvestorName
 		}
+		if s.InvestorID != nil {
+			service["investorId"] = *s.InvestorID
+		}
 		if s.InvestorDetails != nil {
 			service["investorDetails"] = *s.InvestorDetails
 		}
@@ -301,6 +368,17 @@ func GetEntrepreneurServices(c *gin.Context) {
 	query := gdb.Preload("Hub").Preload("CollaboratingHub").
 		Where("entrepreneur_id = ?", entrepreneurID)
 
+	// Managers only see services their hubs deliver or co-deliver
+	if role == string(db.RoleCenterManager) {
+		hubIDs, err := callerManagedCenterIDs(c, gdb)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch service provisions"})
+			return
+		}
+		hubIDs = append(hubIDs, uuid.Nil)
+		query = query.Where("hub_id IN ? OR collaborating_hub_id IN ?", hubIDs, hubIDs)
+	}
+
 	if status != "" {
 		query = query.Where("status = ?", status)
 	}
@@ -347,6 +425,9 @@ func GetEntrepreneurServices(c *gin.Context) {
 		if s.InvestorName != nil {
 			service["investorName"] = *s.InvestorName
 		}
+		if s.InvestorID != nil {
+			service["investorId"] = *s.InvestorID
+		}
 		if s.InvestorDetails != nil {
 			service["investorDetails"] = *s.InvestorDetails
 		}
@@ -382,6 +463,7 @@ func UpdateServiceProvision(c *gin.Context) {
 
 	var req struct {
 		Status         *string `json:"status"`
+		Reason         *string `json:"reason"` // Recorded in the status history
 		Outcome        *string `json:"outcome"`
 		CompletionDate *string `json:"completionDate"` // ISO8601 format
 	}
@@ -396,20 +478,32 @@ func UpdateServiceProvision(c *gin.Context) {
 		return
 	}
 
-	// Update status if provided
-	if req.Status != nil {
-		newStatus := db.ServiceProvisionStatus(*req.Status)
-		if newStatus != db.ServicePending && newStatus != db.ServiceActive &&
-		   newStatus != db.ServiceCompleted && newStatus != db.ServiceCancelled {
+	// Collaborating hubs have read access only
+	allowed, err := canManageCenterID(c, gdb, service.HubID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch hub"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the delivering hub can update this service"})
+		return
+	}
+
+	// Validate status; resending the current status leaves it unchanged
+	var newStatus db.ServiceProvisionStatus
+	if req.Status != nil && db.ServiceProvisionStatus(*req.Status) != service.Status {
+		newStatus = db.ServiceProvisionStatus(*req.Status)
+		if !lifecycle.Services.Valid(newStatus) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid status value"})
 			return
 		}
-		service.Status = newStatus
-
-		// Auto-set completion date if status is COMPLETED or CANCELLED
-		if (newStatus == db.ServiceCompleted || newStatus == db.ServiceCancelled) && service.CompletionDate == nil {
-			now := time.Now()
-			service.CompletionDate = &now
+		if err := lifecycle.Services.Check(service.Status, newStatus); err != nil {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":   err.Error(),
+				"status":  service.Status,
+				"allowed": lifecycle.Services.Allowed(service.Status),
+			})
+			return
 		}
 	}
 
@@ -428,7 +522,24 @@ func UpdateServiceProvision(c *gin.Context) {
 		service.CompletionDate = &parsedDate
 	}
 
-	if err := gdb.Save(&service).Error; err != nil {
+	var actor *uuid.UUID
+	if uid, err := uuid.Parse(ctxutil.UserIDFrom(c)); err == nil {
+		actor = &uid
+	}
+	err = gdb.Transaction(func(tx *gorm.DB) error {
+		if newStatus != "" {
+			// Auto-sets the completion date when the service ends
+			if err := lifecycle.TransitionService(tx, &service, newStatus, actor, req.Reason); err != nil {
+				return err
+			}
+		}
+		return tx.Save(&service).Error
+	})
+	if err != nil {
+		if errors.Is(err, lifecycle.ErrIllegalTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update service provision"})
 		return
 	}
@@ -492,6 +603,10 @@ func GetServiceProvision(c *gin.Context) {
 	} else if role != string(db.RoleAdmin) && role != string(db.RoleCenterManager) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
 		return
+	} else if !canManageCenter(c, &service.Hub) &&
+		(service.CollaboratingHub == nil || !canManageCenter(c, service.CollaboratingHub)) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to view this service"})
+		return
 	}
 
 	response := gin.H{
@@ -536,6 +651,9 @@ func GetServiceProvision(c *gin.Context) {
 	if service.InvestorName != nil {
 		response["investorName"] = *service.InvestorName
 	}
+	if service.InvestorID != nil {
+		response["investorId"] = *service.InvestorID
+	}
 	if service.InvestorDetails != nil {
 		response["investorDetails"] = *service.InvestorDetails
 	}
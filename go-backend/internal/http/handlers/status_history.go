@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/lifecycle"
+)
+
+// GET /api/enrollments/:id/history - Status history of an enrollment
+func GetEnrollmentHistory(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	enrollmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid enrollment id"})
+		return
+	}
+
+	var enrollment db.HubEnrollment
+	if err := gdb.First(&enrollment, enrollmentID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "enrollment not found"})
+		return
+	}
+	allowed, err := canViewEnrollment(c, gdb, &enrollment)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to view this enrollment"})
+		return
+	}
+
+	transitions, err := db.ListStatusTransitions(gdb, db.StatusEntityEnrollment, enrollment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch status history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"enrollmentId": enrollment.ID,
+		"status":       enrollment.Status,
+		"allowed":      lifecycle.Enrollments.Allowed(enrollment.Status),
+		"history":      transformStatusTransitions(transitions),
+	})
+}
+
+// GET /api/services/:id/history - Status history of a service provision
+func GetServiceProvisionHistory(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	serviceID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid service id"})
+		return
+	}
+
+	var service db.ServiceProvision
+	if err := gdb.First(&service, serviceID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "service provision not found"})
+		return
+	}
+	allowed, err := canViewService(c, gdb, &service)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to view this service"})
+		return
+	}
+
+	transitions, err := db.ListStatusTransitions(gdb, db.StatusEntityService, service.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch status history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"serviceId": service.ID,
+		"status":    service.Status,
+		"allowed":   lifecycle.Services.Allowed(service.Status),
+		"history":   transformStatusTransitions(transitions),
+	})
+}
+
+// GET /api/deals/:id/history - Stage history of an investor deal
+func GetDealHistory(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	dealID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid deal id"})
+		return
+	}
+
+	var deal db.Deal
+	if err := gdb.First(&deal, dealID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "deal not found"})
+		return
+	}
+	allowed, err := canViewDeal(c, gdb, &deal)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to check permissions"})
+		return
+	}
+	if !allowed {
+		c.JSON(http.StatusForbidden, gin.H{"error": "not authorized to view this deal"})
+		return
+	}
+
+	transitions, err := db.ListStatusTransitions(gdb, db.StatusEntityDeal, deal.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch status history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"dealId":  deal.ID,
+		"stage":   deal.Stage,
+		"allowed": lifecycle.Deals.Allowed(deal.Stage),
+		"history": transformStatusTransitions(transitions),
+	})
+}
+
+func transformStatusTransitions(transitions []db.StatusTransition) []gin.H {
+	out := make([]gin.H, len(transitions))
+	for i, t := range transitions {
+		entry := gin.H{
+			"id":        t.ID,
+			"from":      t.FromStatus,
+			"to":        t.ToStatus,
+			"actorId":   t.ActorID,
+			"reason":    t.Reason,
+			"createdAt": t.CreatedAt,
+		}
+		if t.Actor != nil {
+			entry["actor"] = gin.H{"id": t.Actor.ID, "name": t.Actor.Name, "role": t.Actor.Role}
+		}
+		out[i] = entry
+	}
+	return out
+}
@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/taxonomy"
+)
+
+type serviceCategoryRequest struct {
+	Kind        string   `json:"kind" binding:"omitempty,oneof=SERVICE RESOURCE"`
+	Slug        string   `json:"slug"`
+	Name        string   `json:"name" binding:"required,min=2,max=255"`
+	Description string   `json:"description"`
+	Synonyms    []string `json:"synonyms"`
+	ParentID    *string  `json:"parentId"`
+}
+
+// GET /api/taxonomy - List taxonomy entries (optionally ?kind=SERVICE|RESOURCE)
+func ListServiceCategories(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	kind := db.TaxonomyKind(strings.ToUpper(c.Query("kind")))
+	if kind != "" && kind != db.TaxonomyService && kind != db.TaxonomyResource {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid kind"})
+		return
+	}
+
+	categories, err := db.ListServiceCategories(gdb, kind)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch taxonomy"})
+		return
+	}
+
+	transformed := make([]gin.H, len(categories))
+	for i, cat := range categories {
+		transformed[i] = transformServiceCategory(&cat)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"categories": transformed, "total": len(transformed)})
+}
+
+// POST /api/taxonomy - Create taxonomy entry (ADMIN only)
+func CreateServiceCategory(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req serviceCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	category := db.ServiceCategory{Kind: db.TaxonomyService}
+	if status, msg := applyServiceCategoryRequest(gdb, &category, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	if err := db.CreateServiceCategory(gdb, &category); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create taxonomy entry"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":  "Taxonomy entry created successfully",
+		"category": transformServiceCategory(&category),
+	})
+}
+
+// PUT /api/taxonomy/:id - Update taxonomy entry (ADMIN only)
+func UpdateServiceCategory(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+
+	category, err := db.FindServiceCategoryByID(gdb, categoryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch taxonomy entry"})
+		return
+	}
+	if category == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "taxonomy entry not found"})
+		return
+	}
+
+	var req serviceCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	if status, msg := applyServiceCategoryRequest(gdb, category, &req); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	if err := db.UpdateServiceCategory(gdb, category); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update taxonomy entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Taxonomy entry updated successfully",
+		"category": transformServiceCategory(category),
+	})
+}
+
+// DELETE /api/taxonomy/:id - Delete taxonomy entry (ADMIN only)
+func DeleteServiceCategory(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	categoryID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category id"})
+		return
+	}
+
+	category, err := db.FindServiceCategoryByID(gdb, categoryID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch taxonomy entry"})
+		return
+	}
+	if category == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "taxonomy entry not found"})
+		return
+	}
+
+	if err := db.DeleteServiceCategory(gdb, category); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete taxonomy entry"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Taxonomy entry deleted successfully"})
+}
+
+// applyServiceCategoryRequest validates the request against the existing
+// taxonomy and copies it onto the category. It returns a non-zero status and
+// message when the request is rejected.
+func applyServiceCategoryRequest(gdb *gorm.DB, category *db.ServiceCategory, req *serviceCategoryRequest) (int, string) {
+	if req.Kind != "" {
+		category.Kind = db.TaxonomyKind(req.Kind)
+	}
+
+	slug := taxonomy.Slugify(req.Slug)
+	if slug == "" {
+		slug = taxonomy.Slugify(req.Name)
+	}
+	if slug == "" {
+		return http.StatusBadRequest, "slug must contain letters or digits"
+	}
+
+	existing, err := db.FindServiceCategoryBySlug(gdb, category.Kind, slug)
+	if err != nil {
+		return http.StatusInternalServerError, "failed to check slug"
+	}
+	if existing != nil && existing.ID != category.ID {
+		return http.StatusConflict, "a taxonomy entry with this slug already exists"
+	}
+
+	var parentID *uuid.UUID
+	if req.ParentID != nil && *req.ParentID != "" {
+		pid, err := uuid.Parse(*req.ParentID)
+		if err != nil {
+			return http.StatusBadRequest, "invalid parent id"
+		}
+		parent, err := db.FindServiceCategoryByID(gdb, pid)
+		if err != nil {
+			return http.StatusInternalServerError, "failed to fetch parent entry"
+		}
+		if parent == nil {
+			return http.StatusNotFound, "parent entry not found"
+		}
+		if parent.Kind != category.Kind {
+			return http.StatusBadRequest, "parent entry must be of the same kind"
+		}
+
+		// Reject cycles: the new parent must not be this entry or one of its descendants
+		if category.ID != uuid.Nil {
+			idx, err := taxonomy.Load(gdb)
+			if err != nil {
+				return http.StatusInternalServerError, "failed to load taxonomy"
+			}
+			if pid == category.ID {
+				return http.StatusBadRequest, "an entry cannot be its own parent"
+			}
+			for _, ancestor := range idx.Ancestors(pid) {
+				if ancestor == category.ID {
+					return http.StatusBadRequest, "parent would create a cycle"
+				}
+			}
+		}
+		parentID = &pid
+	}
+
+	synonyms := make([]string, 0, len(req.Synonyms))
+	for _, s := range req.Synonyms {
+		if s = strings.TrimSpace(s); s != "" {
+			synonyms = append(synonyms, s)
+		}
+	}
+
+	category.Slug = slug
+	category.Name = strings.TrimSpace(req.Name)
+	category.Description = req.Description
+	category.Synonyms = db.StringArray(synonyms)
+	category.ParentID = parentID
+	return 0, ""
+}
+
+func transformServiceCategory(cat *db.ServiceCategory) gin.H {
+	synonyms := cat.Synonyms
+	if synonyms == nil {
+		synonyms = db.StringArray{}
+	}
+	return gin.H{
+		"id":          cat.ID,
+		"kind":        cat.Kind,
+		"slug":        cat.Slug,
+		"name":        cat.Name,
+		"description": cat.Description,
+		"synonyms":    synonyms,
+		"parentId":    cat.ParentID,
+		"createdAt":   cat.CreatedAt,
+		"updatedAt":   cat.UpdatedAt,
+	}
+}
+
+// canonicalizeCenterTerms maps center services and resources onto the
+// taxonomy. Kinds without any taxonomy entries are passed through unchanged.
+func canonicalizeCenterTerms(gdb *gorm.DB, services, resources []string) (outServices, outResources, unknownServices, unknownResources []string, err error) {
+	idx, err := taxonomy.Load(gdb)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	outServices, outResources = services, resources
+	unknownServices, unknownResources = []string{}, []string{}
+	if !idx.Empty(db.TaxonomyService) {
+		outServices, unknownServices = idx.Canonicalize(db.TaxonomyService, services)
+	}
+	if !idx.Empty(db.TaxonomyResource) {
+		outResources, unknownResources = idx.Canonicalize(db.TaxonomyResource, resources)
+	}
+	return outServices, outResources, unknownServices, unknownResources, nil
+}
@@ -0,0 +1,368 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// Thread management: participants, subject, and each participating center's
+// own archive and mute settings. Changes to the thread itself are recorded as
+// system notes in it; every change is broadcast to the thread's subscribers.
+
+const (
+	minThreadSubject = 5 // As when creating a thread
+	maxThreadSubject = 500
+)
+
+// POST /api/messages/thread-messages/:threadId/participants - Invite centers to a thread
+func AddThreadParticipants(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req struct {
+		CenterIDs []string `json:"centerIds" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	thread := findAccessibleThreadFromParam(c, gdb)
+	if thread == nil {
+		return
+	}
+
+	var added []uuid.UUID
+	var names []string
+	for _, raw := range req.CenterIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid centerIds"})
+			return
+		}
+		if isThreadParticipant(thread, id) || slices.Contains(added, id) {
+			continue
+		}
+		center, err := db.FindCenterByID(gdb, id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch center"})
+			return
+		}
+		if center == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "center not found"})
+			return
+		}
+		added = append(added, id)
+		names = append(names, center.Name)
+	}
+	if len(added) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "centers are already participants"})
+		return
+	}
+
+	note, err := db.AddThreadParticipants(gdb, thread.ID, added, callerUserID(c), "Added to the thread: "+strings.Join(names, ", "))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add participants"})
+		return
+	}
+
+	respondThreadChange(c, gdb, thread.ID, note, gin.H{"action": "participants-added", "centerIds": added}, added)
+}
+
+// DELETE /api/messages/thread-messages/:threadId/participants/:centerId - Remove a center from a thread (managers may only take out their own)
+func RemoveThreadParticipant(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	thread := findAccessibleThreadFromParam(c, gdb)
+	if thread == nil {
+		return
+	}
+	centerID, err := uuid.Parse(c.Param("centerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid center id"})
+		return
+	}
+
+	var center *db.CommunityCenter
+	for i := range thread.Participants {
+		if thread.Participants[i].ID == centerID {
+			center = &thread.Participants[i]
+		}
+	}
+	if center == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "center is not a participant in this thread"})
+		return
+	}
+	if !canManageCenter(c, center) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "only the center's manager can remove it from a thread"})
+		return
+	}
+	if len(thread.Participants) <= 2 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a thread needs at least two participants; archive it instead"})
+		return
+	}
+
+	text := center.Name + " left the thread"
+	if ctxutil.RoleFrom(c) == "ADMIN" {
+		text = center.Name + " was removed from the thread"
+	}
+	note, err := db.RemoveThreadParticipant(gdb, thread.ID, centerID, callerUserID(c), text)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove participant"})
+		return
+	}
+
+	// Its manager stops receiving the thread's events, unless they still
+	// manage another participant
+	if br := ctxutil.BrokerFrom(c); br != nil && center.ManagerID != nil && !managesOtherParticipant(thread, *center.ManagerID, centerID) {
+		br.UnsubscribeThread(center.ManagerID.String(), thread.ID.String())
+	}
+
+	// The removed center no longer follows the thread, so it is told directly
+	respondThreadChange(c, gdb, thread.ID, note, gin.H{"action": "participant-removed", "centerId": centerID}, []uuid.UUID{centerID})
+}
+
+// PATCH /api/messages/thread-messages/:threadId/subject - Rename a thread
+func RenameThread(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	var req struct {
+		Subject string `json:"subject" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	subject := strings.TrimSpace(req.Subject)
+	if n := len([]rune(subject)); n < minThreadSubject || n > maxThreadSubject {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("subject must be %d to %d characters", minThreadSubject, maxThreadSubject)})
+		return
+	}
+
+	thread := findAccessibleThreadFromParam(c, gdb)
+	if thread == nil {
+		return
+	}
+	if subject == thread.Subject {
+		c.JSON(http.StatusOK, gin.H{"message": "Subject unchanged", "thread": threadSummary(thread)})
+		return
+	}
+
+	note, err := db.RenameThread(gdb, thread.ID, subject, callerUserID(c), fmt.Sprintf("Subject changed to %q", subject))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to rename thread"})
+		return
+	}
+
+	respondThreadChange(c, gdb, thread.ID, note, gin.H{"action": "subject-changed", "subject": subject}, nil)
+}
+
+// PUT /api/messages/thread-messages/:threadId/preferences - Archive or mute a thread for one of the caller's centers
+func UpdateThreadPreferences(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	// The center defaults as for MarkThreadRead. mutedUntil mutes until then;
+	// muted alone mutes until unmuted.
+	var req struct {
+		CenterID   string     `json:"centerId"`
+		Archived   *bool      `json:"archived"`
+		Muted      *bool      `json:"muted"`
+		MutedUntil *time.Time `json:"mutedUntil"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if req.Archived == nil && req.Muted == nil && req.MutedUntil == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "set archived, muted or mutedUntil"})
+		return
+	}
+	now := time.Now()
+	if req.MutedUntil != nil {
+		if req.Muted != nil && !*req.Muted {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "mutedUntil cannot be set when unmuting"})
+			return
+		}
+		if !req.MutedUntil.After(now) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "mutedUntil must be in the future"})
+			return
+		}
+	}
+
+	thread := findAccessibleThreadFromParam(c, gdb)
+	if thread == nil {
+		return
+	}
+	center, status, msg := readingCenter(c, thread, req.CenterID)
+	if center == nil {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	state := &db.ThreadParticipantState{ThreadID: thread.ID, CenterID: center.ID, UpdatedBy: callerUserID(c)}
+	var columns []string
+	if req.Archived != nil {
+		if *req.Archived {
+			state.ArchivedAt = &now
+		}
+		columns = append(columns, "archived_at")
+	}
+	if req.Muted != nil || req.MutedUntil != nil {
+		state.Muted = req.Muted == nil || *req.Muted
+		state.MutedUntil = req.MutedUntil
+		columns = append(columns, "muted", "muted_until")
+	}
+	saved, err := db.SaveThreadState(gdb, state, columns...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update thread settings"})
+		return
+	}
+
+	preferences := gin.H{
+		"threadId":   thread.ID,
+		"centerId":   center.ID,
+		"archived":   saved.IsArchived(thread.LastActivity),
+		"muted":      saved.IsMuted(now),
+		"mutedUntil": saved.MutedUntil,
+	}
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		br.EmitThreadUpdate(thread.ID.String(), []string{center.ID.String()}, gin.H{
+			"threadId":    thread.ID,
+			"action":      "preferences-updated",
+			"preferences": preferences,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Thread preferences updated", "preferences": preferences})
+}
+
+// findAccessibleThreadFromParam loads the thread named by the :threadId
+// parameter, writing the error response and returning nil when it does not
+// exist or the caller may not access it
+func findAccessibleThreadFromParam(c *gin.Context, gdb *gorm.DB) *db.MessageThread {
+	threadID, err := uuid.Parse(c.Param("threadId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid thread id"})
+		return nil
+	}
+	thread, err := db.FindThreadByID(gdb, threadID)
+	if err != nil || thread == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "thread not found or access denied"})
+		return nil
+	}
+	if !canAccessThread(c, thread) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "thread not found or access denied"})
+		return nil
+	}
+	return thread
+}
+
+// respondThreadChange reloads a changed thread, broadcasts its system note and
+// the change, and responds with both. The change goes to the thread's
+// subscribers and those of its participants plus any extra centers.
+func respondThreadChange(c *gin.Context, gdb *gorm.DB, threadID uuid.UUID, note *db.CenterMessage, change gin.H, extra []uuid.UUID) {
+	thread, err := db.FindThreadByID(gdb, threadID)
+	if err != nil || thread == nil {
+		log.Printf("Failed to reload thread %s: %v", threadID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch thread"})
+		return
+	}
+	summary := threadSummary(thread)
+	noteJSON := gin.H{
+		"id":          note.ID,
+		"threadId":    note.ThreadID,
+		"senderId":    note.SenderID,
+		"senderName":  senderName(note),
+		"senderType":  senderType(note),
+		"content":     note.Content,
+		"timestamp":   note.CreatedAt,
+		"read":        false,
+		"readBy":      []uuid.UUID{},
+		"attachments": []gin.H{},
+	}
+
+	if br := ctxutil.BrokerFrom(c); br != nil {
+		centerIDs := make([]string, 0, len(thread.Participants)+len(extra))
+		for _, p := range thread.Participants {
+			centerIDs = append(centerIDs, p.ID.String())
+		}
+		for _, id := range extra {
+			centerIDs = append(centerIDs, id.String())
+		}
+		change["threadId"] = thread.ID
+		change["thread"] = summary
+		br.EmitNewMessage(thread.ID.String(), noteJSON)
+		br.EmitThreadUpdate(thread.ID.String(), centerIDs, change)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Thread updated", "thread": summary, "note": noteJSON})
+}
+
+// managesOtherParticipant reports whether the user manages a participant of
+// the thread other than the given center
+func managesOtherParticipant(thread *db.MessageThread, userID, centerID uuid.UUID) bool {
+	for _, p := range thread.Participants {
+		if p.ID != centerID && p.ManagerID != nil && *p.ManagerID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// threadSummary describes a thread without its messages
+func threadSummary(thread *db.MessageThread) gin.H {
+	participantIDs := make([]uuid.UUID, len(thread.Participants))
+	participantNames := make([]string, len(thread.Participants))
+	for i, p := range thread.Participants {
+		participantIDs[i] = p.ID
+		participantNames[i] = p.Name
+	}
+	return gin.H{
+		"id":               thread.ID,
+		"participants":     participantIDs,
+		"participantNames": participantNames,
+		"subject":          thread.Subject,
+		"lastActivity":     thread.LastActivity,
+		"messageCount":     thread.MessageCount,
+	}
+}
+
+// threadStates maps a center's thread settings by thread; threads it never
+// changed map to the zero state
+func threadStates(gdb *gorm.DB, centerID uuid.UUID) (map[uuid.UUID]db.ThreadParticipantState, error) {
+	states, err := db.ListThreadStates(gdb, []uuid.UUID{centerID})
+	if err != nil {
+		return nil, err
+	}
+	byThread := make(map[uuid.UUID]db.ThreadParticipantState, len(states))
+	for _, state := range states {
+		byThread[state.ThreadID] = state
+	}
+	return byThread, nil
+}
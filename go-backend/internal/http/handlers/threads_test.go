@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestThreadManagement(t *testing.T) {
+	now := time.Now()
+	f, thread, _ := newThreadFixture(t, now)
+	path := "/api/messages/thread-messages/" + thread
+
+	if w := f.do(t, f.managerC, "POST", path+"/participants", map[string][]string{"centerIds": {f.hubC}}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a non-participant to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerA, "POST", path+"/participants", map[string][]string{"centerIds": {f.hubB}}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected re-adding a participant to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerB, "DELETE", path+"/participants/"+f.hubA, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected removing another manager's hub to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerA, "DELETE", path+"/participants/"+f.hubA, nil); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a two-party thread to keep both hubs, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerA, "PATCH", path+"/subject", map[string]string{"subject": "MoU"}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected a short subject to be refused, got %d", w.Code)
+	}
+	if n := f.fake.writeCount("center_messages"); n != 0 {
+		t.Fatalf("expected no notes for refused changes, got %d writes", n)
+	}
+
+	w := f.do(t, f.managerA, "POST", path+"/participants", map[string][]string{"centerIds": {f.hubC}})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"senderType":"system"`) {
+		t.Fatalf("expected hub C to be added with a system note, got %d: %s", w.Code, w.Body.String())
+	}
+	w = f.do(t, f.managerB, "PATCH", path+"/subject", map[string]string{"subject": "Signed MoU"})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `Subject changed to \"Signed MoU\"`) {
+		t.Fatalf("expected the thread to be renamed with a note, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := f.fake.writeCount("center_messages"); n != 2 {
+		t.Fatalf("expected a note per change, got %d writes", n)
+	}
+
+	// A hub that leaves stops receiving the thread's events
+	f.fake.insert("message_thread_participants", map[string]interface{}{"message_thread_id": thread, "community_center_id": f.hubC})
+	client := f.broker.AddClient(f.managerC)
+	f.broker.SubscribeThread(f.managerC, thread)
+	if w := f.do(t, f.managerC, "DELETE", path+"/participants/"+f.hubC, nil); w.Code != http.StatusOK {
+		t.Fatalf("expected hub C to leave, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, ok := client.Threads[thread]; ok {
+		t.Fatalf("expected hub C's manager to be unsubscribed from the thread")
+	}
+}
+
+func TestThreadPreferences(t *testing.T) {
+	now := time.Now()
+	f, thread, _ := newThreadFixture(t, now.Add(-time.Minute))
+	f.fake.insert("thread_participant_states", map[string]interface{}{
+		"id": uuid.NewString(), "thread_id": thread, "center_id": f.hubB, "archived_at": now, "muted": true,
+		"muted_until": nil, "updated_by": f.managerB, "created_at": now, "updated_at": now,
+	})
+	path := "/api/messages/thread-messages/" + thread + "/preferences"
+
+	if w := f.do(t, f.managerC, "PUT", path, map[string]bool{"archived": true}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a non-participant to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.managerB, "PUT", path, map[string]interface{}{"archived": true, "centerId": f.hubA}); w.Code != http.StatusForbidden {
+		t.Fatalf("expected archiving for another hub to be refused, got %d", w.Code)
+	}
+	if w := f.do(t, f.admin, "PUT", path, map[string]bool{"archived": true}); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected an admin to name the center, got %d", w.Code)
+	}
+	for _, body := range []map[string]interface{}{{}, {"mutedUntil": now.Add(-time.Hour)}, {"muted": false, "mutedUntil": now.Add(time.Hour)}} {
+		if w := f.do(t, f.managerB, "PUT", path, body); w.Code != http.StatusBadRequest {
+			t.Errorf("%v: expected 400, got %d", body, w.Code)
+		}
+	}
+	if n := f.fake.writeCount("thread_participant_states"); n != 0 {
+		t.Fatalf("expected no settings writes, got %d", n)
+	}
+
+	w := f.do(t, f.managerB, "PUT", path, map[string]bool{"archived": true, "muted": true})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"archived":true`) || !strings.Contains(w.Body.String(), `"muted":true`) {
+		t.Fatalf("expected hub B to archive and mute the thread, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,760 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/ctxutil"
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/directory"
+	"communitycentresplatform/go-backend/internal/media"
+	"communitycentresplatform/go-backend/internal/storage"
+)
+
+// uploadLinkTTL is how long signed download links stay valid
+const uploadLinkTTL = time.Hour
+
+// POST /api/uploads - Upload a file (multipart "file"), optionally attaching it via "entityType" and "entityId"
+func CreateUpload(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+	store := ctxutil.StorageFrom(c)
+	if store == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "storage unavailable"})
+		return
+	}
+	if !parseUploadForm(c) {
+		return
+	}
+
+	var entityType db.UploadEntity
+	var entityID *uuid.UUID
+	if raw := strings.TrimSpace(c.PostForm("entityType")); raw != "" {
+		entityType = db.UploadEntity(strings.ToUpper(raw))
+		id, err := uuid.Parse(c.PostForm("entityId"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entity id"})
+			return
+		}
+		if status, msg := canAttachUpload(c, gdb, entityType, id); status != 0 {
+			c.JSON(status, gin.H{"error": msg})
+			return
+		}
+		entityID = &id
+	}
+
+	stored := receiveUpload(c, store, "uploads")
+	if stored == nil {
+		return
+	}
+	uploaderID, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	upload := db.Upload{
+		UploadedBy:   uploaderID,
+		EntityType:   entityType,
+		EntityID:     entityID,
+		StorageKey:   stored.Key,
+		ThumbnailKey: stored.ThumbnailKey,
+		FileName:     stored.FileName,
+		ContentType:  stored.ContentType,
+		Kind:         stored.Kind,
+		SizeBytes:    stored.Size,
+		Width:        stored.Width,
+		Height:       stored.Height,
+	}
+	if err := db.CreateUpload(gdb, &upload); err != nil {
+		discardStored(c, store, stored.keys()...)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save file"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "File uploaded successfully",
+		"upload":  transformUpload(c, &upload),
+	})
+}
+
+// GET /api/uploads?entityType=&entityId= - List the files attached to an activity, center, entrepreneur or message
+func GetUploads(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	entityType := db.UploadEntity(strings.ToUpper(strings.TrimSpace(c.Query("entityType"))))
+	entityID, err := uuid.Parse(c.Query("entityId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entity id"})
+		return
+	}
+	if status, msg := canViewUploadEntity(c, gdb, entityType, entityID); status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return
+	}
+
+	uploads, err := db.ListUploadsFor(gdb, entityType, []uuid.UUID{entityID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch files"})
+		return
+	}
+	transformed := make([]gin.H, len(uploads))
+	for i := range uploads {
+		transformed[i] = transformUpload(c, &uploads[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"uploads": transformed, "total": len(uploads)})
+}
+
+// GET /api/uploads/:id - Get a file's details and signed download links
+func GetUpload(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	upload := findUploadForCaller(c, gdb)
+	if upload == nil {
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"upload": transformUpload(c, upload)})
+}
+
+// GET /api/uploads/:id/content - Download a file through a signed link (?variant=thumbnail for the preview)
+func GetUploadContent(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+	store := ctxutil.StorageFrom(c)
+	if store == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "storage unavailable"})
+		return
+	}
+
+	uploadID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload id"})
+		return
+	}
+	variant := c.DefaultQuery("variant", "original")
+	// The signature is the access check; it was issued to someone allowed to
+	// see the file
+	err = storage.VerifyResource(ctxutil.JWTSecretFrom(c), uploadResource(uploadID, variant), c.Request.URL.Query(), time.Now())
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	upload, err := db.FindUploadByID(gdb, uploadID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch file"})
+		return
+	}
+	if upload == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return
+	}
+
+	key, contentType := upload.StorageKey, upload.ContentType
+	if variant == "thumbnail" {
+		if upload.ThumbnailKey == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "file has no thumbnail"})
+			return
+		}
+		key, contentType = *upload.ThumbnailKey, ""
+	}
+	serveStored(c, store, key, contentType, upload.FileName)
+}
+
+// DELETE /api/uploads/:id - Delete a file (its uploader, ADMIN, or whoever may attach files to its entity)
+func DeleteUpload(c *gin.Context) {
+	gdb := ctxutil.DBFrom(c)
+	if gdb == nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db unavailable"})
+		return
+	}
+
+	upload := findUploadForCaller(c, gdb)
+	if upload == nil {
+		return
+	}
+	uid, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	if upload.UploadedBy != uid && ctxutil.RoleFrom(c) != string(db.RoleAdmin) {
+		if upload.EntityID == nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "you cannot delete this file"})
+			return
+		}
+		if status, _ := canAttachUpload(c, gdb, upload.EntityType, *upload.EntityID); status != 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": "you cannot delete this file"})
+			return
+		}
+	}
+
+	if err := db.DeleteUploads(gdb, []uuid.UUID{upload.ID}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete file"})
+		return
+	}
+	discardStored(c, ctxutil.StorageFrom(c), uploadKeys(upload)...)
+
+	c.JSON(http.StatusOK, gin.H{"message": "File deleted successfully"})
+}
+
+// findUploadForCaller loads the upload named by the :id parameter, treating
+// files the caller may not see as missing. It writes the error response and
+// returns nil when it cannot.
+func findUploadForCaller(c *gin.Context, gdb *gorm.DB) *db.Upload {
+	uploadID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload id"})
+		return nil
+	}
+	upload, err := db.FindUploadByID(gdb, uploadID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch file"})
+		return nil
+	}
+	if upload == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return nil
+	}
+
+	if upload.EntityID == nil {
+		uid, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+		if upload.UploadedBy != uid && ctxutil.RoleFrom(c) != string(db.RoleAdmin) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+			return nil
+		}
+		return upload
+	}
+	status, msg := canViewUploadEntity(c, gdb, upload.EntityType, *upload.EntityID)
+	if status == http.StatusForbidden || status == http.StatusNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		return nil
+	}
+	if status != 0 {
+		c.JSON(status, gin.H{"error": msg})
+		return nil
+	}
+	return upload
+}
+
+// canAttachUpload checks the caller may add files to an entity: the
+// activity's author, the center's manager, the entrepreneur, or the manager
+// of a message's sending center. Admins may attach to anything. It returns a
+// zero status when allowed.
+func canAttachUpload(c *gin.Context, gdb *gorm.DB, entityType db.UploadEntity, id uuid.UUID) (int, string) {
+	isAdmin := ctxutil.RoleFrom(c) == string(db.RoleAdmin)
+	uid, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+
+	switch entityType {
+	case db.UploadForActivity:
+		var activity db.HubActivity
+		if err := gdb.Where("id = ?", id).First(&activity).Error; err != nil {
+			return http.StatusNotFound, "activity not found"
+		}
+		if !isAdmin && activity.CreatedBy != uid {
+			return http.StatusForbidden, "you can only attach files to your own activities"
+		}
+	case db.UploadForCenter:
+		center, err := db.FindCenterByID(gdb, id)
+		if err != nil {
+			return http.StatusInternalServerError, "failed to fetch center"
+		}
+		if center == nil {
+			return http.StatusNotFound, "center not found"
+		}
+		if !canManageCenter(c, center) {
+			return http.StatusForbidden, "only the center's manager can attach files"
+		}
+	case db.UploadForEntrepreneur:
+		var count int64
+		if err := gdb.Model(&db.Entrepreneur{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return http.StatusInternalServerError, "failed to fetch entrepreneur"
+		}
+		if count == 0 {
+			return http.StatusNotFound, "entrepreneur not found"
+		}
+		owner, err := isEntrepreneurOwner(c, gdb, id)
+		if err != nil {
+			return http.StatusInternalServerError, "failed to check permissions"
+		}
+		if !isAdmin && !owner {
+			return http.StatusForbidden, "only the entrepreneur can attach files to their profile"
+		}
+	case db.UploadForMessage:
+		var message db.CenterMessage
+		if err := gdb.Where("id = ?", id).First(&message).Error; err != nil || message.DeletedAt != nil {
+			return http.StatusNotFound, "message not found"
+		}
+		if message.SenderID == nil {
+			if !isAdmin {
+				return http.StatusForbidden, "only the sender can attach files to a message"
+			}
+			break
+		}
+		manages, err := canManageCenterID(c, gdb, *message.SenderID)
+		if err != nil {
+			return http.StatusInternalServerError, "failed to check permissions"
+		}
+		if !manages {
+			return http.StatusForbidden, "only the sender can attach files to a message"
+		}
+	default:
+		return http.StatusBadRequest, "entityType must be ACTIVITY, CENTER, ENTREPRENEUR or MESSAGE"
+	}
+	return 0, ""
+}
+
+// canViewUploadEntity checks the caller may see an entity's files. Activities
+// and centers are public; entrepreneurs follow their directory listing and
+// messages their thread. It returns a zero status when allowed.
+func canViewUploadEntity(c *gin.Context, gdb *gorm.DB, entityType db.UploadEntity, id uuid.UUID) (int, string) {
+	switch entityType {
+	case db.UploadForActivity:
+		var count int64
+		if err := gdb.Model(&db.HubActivity{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return http.StatusInternalServerError, "failed to fetch activity"
+		}
+		if count == 0 {
+			return http.StatusNotFound, "activity not found"
+		}
+	case db.UploadForCenter:
+		center, err := db.FindCenterByID(gdb, id)
+		if err != nil {
+			return http.StatusInternalServerError, "failed to fetch center"
+		}
+		if center == nil {
+			return http.StatusNotFound, "center not found"
+		}
+	case db.UploadForEntrepreneur:
+		var entrepreneur db.Entrepreneur
+		if err := gdb.Where("id = ?", id).First(&entrepreneur).Error; err != nil {
+			return http.StatusNotFound, "entrepreneur not found"
+		}
+		audience, err := entrepreneurAudience(c, gdb, &entrepreneur)
+		if err != nil {
+			return http.StatusInternalServerError, "failed to check permissions"
+		}
+		if !directory.Listed(&entrepreneur, audience) {
+			return http.StatusNotFound, "entrepreneur not found"
+		}
+	case db.UploadForMessage:
+		var message db.CenterMessage
+		if err := gdb.Where("id = ?", id).First(&message).Error; err != nil || message.DeletedAt != nil {
+			return http.StatusNotFound, "message not found"
+		}
+		thread, err := db.FindThreadByID(gdb, message.ThreadID)
+		if err != nil || thread == nil {
+			return http.StatusNotFound, "thread not found or access denied"
+		}
+		if !canAccessThread(c, thread) {
+			return http.StatusForbidden, "thread not found or access denied"
+		}
+	default:
+		return http.StatusBadRequest, "entityType must be ACTIVITY, CENTER, ENTREPRENEUR or MESSAGE"
+	}
+	return 0, ""
+}
+
+// claimUploads checks that the raw ids name files the caller uploaded and
+// has not attached yet, so they can be attached to something new. It writes
+// the error response and returns false when they cannot.
+func claimUploads(c *gin.Context, gdb *gorm.DB, raw []string) ([]uuid.UUID, bool) {
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, s := range raw {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload id"})
+			return nil, false
+		}
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	uploads, err := db.ListUploadsByID(gdb, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch files"})
+		return nil, false
+	}
+	found := make(map[uuid.UUID]bool, len(uploads))
+	uid, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	for _, u := range uploads {
+		if u.UploadedBy != uid || u.EntityID != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "files must be your own unattached uploads"})
+			return nil, false
+		}
+		found[u.ID] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+			return nil, false
+		}
+	}
+	return ids, true
+}
+
+// attachmentsFor renders the files attached to each of the given entities
+func attachmentsFor(c *gin.Context, gdb *gorm.DB, entityType db.UploadEntity, ids []uuid.UUID) (map[uuid.UUID][]gin.H, error) {
+	uploads, err := db.ListUploadsFor(gdb, entityType, ids)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uuid.UUID][]gin.H, len(ids))
+	for _, id := range ids {
+		out[id] = []gin.H{}
+	}
+	for i := range uploads {
+		u := &uploads[i]
+		out[*u.EntityID] = append(out[*u.EntityID], transformUpload(c, u))
+	}
+	return out, nil
+}
+
+// deleteAttachments removes the files attached to an entity that is being
+// deleted
+func deleteAttachments(c *gin.Context, gdb *gorm.DB, entityType db.UploadEntity, id uuid.UUID) error {
+	uploads, err := db.ListUploadsFor(gdb, entityType, []uuid.UUID{id})
+	if err != nil {
+		return err
+	}
+	ids := make([]uuid.UUID, len(uploads))
+	var keys []string
+	for i := range uploads {
+		ids[i] = uploads[i].ID
+		keys = append(keys, uploadKeys(&uploads[i])...)
+	}
+	if err := db.DeleteUploads(gdb, ids); err != nil {
+		return err
+	}
+	discardStored(c, ctxutil.StorageFrom(c), keys...)
+	return nil
+}
+
+func transformUpload(c *gin.Context, u *db.Upload) gin.H {
+	expires := time.Now().Add(uploadLinkTTL)
+	out := gin.H{
+		"id":          u.ID,
+		"fileName":    u.FileName,
+		"contentType": u.ContentType,
+		"kind":        u.Kind,
+		"size":        u.SizeBytes,
+		"width":       u.Width,
+		"height":      u.Height,
+		"entityType":  u.EntityType,
+		"entityId":    u.EntityID,
+		"uploadedBy":  u.UploadedBy,
+		"createdAt":   u.CreatedAt,
+		"url":         uploadLink(c, u.ID, u.StorageKey, "original", expires),
+		"expiresAt":   expires,
+	}
+	if u.ThumbnailKey != nil {
+		out["thumbnailUrl"] = uploadLink(c, u.ID, *u.ThumbnailKey, "thumbnail", expires)
+	}
+	return out
+}
+
+// uploadLink returns a time-limited link to one variant of a file: a
+// presigned URL when the backend supports them, otherwise a signed link to
+// GetUploadContent
+func uploadLink(c *gin.Context, id uuid.UUID, key, variant string, expires time.Time) string {
+	if p, ok := ctxutil.StorageFrom(c).(storage.Presigner); ok {
+		if u, err := p.PresignGet(key, time.Until(expires)); err == nil {
+			return u
+		}
+	}
+	q := storage.SignResource(ctxutil.JWTSecretFrom(c), uploadResource(id, variant), expires)
+	if variant != "original" {
+		q.Set("variant", variant)
+	}
+	return "/api/uploads/" + id.String() + "/content?" + q.Encode()
+}
+
+func uploadResource(id uuid.UUID, variant string) string {
+	return "upload:" + id.String() + ":" + variant
+}
+
+func uploadKeys(u *db.Upload) []string {
+	keys := []string{u.StorageKey}
+	if u.ThumbnailKey != nil {
+		keys = append(keys, *u.ThumbnailKey)
+	}
+	return keys
+}
+
+// storedFile is an upload saved to blob storage, not yet recorded
+type storedFile struct {
+	Key          string
+	ThumbnailKey *string
+	FileName     string
+	ContentType  string
+	Kind         db.MediaKind
+	Size         int64
+	Width        *int
+	Height       *int
+}
+
+func (s *storedFile) keys() []string {
+	keys := []string{s.Key}
+	if s.ThumbnailKey != nil {
+		keys = append(keys, *s.ThumbnailKey)
+	}
+	return keys
+}
+
+// parseUploadForm reads a multipart body, capped a little above the file
+// size limit. It writes the error response and returns false on failure.
+func parseUploadForm(c *gin.Context) bool {
+	return parseMultipartBody(c, media.MaxBytes+1<<20, media.ErrTooLarge)
+}
+
+// parseMultipartBody reads a multipart body of at most limit bytes,
+// answering tooLarge beyond that
+func parseMultipartBody(c *gin.Context, limit int64, tooLarge error) bool {
+	if c.Request.MultipartForm != nil {
+		return true
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+	if _, err := c.MultipartForm(); err != nil {
+		var maxBytes *http.MaxBytesError
+		if errors.As(err, &maxBytes) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": tooLarge.Error()})
+			return false
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expected a multipart form with a file"})
+		return false
+	}
+	return true
+}
+
+// formFile reads the multipart "file" field. It writes the error response
+// and returns nil when there is none.
+func formFile(c *gin.Context) *multipart.FileHeader {
+	if !parseUploadForm(c) {
+		return nil
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return nil
+	}
+	return header
+}
+
+// openUploadedFile opens an uploaded file and works out its type from its
+// contents, leaving it positioned at the start. It writes the error response
+// and returns a nil file when it is rejected; otherwise the caller closes it.
+func openUploadedFile(c *gin.Context, header *multipart.FileHeader) (multipart.File, string, db.MediaKind) {
+	if header.Size > media.MaxBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": media.ErrTooLarge.Error()})
+		return nil, "", ""
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return nil, "", ""
+	}
+
+	head := make([]byte, media.SniffBytes)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		file.Close()
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return nil, "", ""
+	}
+	contentType, kind, err := media.Detect(head[:n], header.Filename)
+	if err != nil {
+		file.Close()
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+		return nil, "", ""
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read file"})
+		return nil, "", ""
+	}
+	return file, contentType, kind
+}
+
+// receiveUpload validates the multipart "file" field by its contents and
+// stores it under prefix, along with a thumbnail for images. It writes the
+// error response and returns nil when the file is rejected.
+func receiveUpload(c *gin.Context, store storage.Blob, prefix string) *storedFile {
+	header := formFile(c)
+	if header == nil {
+		return nil
+	}
+	return receiveFile(c, store, prefix, header)
+}
+
+// receiveFile is receiveUpload for one file of a multipart form
+func receiveFile(c *gin.Context, store storage.Blob, prefix string, header *multipart.FileHeader) *storedFile {
+	file, contentType, kind := openUploadedFile(c, header)
+	if file == nil {
+		return nil
+	}
+	defer file.Close()
+
+	stored := &storedFile{
+		Key:         storage.NewKey(prefix, header.Filename),
+		FileName:    media.SafeFileName(header.Filename),
+		ContentType: contentType,
+		Kind:        kind,
+		Size:        header.Size,
+	}
+	var body io.Reader = file
+	var thumb *media.Rendition
+	if kind == db.MediaImage {
+		data, err := io.ReadAll(file)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read file"})
+			return nil
+		}
+		body = bytes.NewReader(data)
+		img, format, err := media.DecodeImage(data)
+		switch {
+		case err == nil:
+			w, h := img.Bounds().Dx(), img.Bounds().Dy()
+			stored.Width, stored.Height = &w, &h
+			if r, err := media.Render(img, format, media.ThumbnailSize); err == nil {
+				thumb = &r
+			}
+		case errors.Is(err, image.ErrFormat):
+			// No decoder for this format (WebP); keep it without a thumbnail
+		case errors.Is(err, media.ErrTooManyPixels):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return nil
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "image could not be read"})
+			return nil
+		}
+	}
+
+	ctx := c.Request.Context()
+	if err := store.Put(ctx, stored.Key, body, header.Size, contentType); err != nil {
+		log.Printf("Failed to store upload: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to store file"})
+		return nil
+	}
+	if thumb != nil {
+		ext := ".jpg"
+		if thumb.ContentType == "image/png" {
+			ext = ".png"
+		}
+		key := strings.TrimSuffix(stored.Key, path.Ext(stored.Key)) + "-thumb" + ext
+		// The original is what matters; carry on without a preview
+		if err := store.Put(ctx, key, bytes.NewReader(thumb.Data), int64(len(thumb.Data)), thumb.ContentType); err != nil {
+			log.Printf("Failed to store thumbnail: %v", err)
+		} else {
+			stored.ThumbnailKey = &key
+		}
+	}
+	return stored
+}
+
+// saveUploads stores files from a multipart form under prefix and records
+// them as the caller's unattached uploads, ready to be attached to what is
+// being created. It writes the error response and returns false when any
+// file is rejected, leaving none of them behind.
+func saveUploads(c *gin.Context, gdb *gorm.DB, store storage.Blob, prefix string, headers []*multipart.FileHeader) ([]db.Upload, bool) {
+	uploaderID, _ := uuid.Parse(ctxutil.UserIDFrom(c))
+	uploads := make([]db.Upload, 0, len(headers))
+	for _, header := range headers {
+		stored := receiveFile(c, store, prefix, header)
+		if stored == nil {
+			discardUploads(c, gdb, uploads)
+			return nil, false
+		}
+		upload := db.Upload{
+			UploadedBy:   uploaderID,
+			StorageKey:   stored.Key,
+			ThumbnailKey: stored.ThumbnailKey,
+			FileName:     stored.FileName,
+			ContentType:  stored.ContentType,
+			Kind:         stored.Kind,
+			SizeBytes:    stored.Size,
+			Width:        stored.Width,
+			Height:       stored.Height,
+		}
+		if err := db.CreateUpload(gdb, &upload); err != nil {
+			discardStored(c, store, stored.keys()...)
+			discardUploads(c, gdb, uploads)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to save file"})
+			return nil, false
+		}
+		uploads = append(uploads, upload)
+	}
+	return uploads, true
+}
+
+// discardUploads deletes uploads made by saveUploads when what they were
+// for could not be created
+func discardUploads(c *gin.Context, gdb *gorm.DB, uploads []db.Upload) {
+	ids := make([]uuid.UUID, len(uploads))
+	var keys []string
+	for i := range uploads {
+		ids[i] = uploads[i].ID
+		keys = append(keys, uploadKeys(&uploads[i])...)
+	}
+	if err := db.DeleteUploads(gdb, ids); err != nil {
+		log.Printf("Failed to delete upload records: %v", err)
+	}
+	discardStored(c, ctxutil.StorageFrom(c), keys...)
+}
+
+// discardStored deletes stored files whose records are gone or were never
+// written; failures only leave unreachable files behind, so they are logged
+func discardStored(c *gin.Context, store storage.Blob, keys ...string) {
+	if store == nil {
+		return
+	}
+	for _, key := range keys {
+		if err := store.Delete(c.Request.Context(), key); err != nil {
+			log.Printf("Failed to delete stored file %s: %v", key, err)
+		}
+	}
+}
+
+// serveStored streams a stored file. An empty contentType uses the one the
+// backend reports.
+func serveStored(c *gin.Context, store storage.Blob, key, contentType, fileName string) {
+	body, obj, err := store.Get(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read file"})
+		return
+	}
+	defer body.Close()
+	if contentType == "" {
+		contentType = obj.ContentType
+	}
+
+	disposition := "inline"
+	if c.Query("download") == "true" {
+		disposition = "attachment"
+	}
+	c.DataFromReader(http.StatusOK, obj.Size, contentType, body, map[string]string{
+		"Content-Disposition":    disposition + `; filename="` + fileName + `"`,
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, max-age=3600",
+	})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUploadAttachmentsAndLinks(t *testing.T) {
+	f := newHubFixture(t)
+	photo := testPNG(t)
+
+	// Only the entrepreneur may attach files to their profile, and only a
+	// center's manager to the center
+	denied := []struct {
+		name, user, entityType, entityID string
+	}{
+		{"other entrepreneur's profile", f.otherFounder, "ENTREPRENEUR", f.entrepreneur},
+		{"another hub's center", f.managerB, "CENTER", f.hubA},
+	}
+	for _, tc := range denied {
+		w := f.upload(t, tc.user, "/api/uploads", "photo.png", photo, map[string]string{"entityType": tc.entityType, "entityId": tc.entityID})
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected 403, got %d: %s", tc.name, w.Code, w.Body.String())
+		}
+	}
+	if n := f.fake.writeCount("uploads"); n != 0 {
+		t.Fatalf("expected no writes to uploads, got %d", n)
+	}
+
+	w := f.upload(t, f.founder, "/api/uploads", "photo.png", photo, map[string]string{"entityType": "ENTREPRENEUR", "entityId": f.entrepreneur})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected the entrepreneur to upload, got %d: %s", w.Code, w.Body.String())
+	}
+	var created struct {
+		Upload struct {
+			Width        int    `json:"width"`
+			ThumbnailURL string `json:"thumbnailUrl"`
+		} `json:"upload"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &created)
+	if created.Upload.Width != 640 || created.Upload.ThumbnailURL == "" {
+		t.Fatalf("expected image dimensions and a thumbnail, got %s", w.Body.String())
+	}
+
+	// Files on public centers are readable by anyone through signed links
+	uploadID := f.seedUpload(t, "CENTER", f.hubA, "uploads/floorplan.pdf", "application/pdf", []byte("%PDF-1.7"))
+	w = f.do(t, "", "GET", "/api/uploads/"+uploadID, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected anonymous access to a center file, got %d: %s", w.Code, w.Body.String())
+	}
+	var got struct {
+		Upload struct {
+			URL string `json:"url"`
+		} `json:"upload"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &got)
+	if w := f.do(t, "", "GET", got.Upload.URL, nil); w.Code != http.StatusOK || w.Body.String() != "%PDF-1.7" {
+		t.Fatalf("expected the signed link to serve the file, got %d: %q", w.Code, w.Body.String())
+	}
+	tampered := strings.Replace(got.Upload.URL, "signature=", "signature=0", 1)
+	if w := f.do(t, "", "GET", tampered, nil); w.Code != http.StatusForbidden {
+		t.Fatalf("expected a tampered link to be refused, got %d", w.Code)
+	}
+}
@@ -4,11 +4,13 @@ import (
     "net/http"
     "net/http/httptest"
     "testing"
+
+    "github.com/gin-gonic/gin"
 )
 
 func TestHealthz(t *testing.T) {
     r := NewRouter(Deps{FrontendURL: "http://localhost:3000"})
-    r.GET("/healthz", func(c *Context) {})
+    r.GET("/healthz", func(c *gin.Context) {})
     w := httptest.NewRecorder()
     req, _ := http.NewRequest("GET", "/healthz", nil)
     r.ServeHTTP(w, req)
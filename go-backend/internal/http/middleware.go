@@ -10,6 +10,7 @@ import (
 	"communitycentresplatform/go-backend/internal/auth"
     "communitycentresplatform/go-backend/internal/events"
     "communitycentresplatform/go-backend/internal/ctxutil"
+    "communitycentresplatform/go-backend/internal/storage"
 )
 
 type ContextKeys string
@@ -52,6 +53,25 @@ func AuthMiddleware(secret string) gin.HandlerFunc {
 	}
 }
 
+// OptionalAuthMiddleware sets user claims when a valid bearer token is
+// present and otherwise lets the request through anonymously, for public
+// endpoints that reveal more to signed-in users
+func OptionalAuthMiddleware(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authz := c.GetHeader("Authorization")
+		if strings.HasPrefix(authz, "Bearer ") {
+			token := strings.TrimSpace(strings.TrimPrefix(authz, "Bearer "))
+			if claims, err := auth.ParseJWT(secret, token); err == nil {
+				c.Set(ctxutil.KeyUserID, claims.UserID)
+				c.Set(ctxutil.KeyEmail, claims.Email)
+				c.Set(ctxutil.KeyRole, claims.Role)
+				c.Set(ctxutil.KeyName, claims.Name)
+			}
+		}
+		c.Next()
+	}
+}
+
 // ConfigMiddleware stores config values needed in handlers (e.g., JWT secret, Google Client ID)
 func ConfigMiddleware(jwtSecret string, jwtExpiry string, googleClientID string) gin.HandlerFunc {
     return func(c *gin.Context) {
@@ -70,6 +90,14 @@ func BrokerMiddleware(b *events.Broker) gin.HandlerFunc {
     }
 }
 
+// StorageMiddleware exposes the file store to handlers
+func StorageMiddleware(b storage.Blob) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        c.Set(ctxutil.KeyStorage, b)
+        c.Next()
+    }
+}
+
 // RequireRole ensures the user has one of the allowed roles
 func RequireRole(roles ...string) gin.HandlerFunc {
 	allowed := map[string]struct{}{}
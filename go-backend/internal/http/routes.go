@@ -24,9 +24,24 @@ func RegisterRoutes(r *gin.Engine, d Deps) {
     centers := api.Group("/centers")
 	{
         centers.GET("/", handlers.ListCenters)
+        centers.GET("/facets", handlers.GetCenterFacets)
         centers.GET("/:id", handlers.GetCenter)
+        centers.GET("/:id/recommendations", AuthMiddleware(d.JWTSecret), handlers.GetCenterRecommendations)
+        centers.GET("/:id/hours", handlers.GetCenterHours)
+        centers.GET("/:id/hours/next", handlers.GetCenterNextOpenings)
+        centers.PUT("/:id/hours", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateCenterHours)
+        centers.POST("/:id/hours/exceptions", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.SetCenterHoursException)
+        centers.DELETE("/:id/hours/exceptions/:exceptionId", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.DeleteCenterHoursException)
+        centers.GET("/:id/bookable-resources", handlers.ListBookableResources)
+        centers.POST("/:id/bookable-resources", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateBookableResource)
+        centers.GET("/:id/bookings", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.ListCenterBookings)
+        centers.POST("/:id/images", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UploadCenterImage)
+        centers.GET("/:id/images/:imageId/:size", handlers.GetCenterImage)
+        centers.PUT("/:id/images/:imageId", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateCenterImage)
+        centers.DELETE("/:id/images/:imageId", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.DeleteCenterImage)
+        centers.PUT("/:id/gallery", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.ReorderCenterGallery)
         centers.POST("/", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateCenter)
-        centers.PUT("/", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateCenter)
+        centers.PUT("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateCenter)
         centers.PATCH("/:id/verify", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.VerifyCenter)
         centers.POST("/connect", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.ConnectCenters)
 	}
@@ -45,6 +60,16 @@ func RegisterRoutes(r *gin.Engine, d Deps) {
         // Thread messages - use /thread-messages to avoid conflict
         messages.GET("/thread-messages/:threadId", AuthMiddleware(d.JWTSecret), handlers.GetThreadMessages)
         messages.POST("/thread-messages/:threadId", AuthMiddleware(d.JWTSecret), handlers.SendThreadMessage)
+        messages.GET("/thread-messages/:threadId/attachments/:uploadId", AuthMiddleware(d.JWTSecret), handlers.GetMessageAttachment)
+        messages.POST("/thread-messages/:threadId/read", AuthMiddleware(d.JWTSecret), handlers.MarkThreadRead)
+        messages.PATCH("/thread-messages/:threadId/messages/:messageId", AuthMiddleware(d.JWTSecret), handlers.EditThreadMessage)
+        messages.DELETE("/thread-messages/:threadId/messages/:messageId", AuthMiddleware(d.JWTSecret), handlers.DeleteThreadMessage)
+        messages.GET("/thread-messages/:threadId/messages/:messageId/revisions", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.GetMessageRevisions)
+        messages.PATCH("/thread-messages/:threadId/subject", AuthMiddleware(d.JWTSecret), handlers.RenameThread)
+        messages.POST("/thread-messages/:threadId/participants", AuthMiddleware(d.JWTSecret), handlers.AddThreadParticipants)
+        messages.DELETE("/thread-messages/:threadId/participants/:centerId", AuthMiddleware(d.JWTSecret), handlers.RemoveThreadParticipant)
+        messages.PUT("/thread-messages/:threadId/preferences", AuthMiddleware(d.JWTSecret), handlers.UpdateThreadPreferences)
+        messages.GET("/unread", AuthMiddleware(d.JWTSecret), handlers.GetUnreadCounts)
 	}
 
 	// /api/events
@@ -76,6 +101,37 @@ func RegisterRoutes(r *gin.Engine, d Deps) {
 		entrepreneurs.PATCH("/:id/verify", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.VerifyEntrepreneur)
 	}
 
+	// /api/directory (public; signed-in users see more)
+	directory := api.Group("/directory")
+	directory.Use(OptionalAuthMiddleware(d.JWTSecret))
+	{
+		directory.GET("/entrepreneurs", handlers.GetEntrepreneurDirectory)
+		directory.GET("/entrepreneurs/:id", handlers.GetDirectoryEntrepreneur)
+	}
+
+	// /api/portfolio (reads are public; signed-in users see more)
+	portfolio := api.Group("/portfolio")
+	{
+		portfolio.GET("/entrepreneur/:entrepreneurId", OptionalAuthMiddleware(d.JWTSecret), handlers.GetEntrepreneurPortfolio)
+		portfolio.POST("/", AuthMiddleware(d.JWTSecret), RequireRole("ENTREPRENEUR", "ADMIN"), handlers.CreatePortfolioItem)
+		portfolio.GET("/:id", OptionalAuthMiddleware(d.JWTSecret), handlers.GetPortfolioItem)
+		portfolio.PUT("/:id", AuthMiddleware(d.JWTSecret), handlers.UpdatePortfolioItem)
+		portfolio.DELETE("/:id", AuthMiddleware(d.JWTSecret), handlers.DeletePortfolioItem)
+		portfolio.POST("/:id/media", AuthMiddleware(d.JWTSecret), handlers.UploadPortfolioMedia)
+		portfolio.GET("/:id/media/:mediaId", OptionalAuthMiddleware(d.JWTSecret), handlers.GetPortfolioMedia)
+		portfolio.DELETE("/:id/media/:mediaId", AuthMiddleware(d.JWTSecret), handlers.DeletePortfolioMedia)
+	}
+
+	// /api/uploads (downloads go through signed links)
+	uploads := api.Group("/uploads")
+	{
+		uploads.POST("/", AuthMiddleware(d.JWTSecret), handlers.CreateUpload)
+		uploads.GET("/", OptionalAuthMiddleware(d.JWTSecret), handlers.GetUploads)
+		uploads.GET("/:id", OptionalAuthMiddleware(d.JWTSecret), handlers.GetUpload)
+		uploads.GET("/:id/content", handlers.GetUploadContent)
+		uploads.DELETE("/:id", AuthMiddleware(d.JWTSecret), handlers.DeleteUpload)
+	}
+
 	// /api/enrollments
 	enrollments := api.Group("/enrollments")
 	{
@@ -83,7 +139,140 @@ func RegisterRoutes(r *gin.Engine, d Deps) {
 		enrollments.GET("/hub/:hubId", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.GetHubEnrollments)
 		enrollments.GET("/entrepreneur/:entrepreneurId", AuthMiddleware(d.JWTSecret), handlers.GetEntrepreneurEnrollments)
 		enrollments.GET("/:id", AuthMiddleware(d.JWTSecret), handlers.GetEnrollment)
+		enrollments.GET("/:id/history", AuthMiddleware(d.JWTSecret), handlers.GetEnrollmentHistory)
 		enrollments.PATCH("/:id/status", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateEnrollmentStatus)
+		enrollments.PATCH("/:id/cohort", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.AssignEnrollmentCohort)
+		enrollments.GET("/:id/milestones", AuthMiddleware(d.JWTSecret), handlers.GetEnrollmentMilestones)
+		enrollments.POST("/:id/milestones/:milestoneId/complete", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CompleteEnrollmentMilestone)
+		enrollments.DELETE("/:id/milestones/:milestoneId/complete", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UncompleteEnrollmentMilestone)
+	}
+
+	// /api/programs
+	programs := api.Group("/programs")
+	{
+		programs.GET("/hub/:id", handlers.GetHubPrograms)
+		programs.GET("/:id", handlers.GetProgram)
+		programs.POST("/", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateProgram)
+		programs.PUT("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateProgram)
+		programs.POST("/:id/cohorts", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateCohort)
+	}
+
+	// /api/application-forms
+	applicationForms := api.Group("/application-forms")
+	{
+		applicationForms.GET("/hub/:id", handlers.GetHubApplicationForms)
+		applicationForms.GET("/:id", handlers.GetApplicationForm)
+		applicationForms.POST("/", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateApplicationForm)
+		applicationForms.PUT("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateApplicationForm)
+		applicationForms.GET("/:id/applications", AuthMiddleware(d.JWTSecret), handlers.GetFormApplications)
+	}
+
+	// /api/applications
+	applicationsGroup := api.Group("/applications")
+	{
+		applicationsGroup.POST("/", AuthMiddleware(d.JWTSecret), RequireRole("ENTREPRENEUR"), handlers.SubmitApplication)
+		applicationsGroup.GET("/mine", AuthMiddleware(d.JWTSecret), RequireRole("ENTREPRENEUR"), handlers.GetMyApplications)
+		applicationsGroup.GET("/:id", AuthMiddleware(d.JWTSecret), handlers.GetApplication)
+		applicationsGroup.POST("/:id/reviews", AuthMiddleware(d.JWTSecret), handlers.ReviewApplication)
+		applicationsGroup.PATCH("/:id/advance", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.AdvanceApplication)
+		applicationsGroup.PATCH("/:id/decision", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.DecideApplication)
+		applicationsGroup.PATCH("/:id/withdraw", AuthMiddleware(d.JWTSecret), RequireRole("ENTREPRENEUR"), handlers.WithdrawApplication)
+	}
+
+	// /api/cohorts
+	cohorts := api.Group("/cohorts")
+	{
+		cohorts.GET("/:id", handlers.GetCohort)
+		cohorts.PUT("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateCohort)
+		cohorts.GET("/:id/progress", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.GetCohortProgress)
+		cohorts.POST("/:id/complete", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CompleteCohort)
+		cohorts.POST("/:id/milestones", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateCohortMilestone)
+		cohorts.PUT("/:id/milestones/:milestoneId", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateCohortMilestone)
+		cohorts.DELETE("/:id/milestones/:milestoneId", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.DeleteCohortMilestone)
+	}
+
+	// /api/metric-types
+	metricTypes := api.Group("/metric-types")
+	{
+		metricTypes.GET("/", handlers.GetMetricTypes)
+		metricTypes.POST("/", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.CreateMetricType)
+		metricTypes.PUT("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.UpdateMetricType)
+	}
+
+	// /api/metrics
+	metricsGroup := api.Group("/metrics")
+	metricsGroup.Use(AuthMiddleware(d.JWTSecret))
+	{
+		metricsGroup.POST("/", handlers.SubmitMetricEntry)
+		metricsGroup.GET("/entrepreneur/:id", handlers.GetEntrepreneurMetrics)
+		metricsGroup.GET("/hub/:id/pending", RequireRole("ADMIN", "CENTER_MANAGER"), handlers.GetPendingHubMetrics)
+		metricsGroup.PATCH("/:id/verify", RequireRole("ADMIN", "CENTER_MANAGER"), handlers.VerifyMetricEntry)
+		metricsGroup.GET("/summary/hub/:id", handlers.GetHubMetricsSummary)
+		metricsGroup.GET("/summary/program/:id", handlers.GetProgramMetricsSummary)
+		metricsGroup.GET("/summary/network", handlers.GetNetworkMetricsSummary)
+	}
+
+	// /api/reports
+	reports := api.Group("/reports")
+	reports.Use(AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"))
+	{
+		reports.GET("/", handlers.GetReportDefinitions)
+		reports.GET("/:kind", handlers.GetReport)
+	}
+
+	// /api/investors
+	investorsGroup := api.Group("/investors")
+	investorsGroup.Use(AuthMiddleware(d.JWTSecret))
+	{
+		investorsGroup.GET("/", handlers.GetInvestors)
+		investorsGroup.GET("/:id", handlers.GetInvestor)
+		investorsGroup.POST("/", RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateInvestor)
+		investorsGroup.PUT("/:id", RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateInvestor)
+	}
+
+	// /api/deals
+	deals := api.Group("/deals")
+	deals.Use(AuthMiddleware(d.JWTSecret))
+	{
+		deals.GET("/", handlers.GetDeals)
+		deals.POST("/", RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateDeal)
+		deals.GET("/:id", handlers.GetDeal)
+		deals.GET("/:id/history", handlers.GetDealHistory)
+		deals.PATCH("/:id/stage", RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateDealStage)
+	}
+
+	// /api/mentors
+	mentors := api.Group("/mentors")
+	mentors.Use(AuthMiddleware(d.JWTSecret))
+	{
+		mentors.GET("/", handlers.GetMentors)
+		mentors.GET("/me", handlers.GetMyMentorProfile)
+		mentors.GET("/:id", handlers.GetMentor)
+		mentors.POST("/", RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateMentor)
+		mentors.PUT("/:id", handlers.UpdateMentor)
+		mentors.POST("/:id/requests", RequireRole("ENTREPRENEUR"), handlers.CreateMentorRequest)
+	}
+
+	// /api/mentor-requests
+	mentorRequests := api.Group("/mentor-requests")
+	mentorRequests.Use(AuthMiddleware(d.JWTSecret))
+	{
+		mentorRequests.GET("/", handlers.GetMentorRequests)
+		mentorRequests.GET("/:id", handlers.GetMentorRequest)
+		mentorRequests.PATCH("/:id", handlers.RespondMentorRequest)
+	}
+
+	// /api/mentoring-sessions
+	sessions := api.Group("/mentoring-sessions")
+	sessions.Use(AuthMiddleware(d.JWTSecret))
+	{
+		sessions.GET("/", handlers.GetMentoringSessions)
+		sessions.POST("/", handlers.CreateMentoringSession)
+		sessions.GET("/:id", handlers.GetMentoringSession)
+		sessions.PUT("/:id", handlers.UpdateMentoringSession)
+		sessions.GET("/:id/history", handlers.GetMentoringSessionHistory)
+		sessions.PATCH("/:id/complete", handlers.CompleteMentoringSession)
+		sessions.PATCH("/:id/cancel", handlers.CancelMentoringSession)
 	}
 
 	// /api/services
@@ -93,6 +282,7 @@ func RegisterRoutes(r *gin.Engine, d Deps) {
 		services.GET("/hub/:hubId", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.GetHubServiceProvisions)
 		services.GET("/entrepreneur/:entrepreneurId", AuthMiddleware(d.JWTSecret), handlers.GetEntrepreneurServices)
 		services.GET("/:id", AuthMiddleware(d.JWTSecret), handlers.GetServiceProvision)
+		services.GET("/:id/history", AuthMiddleware(d.JWTSecret), handlers.GetServiceProvisionHistory)
 		services.PUT("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateServiceProvision)
 	}
 
@@ -105,6 +295,57 @@ func RegisterRoutes(r *gin.Engine, d Deps) {
 		roleUpgrades.PUT("/:id/review", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.ReviewUpgradeRequest)
 	}
 
+	// /api/taxonomy
+	taxonomy := api.Group("/taxonomy")
+	{
+		taxonomy.GET("/", handlers.ListServiceCategories)
+		taxonomy.POST("/", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.CreateServiceCategory)
+		taxonomy.PUT("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.UpdateServiceCategory)
+		taxonomy.DELETE("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN"), handlers.DeleteServiceCategory)
+	}
+
+	// /api/bookable-resources
+	bookableResources := api.Group("/bookable-resources")
+	{
+		bookableResources.PUT("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateBookableResource)
+		bookableResources.GET("/:id/availability", handlers.GetResourceAvailability)
+		bookableResources.GET("/:id/calendar.ics", handlers.GetResourceCalendar)
+		bookableResources.POST("/:id/bookings", AuthMiddleware(d.JWTSecret), handlers.CreateBooking)
+	}
+
+	// /api/bookings
+	bookings := api.Group("/bookings")
+	{
+		bookings.GET("/mine", AuthMiddleware(d.JWTSecret), handlers.ListMyBookings)
+		bookings.PATCH("/:id/approve", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.ApproveBooking)
+		bookings.PATCH("/:id/reject", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.RejectBooking)
+		bookings.PATCH("/:id/cancel", AuthMiddleware(d.JWTSecret), handlers.CancelBooking)
+	}
+
+	// /api/hub-events (/api/events is the realtime stream)
+	hubEvents := api.Group("/hub-events")
+	{
+		hubEvents.GET("/", handlers.ListHubEvents)
+		hubEvents.GET("/hub/:id", handlers.GetHubEvents)
+		hubEvents.GET("/hub/:id/calendar.ics", handlers.GetHubEventsCalendar)
+		hubEvents.GET("/:id", handlers.GetHubEvent)
+		hubEvents.GET("/:id/event.ics", handlers.GetHubEventICS)
+		hubEvents.POST("/", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CreateHubEvent)
+		hubEvents.PUT("/:id", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.UpdateHubEvent)
+		hubEvents.PATCH("/:id/cancel", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CancelHubEvent)
+		hubEvents.POST("/:id/rsvp", AuthMiddleware(d.JWTSecret), handlers.RSVPHubEvent)
+		hubEvents.DELETE("/:id/rsvp", AuthMiddleware(d.JWTSecret), handlers.CancelHubEventRSVP)
+		hubEvents.GET("/:id/attendees", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.GetHubEventAttendees)
+		hubEvents.POST("/:id/check-in", AuthMiddleware(d.JWTSecret), RequireRole("ADMIN", "CENTER_MANAGER"), handlers.CheckInHubEvent)
+	}
+
+	// /api/network
+	network := api.Group("/network")
+	{
+		network.GET("/graph", handlers.GetNetworkGraph)
+		network.GET("/stats", handlers.GetNetworkStats)
+	}
+
 	// /api/activities
 	activities := api.Group("/activities")
 	{
@@ -0,0 +1,100 @@
+package ical
+
+import (
+	"io"
+	"strings"
+	"time"
+)
+
+// Calendar is a minimal iCalendar (RFC 5545) feed
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// Event is a single VEVENT; times are written in UTC
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Status      string // TENTATIVE, CONFIRMED or CANCELLED
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+}
+
+const timeLayout = "20060102T150405Z"
+
+// Write renders the calendar with CRLF line endings and folded long lines
+func (cal *Calendar) Write(w io.Writer) error {
+	var b strings.Builder
+	line := func(name, value string) {
+		b.WriteString(fold(name + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", Escape(cal.ProdID))
+	line("CALSCALE", "GREGORIAN")
+	if cal.Name != "" {
+		line("X-WR-CALNAME", Escape(cal.Name))
+	}
+	for _, ev := range cal.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", Escape(ev.UID))
+		line("DTSTAMP", ev.Stamp.UTC().Format(timeLayout))
+		line("DTSTART", ev.Start.UTC().Format(timeLayout))
+		line("DTEND", ev.End.UTC().Format(timeLayout))
+		line("SUMMARY", Escape(ev.Summary))
+		if ev.Description != "" {
+			line("DESCRIPTION", Escape(ev.Description))
+		}
+		if ev.Location != "" {
+			line("LOCATION", Escape(ev.Location))
+		}
+		if ev.Status != "" {
+			line("STATUS", ev.Status)
+		}
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Escape escapes a TEXT property value
+func Escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// fold splits a content line into 75-octet pieces, continuing each with a
+// leading space, without breaking UTF-8 sequences
+func fold(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !isRuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		width = limit - 1 // the leading space counts towards the limit
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+func isRuneStart(c byte) bool {
+	return c&0xC0 != 0x80
+}
@@ -0,0 +1,53 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteCalendar(t *testing.T) {
+	start := time.Date(2024, 6, 3, 9, 0, 0, 0, time.FixedZone("EAT", 3*3600))
+	cal := Calendar{
+		ProdID: "-//Test//EN",
+		Name:   "Board Room",
+		Events: []Event{{
+			UID:     "abc@test",
+			Summary: "Pitch practice; demo, day",
+			Status:  "CONFIRMED",
+			Start:   start,
+			End:     start.Add(time.Hour),
+			Stamp:   start,
+		}},
+	}
+
+	var b strings.Builder
+	if err := cal.Write(&b); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20240603T060000Z\r\n",
+		"DTEND:20240603T070000Z\r\n",
+		`SUMMARY:Pitch practice\; demo\, day` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output:\n%s", want, out)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	long := "DESCRIPTION:" + strings.Repeat("é", 60)
+	folded := fold(long)
+	for _, part := range strings.Split(folded, "\r\n") {
+		if len(part) > 75 {
+			t.Fatalf("line longer than 75 octets: %d", len(part))
+		}
+	}
+	if strings.ReplaceAll(folded, "\r\n ", "") != long {
+		t.Fatalf("unfolding should restore the original line")
+	}
+}
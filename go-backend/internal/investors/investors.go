@@ -0,0 +1,130 @@
+package investors
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/taxonomy"
+)
+
+var (
+	ErrInvalidType     = errors.New("unknown investor type")
+	ErrInvalidTicket   = errors.New("ticket sizes must be zero or more, with the minimum not above the maximum")
+	ErrInvalidCurrency = errors.New("currency must be a three-letter ISO code")
+)
+
+var currencyRe = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// legalSuffixes are dropped when matching names, so "Acme Capital Ltd" and
+// "Acme Capital" are the same investor
+var legalSuffixes = map[string]bool{
+	"ltd": true, "limited": true, "inc": true, "llc": true, "plc": true, "co": true, "company": true,
+}
+
+// Slug normalizes an investor name for matching and uniqueness
+func Slug(name string) string {
+	words := strings.Split(taxonomy.Slugify(name), "-")
+	for len(words) > 1 && legalSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, "-")
+}
+
+// ValidType reports whether t is a known investor type
+func ValidType(t db.InvestorType) bool {
+	switch t {
+	case db.InvestorAngel, db.InvestorVentureCapital, db.InvestorImpactFund,
+		db.InvestorGrantMaker, db.InvestorCorporate, db.InvestorOther:
+		return true
+	}
+	return false
+}
+
+// CheckProfile validates an investor's type, ticket-size range and currency
+func CheckProfile(inv *db.Investor) error {
+	if !ValidType(inv.Type) {
+		return ErrInvalidType
+	}
+	if (inv.TicketMin != nil && *inv.TicketMin < 0) || (inv.TicketMax != nil && *inv.TicketMax < 0) {
+		return ErrInvalidTicket
+	}
+	if inv.TicketMin != nil && inv.TicketMax != nil && *inv.TicketMin > *inv.TicketMax {
+		return ErrInvalidTicket
+	}
+	if !currencyRe.MatchString(inv.Currency) {
+		return ErrInvalidCurrency
+	}
+	return nil
+}
+
+// StageForService maps a service provision that named an investor onto the
+// deal pipeline: completed services funded, cancelled ones passed and the
+// rest still at introduction
+func StageForService(status db.ServiceProvisionStatus) db.DealStage {
+	switch status {
+	case db.ServiceCompleted:
+		return db.DealFunded
+	case db.ServiceCancelled:
+		return db.DealPassed
+	}
+	return db.DealIntro
+}
+
+// Candidate is an investor found in free-text service provision fields
+type Candidate struct {
+	Slug     string
+	Name     string // Most frequent spelling; the earliest wins ties
+	Details  string // First non-empty InvestorDetails
+	Services []db.ServiceProvision
+}
+
+// Extract groups services by the investor they name. Services without an
+// investor name are skipped. Candidates are ordered by name.
+func Extract(services []db.ServiceProvision) []Candidate {
+	type group struct {
+		candidate Candidate
+		spellings map[string]int
+		order     []string
+	}
+	groups := map[string]*group{}
+	for _, s := range services {
+		if s.InvestorName == nil {
+			continue
+		}
+		name := strings.Join(strings.Fields(*s.InvestorName), " ")
+		slug := Slug(name)
+		if slug == "" {
+			continue
+		}
+		g := groups[slug]
+		if g == nil {
+			g = &group{candidate: Candidate{Slug: slug}, spellings: map[string]int{}}
+			groups[slug] = g
+		}
+		if g.spellings[name] == 0 {
+			g.order = append(g.order, name)
+		}
+		g.spellings[name]++
+		if g.candidate.Details == "" && s.InvestorDetails != nil {
+			g.candidate.Details = strings.TrimSpace(*s.InvestorDetails)
+		}
+		g.candidate.Services = append(g.candidate.Services, s)
+	}
+
+	out := make([]Candidate, 0, len(groups))
+	for _, g := range groups {
+		best := g.order[0]
+		for _, name := range g.order[1:] {
+			if g.spellings[name] > g.spellings[best] {
+				best = name
+			}
+		}
+		g.candidate.Name = best
+		out = append(out, g.candidate)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
@@ -0,0 +1,78 @@
+package investors
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func strPtr(s string) *string { return &s }
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestSlug(t *testing.T) {
+	cases := map[string]string{
+		"Acme Capital Ltd.":   "acme-capital",
+		"  acme   capital  ":  "acme-capital",
+		"ACME Capital, Inc":   "acme-capital",
+		"Limited":             "limited",
+		"Women in Tech Fund!": "women-in-tech-fund",
+	}
+	for in, want := range cases {
+		if got := Slug(in); got != want {
+			t.Fatalf("Slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCheckProfile(t *testing.T) {
+	ok := db.Investor{Type: db.InvestorAngel, Currency: "USD", TicketMin: floatPtr(5000), TicketMax: floatPtr(50000)}
+	if err := CheckProfile(&ok); err != nil {
+		t.Fatalf("expected valid profile: %v", err)
+	}
+	bad := []db.Investor{
+		{Type: "BANK", Currency: "USD"},
+		{Type: db.InvestorAngel, Currency: "usd"},
+		{Type: db.InvestorAngel, Currency: "USD", TicketMin: floatPtr(10), TicketMax: floatPtr(5)},
+		{Type: db.InvestorAngel, Currency: "USD", TicketMin: floatPtr(-1)},
+	}
+	for _, inv := range bad {
+		if err := CheckProfile(&inv); err == nil {
+			t.Fatalf("expected %+v to be rejected", inv)
+		}
+	}
+}
+
+func TestExtract(t *testing.T) {
+	service := func(name *string, details *string, status db.ServiceProvisionStatus) db.ServiceProvision {
+		return db.ServiceProvision{ID: uuid.New(), InvestorName: name, InvestorDetails: details, Status: status}
+	}
+	services := []db.ServiceProvision{
+		service(strPtr("acme capital"), nil, db.ServiceActive),
+		service(strPtr("Acme Capital"), strPtr(" Seed fund "), db.ServiceCompleted),
+		service(strPtr("Acme  Capital Ltd"), nil, db.ServicePending),
+		service(strPtr("Acme Capital"), strPtr("Later note"), db.ServiceCancelled),
+		service(strPtr("Baobab Angels"), nil, db.ServiceActive),
+		service(nil, nil, db.ServiceActive),
+		service(strPtr("  "), nil, db.ServiceActive),
+	}
+
+	got := Extract(services)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 candidates, got %+v", got)
+	}
+	acme := got[0]
+	if acme.Name != "Acme Capital" || acme.Slug != "acme-capital" || len(acme.Services) != 4 || acme.Details != "Seed fund" {
+		t.Fatalf("unexpected candidate %+v", acme)
+	}
+	if got[1].Name != "Baobab Angels" || len(got[1].Services) != 1 {
+		t.Fatalf("unexpected candidate %+v", got[1])
+	}
+
+	if StageForService(db.ServiceCompleted) != db.DealFunded || StageForService(db.ServiceCancelled) != db.DealPassed ||
+		StageForService(db.ServiceActive) != db.DealIntro {
+		t.Fatalf("unexpected stage mapping")
+	}
+}
@@ -0,0 +1,233 @@
+package lifecycle
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// Enrollments governs HubEnrollment.Status. Pending requests are approved
+// (ACTIVE) or declined (SUSPENDED); suspended enrollments can be reinstated;
+// completion is final.
+var Enrollments = NewMachine("enrollment", map[db.EnrollmentStatus][]db.EnrollmentStatus{
+	db.EnrollmentPending:   {db.EnrollmentActive, db.EnrollmentSuspended},
+	db.EnrollmentActive:    {db.EnrollmentSuspended, db.EnrollmentCompleted},
+	db.EnrollmentSuspended: {db.EnrollmentActive},
+	db.EnrollmentCompleted: {},
+})
+
+// Services governs ServiceProvision.Status. One-off services may be
+// completed straight from PENDING; completed and cancelled are final.
+var Services = NewMachine("service", map[db.ServiceProvisionStatus][]db.ServiceProvisionStatus{
+	db.ServicePending:   {db.ServiceActive, db.ServiceCompleted, db.ServiceCancelled},
+	db.ServiceActive:    {db.ServiceCompleted, db.ServiceCancelled},
+	db.ServiceCompleted: {},
+	db.ServiceCancelled: {},
+})
+
+// Deals governs Deal.Stage. An introduction may go straight to a term sheet
+// when no meeting is tracked; investors can pass at any open stage; funded
+// and passed are final.
+var Deals = NewMachine("deal", map[db.DealStage][]db.DealStage{
+	db.DealIntro:     {db.DealMeeting, db.DealTermSheet, db.DealPassed},
+	db.DealMeeting:   {db.DealTermSheet, db.DealPassed},
+	db.DealTermSheet: {db.DealFunded, db.DealPassed},
+	db.DealFunded:    {},
+	db.DealPassed:    {},
+})
+
+// MentorRequests governs MentorRequest.Status. The mentor accepts or
+// declines a pending request, the entrepreneur may withdraw it, and either
+// side ends an accepted mentorship by closing it.
+var MentorRequests = NewMachine("mentor request", map[db.MentorRequestStatus][]db.MentorRequestStatus{
+	db.MentorRequestPending:   {db.MentorRequestAccepted, db.MentorRequestDeclined, db.MentorRequestWithdrawn},
+	db.MentorRequestAccepted:  {db.MentorRequestClosed},
+	db.MentorRequestDeclined:  {},
+	db.MentorRequestWithdrawn: {},
+	db.MentorRequestClosed:    {},
+})
+
+// Sessions governs MentoringSession.Status; both outcomes are final
+var Sessions = NewMachine("mentoring session", map[db.MentoringSessionStatus][]db.MentoringSessionStatus{
+	db.SessionScheduled: {db.SessionCompleted, db.SessionCancelled},
+	db.SessionCompleted: {},
+	db.SessionCancelled: {},
+})
+
+// TransitionEnrollment moves an enrollment to a new status, stamping its
+// enrollment or completion date and recording the change in its history.
+// A nil actor marks an automatic transition.
+func TransitionEnrollment(gdb *gorm.DB, e *db.HubEnrollment, to db.EnrollmentStatus, actor *uuid.UUID, reason *string) error {
+	from := e.Status
+	if err := Enrollments.Check(from, to); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	e.Status = to
+	switch to {
+	case db.EnrollmentActive:
+		if e.EnrollmentDate == nil {
+			e.EnrollmentDate = &now
+		}
+	case db.EnrollmentCompleted:
+		if e.CompletionDate == nil {
+			e.CompletionDate = &now
+		}
+	}
+
+	return gdb.Transaction(func(tx *gorm.DB) error {
+		err := applyTransition(tx, Enrollments, e, "status", from, map[string]interface{}{
+			"status":          e.Status,
+			"enrollment_date": e.EnrollmentDate,
+			"completion_date": e.CompletionDate,
+		})
+		if err != nil {
+			return err
+		}
+		return record(tx, db.StatusEntityEnrollment, e.ID, string(from), string(to), actor, reason)
+	})
+}
+
+// TransitionService moves a service provision to a new status, stamping its
+// completion date when it ends and recording the change in its history
+func TransitionService(gdb *gorm.DB, s *db.ServiceProvision, to db.ServiceProvisionStatus, actor *uuid.UUID, reason *string) error {
+	from := s.Status
+	if err := Services.Check(from, to); err != nil {
+		return err
+	}
+
+	s.Status = to
+	if Services.Terminal(to) && s.CompletionDate == nil {
+		now := time.Now()
+		s.CompletionDate = &now
+	}
+
+	return gdb.Transaction(func(tx *gorm.DB) error {
+		err := applyTransition(tx, Services, s, "status", from, map[string]interface{}{
+			"status":          s.Status,
+			"completion_date": s.CompletionDate,
+		})
+		if err != nil {
+			return err
+		}
+		return record(tx, db.StatusEntityService, s.ID, string(from), string(to), actor, reason)
+	})
+}
+
+// TransitionDeal moves a deal to a new stage, saving its amount and notes,
+// stamping its close date when it is funded or passed and recording the
+// change in its history
+func TransitionDeal(gdb *gorm.DB, d *db.Deal, to db.DealStage, actor *uuid.UUID, reason *string) error {
+	from := d.Stage
+	if err := Deals.Check(from, to); err != nil {
+		return err
+	}
+
+	d.Stage = to
+	if Deals.Terminal(to) && d.ClosedAt == nil {
+		now := time.Now()
+		d.ClosedAt = &now
+	}
+
+	return gdb.Transaction(func(tx *gorm.DB) error {
+		err := applyTransition(tx, Deals, d, "stage", from, map[string]interface{}{
+			"stage":     d.Stage,
+			"amount":    d.Amount,
+			"notes":     d.Notes,
+			"closed_at": d.ClosedAt,
+		})
+		if err != nil {
+			return err
+		}
+		return record(tx, db.StatusEntityDeal, d.ID, string(from), string(to), actor, reason)
+	})
+}
+
+// TransitionMentorRequest records the mentor's or entrepreneur's response to a
+// mentorship request. Requests keep no history beyond who responded last.
+func TransitionMentorRequest(gdb *gorm.DB, r *db.MentorRequest, to db.MentorRequestStatus, by *uuid.UUID, note *string) error {
+	from := r.Status
+	if err := MentorRequests.Check(from, to); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err := applyTransition(gdb, MentorRequests, r, "status", from, map[string]interface{}{
+		"status":        to,
+		"response_note": note,
+		"responded_by":  by,
+		"responded_at":  now,
+	})
+	if err != nil {
+		return err
+	}
+	r.Status, r.ResponseNote, r.RespondedBy, r.RespondedAt = to, note, by, &now
+	return nil
+}
+
+// TransitionSession moves a mentoring session to a new status, saving its
+// notes and outcome and recording the change in its history. Completing a
+// session stamps its completion time; the caller links the service provision
+// it produced.
+func TransitionSession(gdb *gorm.DB, s *db.MentoringSession, to db.MentoringSessionStatus, actor *uuid.UUID, reason *string) error {
+	from := s.Status
+	if err := Sessions.Check(from, to); err != nil {
+		return err
+	}
+
+	s.Status = to
+	if to == db.SessionCompleted && s.CompletedAt == nil {
+		now := time.Now()
+		s.CompletedAt = &now
+	}
+
+	return gdb.Transaction(func(tx *gorm.DB) error {
+		err := applyTransition(tx, Sessions, s, "status", from, map[string]interface{}{
+			"status":               s.Status,
+			"notes":                s.Notes,
+			"outcome":              s.Outcome,
+			"completed_at":         s.CompletedAt,
+			"service_provision_id": s.ServiceProvisionID,
+		})
+		if err != nil {
+			return err
+		}
+		return record(tx, db.StatusEntitySession, s.ID, string(from), string(to), actor, reason)
+	})
+}
+
+// applyTransition writes a status change only if the record still has the
+// status it was checked against, so two concurrent changes cannot both
+// apply; the loser gets an error wrapping ErrIllegalTransition
+func applyTransition[S ~string](tx *gorm.DB, m *Machine[S], model interface{}, column string, from S, values map[string]interface{}) error {
+	result := tx.Model(model).Where(column+" = ?", from).Updates(values)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s is no longer %s", ErrIllegalTransition, m.name, from)
+	}
+	return nil
+}
+
+// RecordInitial stores the status a record was created with, so its history
+// starts at creation rather than at the first change
+func RecordInitial(gdb *gorm.DB, entity db.StatusEntity, id uuid.UUID, status string, actor *uuid.UUID, reason *string) error {
+	return record(gdb, entity, id, "", status, actor, reason)
+}
+
+func record(gdb *gorm.DB, entity db.StatusEntity, id uuid.UUID, from, to string, actor *uuid.UUID, reason *string) error {
+	return db.RecordStatusTransition(gdb, &db.StatusTransition{
+		EntityType: entity,
+		EntityID:   id,
+		FromStatus: from,
+		ToStatus:   to,
+		ActorID:    actor,
+		Reason:     reason,
+	})
+}
@@ -0,0 +1,109 @@
+package lifecycle
+
+import (
+	"errors"
+	"testing"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func TestEnrollmentTransitions(t *testing.T) {
+	allowed := [][2]db.EnrollmentStatus{
+		{db.EnrollmentPending, db.EnrollmentActive},
+		{db.EnrollmentPending, db.EnrollmentSuspended},
+		{db.EnrollmentActive, db.EnrollmentCompleted},
+		{db.EnrollmentActive, db.EnrollmentSuspended},
+		{db.EnrollmentSuspended, db.EnrollmentActive},
+	}
+	for _, tr := range allowed {
+		if err := Enrollments.Check(tr[0], tr[1]); err != nil {
+			t.Fatalf("expected %s -> %s to be allowed: %v", tr[0], tr[1], err)
+		}
+	}
+
+	illegal := [][2]db.EnrollmentStatus{
+		{db.EnrollmentCompleted, db.EnrollmentPending},
+		{db.EnrollmentCompleted, db.EnrollmentActive},
+		{db.EnrollmentPending, db.EnrollmentCompleted},
+		{db.EnrollmentSuspended, db.EnrollmentCompleted},
+		{db.EnrollmentActive, db.EnrollmentActive},
+		{db.EnrollmentActive, "ARCHIVED"},
+	}
+	for _, tr := range illegal {
+		if err := Enrollments.Check(tr[0], tr[1]); !errors.Is(err, ErrIllegalTransition) {
+			t.Fatalf("expected %s -> %s to be rejected, got %v", tr[0], tr[1], err)
+		}
+	}
+	if !Enrollments.Terminal(db.EnrollmentCompleted) || Enrollments.Terminal(db.EnrollmentSuspended) {
+		t.Fatalf("unexpected terminal statuses")
+	}
+}
+
+func TestServiceTransitions(t *testing.T) {
+	if err := Services.Check(db.ServicePending, db.ServiceCompleted); err != nil {
+		t.Fatalf("expected one-off services to complete from PENDING: %v", err)
+	}
+	if err := Services.Check(db.ServiceCancelled, db.ServiceActive); !errors.Is(err, ErrIllegalTransition) {
+		t.Fatalf("expected cancelled services to be final, got %v", err)
+	}
+	if err := Services.Check(db.ServiceCompleted, db.ServicePending); !errors.Is(err, ErrIllegalTransition) {
+		t.Fatalf("expected completed services to be final, got %v", err)
+	}
+	if got := Services.Allowed(db.ServiceActive); len(got) != 2 {
+		t.Fatalf("unexpected allowed statuses %v", got)
+	}
+	if Services.Valid("UNKNOWN") {
+		t.Fatalf("expected unknown status to be invalid")
+	}
+}
+
+func TestDealTransitions(t *testing.T) {
+	for _, tr := range [][2]db.DealStage{
+		{db.DealIntro, db.DealMeeting},
+		{db.DealIntro, db.DealTermSheet},
+		{db.DealMeeting, db.DealPassed},
+		{db.DealTermSheet, db.DealFunded},
+	} {
+		if err := Deals.Check(tr[0], tr[1]); err != nil {
+			t.Fatalf("expected %s -> %s to be allowed: %v", tr[0], tr[1], err)
+		}
+	}
+	for _, tr := range [][2]db.DealStage{
+		{db.DealIntro, db.DealFunded},
+		{db.DealFunded, db.DealPassed},
+		{db.DealPassed, db.DealIntro},
+		{db.DealMeeting, db.DealIntro},
+	} {
+		if err := Deals.Check(tr[0], tr[1]); !errors.Is(err, ErrIllegalTransition) {
+			t.Fatalf("expected %s -> %s to be rejected, got %v", tr[0], tr[1], err)
+		}
+	}
+}
+
+func TestMentoringTransitions(t *testing.T) {
+	for _, tr := range [][2]db.MentorRequestStatus{
+		{db.MentorRequestPending, db.MentorRequestAccepted},
+		{db.MentorRequestPending, db.MentorRequestWithdrawn},
+		{db.MentorRequestAccepted, db.MentorRequestClosed},
+	} {
+		if err := MentorRequests.Check(tr[0], tr[1]); err != nil {
+			t.Fatalf("expected %s -> %s to be allowed: %v", tr[0], tr[1], err)
+		}
+	}
+	for _, tr := range [][2]db.MentorRequestStatus{
+		{db.MentorRequestDeclined, db.MentorRequestAccepted},
+		{db.MentorRequestAccepted, db.MentorRequestWithdrawn},
+		{db.MentorRequestPending, db.MentorRequestClosed},
+	} {
+		if err := MentorRequests.Check(tr[0], tr[1]); !errors.Is(err, ErrIllegalTransition) {
+			t.Fatalf("expected %s -> %s to be rejected, got %v", tr[0], tr[1], err)
+		}
+	}
+
+	if err := Sessions.Check(db.SessionScheduled, db.SessionCompleted); err != nil {
+		t.Fatalf("expected scheduled session to complete: %v", err)
+	}
+	if err := Sessions.Check(db.SessionCancelled, db.SessionCompleted); !errors.Is(err, ErrIllegalTransition) {
+		t.Fatalf("expected cancelled session to stay cancelled, got %v", err)
+	}
+}
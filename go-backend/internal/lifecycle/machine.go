@@ -0,0 +1,50 @@
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIllegalTransition is wrapped by every rejected status change
+var ErrIllegalTransition = errors.New("illegal status transition")
+
+// Machine lists the status changes allowed for one kind of record
+type Machine[S ~string] struct {
+	name  string
+	edges map[S][]S
+}
+
+// NewMachine builds a machine from each status's allowed next statuses.
+// Statuses without outgoing edges are terminal.
+func NewMachine[S ~string](name string, edges map[S][]S) *Machine[S] {
+	return &Machine[S]{name: name, edges: edges}
+}
+
+// Valid reports whether s is a known status
+func (m *Machine[S]) Valid(s S) bool {
+	_, ok := m.edges[s]
+	return ok
+}
+
+// Allowed returns the statuses reachable in one step from s
+func (m *Machine[S]) Allowed(s S) []S {
+	return append([]S{}, m.edges[s]...)
+}
+
+// Terminal reports whether no further transitions are possible from s
+func (m *Machine[S]) Terminal(s S) bool {
+	return len(m.edges[s]) == 0
+}
+
+// Check returns an error wrapping ErrIllegalTransition unless from -> to is allowed
+func (m *Machine[S]) Check(from, to S) error {
+	if !m.Valid(to) {
+		return fmt.Errorf("%w: unknown %s status %q", ErrIllegalTransition, m.name, to)
+	}
+	for _, next := range m.edges[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s cannot move from %s to %s", ErrIllegalTransition, m.name, from, to)
+}
@@ -0,0 +1,161 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+
+	_ "image/gif" // Register decoders for image.Decode
+)
+
+const (
+	ThumbnailSize = 320        // Longest side, in pixels
+	MaxPixels     = 40_000_000 // Refuse to decode anything bigger
+	jpegQuality   = 82
+)
+
+var ErrTooManyPixels = errors.New("image dimensions are too large")
+
+// Rendition is an encoded, resized copy of an image
+type Rendition struct {
+	Data        []byte
+	ContentType string
+	Width       int
+	Height      int
+}
+
+// DecodeImage decodes a JPEG, PNG or GIF, checking its dimensions before
+// decompressing it. Formats without a decoder (e.g. WebP) return
+// image.ErrFormat.
+func DecodeImage(data []byte) (image.Image, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, "", ErrTooManyPixels
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, format, err
+}
+
+// Render scales img to fit within maxSide and encodes it; images are never
+// enlarged. PNG and GIF sources become PNG to keep transparency, everything
+// else becomes JPEG.
+func Render(img image.Image, format string, maxSide int) (Rendition, error) {
+	w, h := FitWithin(img.Bounds().Dx(), img.Bounds().Dy(), maxSide)
+	scaled := Resize(img, w, h)
+
+	var buf bytes.Buffer
+	out := Rendition{Width: w, Height: h}
+	if format == "png" || format == "gif" {
+		out.ContentType = "image/png"
+		if err := png.Encode(&buf, scaled); err != nil {
+			return Rendition{}, err
+		}
+	} else {
+		out.ContentType = "image/jpeg"
+		if err := jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return Rendition{}, err
+		}
+	}
+	out.Data = buf.Bytes()
+	return out, nil
+}
+
+// Size is a named bound on an image's longest side
+type Size struct {
+	Name    string
+	MaxSide int
+}
+
+// Standard sizes for responsive image sets, smallest first
+var (
+	PhotoSizes = []Size{{"small", 480}, {"medium", 1024}, {"large", 1920}}
+	LogoSizes  = []Size{{"small", 96}, {"medium", 256}, {"large", 512}}
+)
+
+// SizedRendition is a rendition made for one of a set of sizes
+type SizedRendition struct {
+	Size string
+	Rendition
+}
+
+// RenderSizes renders img at each size, smallest first. Sizes larger than
+// the image collapse into one full-size rendition under the first of their
+// names, so small images are not stored several times over.
+func RenderSizes(img image.Image, format string, sizes []Size) ([]SizedRendition, error) {
+	longest := max(img.Bounds().Dx(), img.Bounds().Dy())
+	var out []SizedRendition
+	for _, size := range sizes {
+		r, err := Render(img, format, size.MaxSide)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, SizedRendition{Size: size.Name, Rendition: r})
+		if size.MaxSide >= longest {
+			break
+		}
+	}
+	return out, nil
+}
+
+// FitWithin scales w x h down so the longest side is at most maxSide,
+// keeping the aspect ratio
+func FitWithin(w, h, maxSide int) (int, int) {
+	if w <= maxSide && h <= maxSide {
+		return w, h
+	}
+	if w >= h {
+		return maxSide, max(1, h*maxSide/w)
+	}
+	return max(1, w*maxSide/h), maxSide
+}
+
+// Resize scales img to w x h. Each output pixel averages the block of source
+// pixels it covers, weighting by alpha so transparent edges don't darken.
+func Resize(img image.Image, w, h int) *image.NRGBA {
+	b := img.Bounds()
+	src, ok := img.(*image.NRGBA)
+	if !ok || b.Min != (image.Point{}) {
+		src = image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+		draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	}
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, (y+1)*sh/h
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, (x+1)*sw/w
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					alpha := uint64(p[3])
+					r += uint64(p[0]) * alpha
+					g += uint64(p[1]) * alpha
+					bl += uint64(p[2]) * alpha
+					a += alpha
+					n++
+				}
+			}
+			d := dst.Pix[y*dst.Stride+x*4:]
+			if a > 0 {
+				d[0], d[1], d[2] = uint8(r/a), uint8(g/a), uint8(bl/a)
+			}
+			d[3] = uint8(a / n)
+		}
+	}
+	return dst
+}
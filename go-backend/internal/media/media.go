@@ -0,0 +1,77 @@
+// Package media validates uploaded files and derives resized images from
+// them. Types are worked out from file contents, never from what the client
+// claims.
+package media
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+const (
+	MaxBytes   = 25 << 20 // Per file
+	SniffBytes = 512      // Enough for http.DetectContentType
+)
+
+var (
+	ErrUnsupportedType = errors.New("unsupported file type; upload an image, PDF, office document or MP4 video")
+	ErrTooLarge        = fmt.Errorf("file is larger than %d MB", MaxBytes>>20)
+)
+
+// Office formats are zip archives to the sniffer, so they are recognised by
+// extension once the content is confirmed to be a zip
+var officeTypes = map[string]string{
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+var sniffedKinds = map[string]db.MediaKind{
+	"image/jpeg":      db.MediaImage,
+	"image/png":       db.MediaImage,
+	"image/gif":       db.MediaImage,
+	"image/webp":      db.MediaImage,
+	"application/pdf": db.MediaDocument,
+	"video/mp4":       db.MediaVideo,
+}
+
+// Detect works out a file's type from its first bytes
+func Detect(head []byte, filename string) (string, db.MediaKind, error) {
+	sniffed := http.DetectContentType(head)
+	if i := strings.IndexByte(sniffed, ';'); i >= 0 {
+		sniffed = sniffed[:i]
+	}
+	if kind, ok := sniffedKinds[sniffed]; ok {
+		return sniffed, kind, nil
+	}
+	if sniffed == "application/zip" {
+		if t, ok := officeTypes[strings.ToLower(filepath.Ext(filename))]; ok {
+			return t, db.MediaDocument, nil
+		}
+	}
+	return "", "", ErrUnsupportedType
+}
+
+// SafeFileName strips any path from an uploaded file's name so it can be
+// echoed back in Content-Disposition
+func SafeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == '"' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
@@ -0,0 +1,145 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name     string
+		head     []byte
+		filename string
+		wantType string
+		wantKind db.MediaKind
+		wantErr  bool
+	}{
+		{"png", []byte("\x89PNG\r\n\x1a\n0000"), "logo.png", "image/png", db.MediaImage, false},
+		{"pdf", []byte("%PDF-1.7\n"), "deck.pdf", "application/pdf", db.MediaDocument, false},
+		{"pptx", []byte("PK\x03\x04rest"), "Deck.PPTX", officeTypes[".pptx"], db.MediaDocument, false},
+		{"zip without office extension", []byte("PK\x03\x04rest"), "archive.zip", "", "", true},
+		{"html renamed to png", []byte("<html><script>"), "photo.png", "", "", true},
+	}
+	for _, tc := range cases {
+		gotType, gotKind, err := Detect(tc.head, tc.filename)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tc.name, err, tc.wantErr)
+			continue
+		}
+		if gotType != tc.wantType || gotKind != tc.wantKind {
+			t.Errorf("%s: got %q/%q, want %q/%q", tc.name, gotType, gotKind, tc.wantType, tc.wantKind)
+		}
+	}
+}
+
+func TestSafeFileName(t *testing.T) {
+	cases := map[string]string{
+		`C:\Users\me\deck.pdf`: "deck.pdf",
+		"../../etc/passwd":     "passwd",
+		"say \"hi\".png":       "say hi.png",
+		"":                     "file",
+	}
+	for in, want := range cases {
+		if got := SafeFileName(in); got != want {
+			t.Errorf("SafeFileName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFitWithin(t *testing.T) {
+	cases := []struct{ w, h, max, wantW, wantH int }{
+		{100, 50, 320, 100, 50},
+		{1600, 800, 320, 320, 160},
+		{800, 1600, 320, 160, 320},
+		{5000, 1, 320, 320, 1},
+	}
+	for _, tc := range cases {
+		if w, h := FitWithin(tc.w, tc.h, tc.max); w != tc.wantW || h != tc.wantH {
+			t.Errorf("FitWithin(%d, %d, %d) = %dx%d, want %dx%d", tc.w, tc.h, tc.max, w, h, tc.wantW, tc.wantH)
+		}
+	}
+}
+
+func TestRenderThumbnail(t *testing.T) {
+	// Left half red, right half fully transparent
+	src := image.NewNRGBA(image.Rect(0, 0, 800, 400))
+	for y := 0; y < 400; y++ {
+		for x := 0; x < 400; x++ {
+			src.SetNRGBA(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	img, format, err := DecodeImage(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := Render(img, format, ThumbnailSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Width != 320 || r.Height != 160 || r.ContentType != "image/png" {
+		t.Fatalf("got %dx%d %s", r.Width, r.Height, r.ContentType)
+	}
+	thumb, err := png.Decode(bytes.NewReader(r.Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := color.NRGBAModel.Convert(thumb.At(10, 10)).(color.NRGBA); c.R != 255 || c.A != 255 {
+		t.Fatalf("expected opaque red on the left, got %+v", c)
+	}
+	if c := color.NRGBAModel.Convert(thumb.At(300, 10)).(color.NRGBA); c.A != 0 {
+		t.Fatalf("expected transparency on the right, got %+v", c)
+	}
+}
+
+func TestRenderSizes(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 1200, 600))
+	sets, err := RenderSizes(img, "jpeg", PhotoSizes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 1200px fits within "large", so it is kept at full size once
+	if len(sets) != 3 {
+		t.Fatalf("expected 3 renditions, got %d", len(sets))
+	}
+	want := []struct {
+		size string
+		w, h int
+	}{{"small", 480, 240}, {"medium", 1024, 512}, {"large", 1200, 600}}
+	for i, w := range want {
+		if r := sets[i]; r.Size != w.size || r.Width != w.w || r.Height != w.h || r.ContentType != "image/jpeg" {
+			t.Errorf("rendition %d: got %s %dx%d %s", i, r.Size, r.Width, r.Height, r.ContentType)
+		}
+	}
+
+	small, err := RenderSizes(image.NewGray(image.Rect(0, 0, 300, 200)), "jpeg", PhotoSizes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(small) != 1 || small[0].Size != "small" || small[0].Width != 300 {
+		t.Fatalf("expected one full-size rendition of a small image, got %+v", small)
+	}
+}
+
+func TestDecodeImageRejectsHugeDimensions(t *testing.T) {
+	// A valid PNG header claiming 100000 x 100000 pixels
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1)))
+	data := buf.Bytes()
+	copy(data[16:24], []byte{0, 1, 0x86, 0xa0, 0, 1, 0x86, 0xa0})
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	if _, _, err := DecodeImage(data); err != ErrTooManyPixels {
+		t.Fatalf("expected ErrTooManyPixels, got %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package mentoring
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+	"communitycentresplatform/go-backend/internal/hours"
+	"communitycentresplatform/go-backend/internal/taxonomy"
+)
+
+// ServiceType is the service provision type logged for a completed session,
+// matching what hubs typed by hand before mentors were modelled
+const ServiceType = "Mentorship"
+
+const (
+	MaxExpertise       = 20
+	MinSessionMinutes  = 15
+	MaxSessionMinutes  = 480
+	maxSchedulingYears = 1
+)
+
+var (
+	ErrTooManyTags     = fmt.Errorf("at most %d expertise tags are allowed", MaxExpertise)
+	ErrInvalidDuration = fmt.Errorf("sessions must last between %d and %d minutes", MinSessionMinutes, MaxSessionMinutes)
+	ErrInPast          = errors.New("sessions must be scheduled in the future")
+	ErrTooFarAhead     = errors.New("sessions can be scheduled at most a year ahead")
+	ErrUnavailable     = errors.New("session falls outside the mentor's availability")
+)
+
+// NormalizeExpertise slugifies expertise tags, dropping blanks and duplicates,
+// and sorts them so profiles compare and display consistently
+func NormalizeExpertise(tags []string) (db.StringArray, error) {
+	seen := map[string]bool{}
+	out := db.StringArray{}
+	for _, tag := range tags {
+		slug := taxonomy.Slugify(tag)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+		out = append(out, slug)
+	}
+	if len(out) > MaxExpertise {
+		return nil, ErrTooManyTags
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// Schedule builds a mentor's weekly availability in the hosting hub's time
+// zone, rejecting malformed or overlapping windows
+func Schedule(timeZone string, slots []db.MentorAvailability) (*hours.Schedule, error) {
+	s, err := hours.NewSchedule(timeZone)
+	if err != nil {
+		return nil, err
+	}
+	var byDay [7][]hours.Interval
+	for _, slot := range slots {
+		if slot.Weekday < 0 || slot.Weekday > 6 {
+			return nil, fmt.Errorf("weekday %d must be between 0 (Sunday) and 6 (Saturday)", slot.Weekday)
+		}
+		iv, err := hours.NewInterval(slot.StartsAt, slot.EndsAt)
+		if err != nil {
+			return nil, err
+		}
+		byDay[slot.Weekday] = append(byDay[slot.Weekday], iv)
+		s.Add(time.Weekday(slot.Weekday), iv)
+	}
+	for day, intervals := range byDay {
+		if hours.Overlaps(intervals) {
+			return nil, fmt.Errorf("overlapping availability on %s", time.Weekday(day))
+		}
+	}
+	return s, nil
+}
+
+// CheckSession validates a session's timing. Mentors without availability
+// windows take sessions at any time; otherwise the whole session must fall
+// inside one window.
+func CheckSession(availability *hours.Schedule, start time.Time, minutes int, now time.Time) error {
+	if minutes < MinSessionMinutes || minutes > MaxSessionMinutes {
+		return ErrInvalidDuration
+	}
+	if !start.After(now) {
+		return ErrInPast
+	}
+	if start.After(now.AddDate(maxSchedulingYears, 0, 0)) {
+		return ErrTooFarAhead
+	}
+	if availability != nil && availability.HasWeeklyHours() &&
+		!availability.Covers(start, start.Add(time.Duration(minutes)*time.Minute)) {
+		return ErrUnavailable
+	}
+	return nil
+}
+
+// ServiceFor builds the completed service provision that credits a session
+// to its hosting hub
+func ServiceFor(s *db.MentoringSession, mentorName, topic string) db.ServiceProvision {
+	start := s.ScheduledAt
+	completed := start.Add(time.Duration(s.DurationMinutes) * time.Minute)
+	if s.CompletedAt != nil {
+		completed = *s.CompletedAt
+	}
+	description := fmt.Sprintf("Mentoring session with %s (%d min)", mentorName, s.DurationMinutes)
+	if topic != "" {
+		description += ": " + topic
+	}
+	return db.ServiceProvision{
+		HubID:          s.HubID,
+		EntrepreneurID: s.EntrepreneurID,
+		ServiceType:    ServiceType,
+		Description:    description,
+		StartDate:      &start,
+		CompletionDate: &completed,
+		Status:         db.ServiceCompleted,
+		Outcome:        s.Outcome,
+	}
+}
+
+// Conflict returns the first scheduled session that overlaps a proposed one,
+// ignoring the session being rescheduled
+func Conflict(existing []db.MentoringSession, start time.Time, minutes int, exclude uuid.UUID) *db.MentoringSession {
+	end := start.Add(time.Duration(minutes) * time.Minute)
+	for i := range existing {
+		s := &existing[i]
+		if s.ID == exclude || s.Status != db.SessionScheduled {
+			continue
+		}
+		sEnd := s.ScheduledAt.Add(time.Duration(s.DurationMinutes) * time.Minute)
+		if s.ScheduledAt.Before(end) && sEnd.After(start) {
+			return s
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package mentoring
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func TestNormalizeExpertise(t *testing.T) {
+	got, err := NormalizeExpertise([]string{"Marketing ", "access to finance", "", "marketing", "Access-to-Finance"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (db.StringArray{"access-to-finance", "marketing"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	many := make([]string, MaxExpertise+1)
+	for i := range many {
+		many[i] = uuid.NewString()
+	}
+	if _, err := NormalizeExpertise(many); !errors.Is(err, ErrTooManyTags) {
+		t.Fatalf("expected too many tags, got %v", err)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	if _, err := Schedule("Africa/Kampala", []db.MentorAvailability{
+		{Weekday: 2, StartsAt: "09:00", EndsAt: "12:00"},
+		{Weekday: 2, StartsAt: "11:00", EndsAt: "13:00"},
+	}); err == nil {
+		t.Fatal("expected overlapping windows to be rejected")
+	}
+	if _, err := Schedule("Africa/Kampala", []db.MentorAvailability{{Weekday: 7, StartsAt: "09:00", EndsAt: "12:00"}}); err == nil {
+		t.Fatal("expected weekday 7 to be rejected")
+	}
+	if _, err := Schedule("Mars/Olympus", nil); err == nil {
+		t.Fatal("expected unknown time zone to be rejected")
+	}
+}
+
+func TestCheckSession(t *testing.T) {
+	kampala, _ := time.LoadLocation("Africa/Kampala")
+	now := time.Date(2024, 3, 4, 8, 0, 0, 0, kampala) // Monday
+	tuesday := func(h, m int) time.Time { return time.Date(2024, 3, 5, h, m, 0, 0, kampala) }
+
+	avail, err := Schedule("Africa/Kampala", []db.MentorAvailability{{Weekday: 2, StartsAt: "09:00", EndsAt: "12:00"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name    string
+		sched   bool
+		start   time.Time
+		minutes int
+		want    error
+	}{
+		{"inside window", true, tuesday(10, 0), 60, nil},
+		{"runs past window", true, tuesday(11, 30), 60, ErrUnavailable},
+		{"wrong day", true, tuesday(10, 0).AddDate(0, 0, 1), 60, ErrUnavailable},
+		{"no availability set", false, tuesday(18, 0), 60, nil},
+		{"too short", false, tuesday(10, 0), 10, ErrInvalidDuration},
+		{"in the past", false, now.Add(-time.Hour), 60, ErrInPast},
+		{"too far ahead", false, now.AddDate(2, 0, 0), 60, ErrTooFarAhead},
+	}
+	for _, tc := range cases {
+		sched := avail
+		if !tc.sched {
+			sched = nil
+		}
+		if err := CheckSession(sched, tc.start, tc.minutes, now); !errors.Is(err, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestServiceFor(t *testing.T) {
+	start := time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC)
+	outcome := "Drafted a pricing model"
+	s := &db.MentoringSession{
+		HubID:           uuid.New(),
+		EntrepreneurID:  uuid.New(),
+		ScheduledAt:     start,
+		DurationMinutes: 45,
+		Outcome:         &outcome,
+	}
+
+	svc := ServiceFor(s, "Grace Nakato", "Pricing")
+	if svc.HubID != s.HubID || svc.EntrepreneurID != s.EntrepreneurID {
+		t.Fatalf("service not attributed to the session's hub and mentee: %+v", svc)
+	}
+	if svc.ServiceType != ServiceType || svc.Status != db.ServiceCompleted {
+		t.Fatalf("unexpected type or status: %s %s", svc.ServiceType, svc.Status)
+	}
+	if svc.Description != "Mentoring session with Grace Nakato (45 min): Pricing" {
+		t.Fatalf("unexpected description %q", svc.Description)
+	}
+	if !svc.CompletionDate.Equal(start.Add(45*time.Minute)) || svc.Outcome != &outcome {
+		t.Fatalf("unexpected completion %v or outcome %v", svc.CompletionDate, svc.Outcome)
+	}
+}
+
+func TestConflict(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2024, 3, 5, h, m, 0, 0, time.UTC) }
+	booked := db.MentoringSession{ID: uuid.New(), ScheduledAt: at(10, 0), DurationMinutes: 60, Status: db.SessionScheduled}
+	cancelled := db.MentoringSession{ID: uuid.New(), ScheduledAt: at(14, 0), DurationMinutes: 60, Status: db.SessionCancelled}
+	existing := []db.MentoringSession{booked, cancelled}
+
+	if got := Conflict(existing, at(10, 30), 30, uuid.Nil); got == nil || got.ID != booked.ID {
+		t.Fatalf("expected overlap with the booked session, got %v", got)
+	}
+	if got := Conflict(existing, at(11, 0), 30, uuid.Nil); got != nil {
+		t.Fatalf("back-to-back sessions should not conflict, got %v", got.ID)
+	}
+	if got := Conflict(existing, at(14, 0), 30, uuid.Nil); got != nil {
+		t.Fatal("cancelled sessions should not block the slot")
+	}
+	if got := Conflict(existing, at(10, 15), 30, booked.ID); got != nil {
+		t.Fatal("a session should not conflict with itself when rescheduled")
+	}
+}
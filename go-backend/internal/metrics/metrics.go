@@ -0,0 +1,185 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+var (
+	ErrValueRequired = errors.New("a numeric value is required for this metric")
+	ErrBandRequired  = errors.New("a band is required for this metric")
+	ErrInvalidPeriod = errors.New("period start must not be after period end")
+)
+
+// CheckType validates an admin-defined metric type
+func CheckType(t *db.MetricType) error {
+	switch t.ValueType {
+	case db.MetricInteger, db.MetricDecimal:
+		if len(t.Bands) > 0 {
+			return errors.New("only BAND metrics can define bands")
+		}
+	case db.MetricBand:
+		if len(t.Bands) < 2 {
+			return errors.New("BAND metrics need at least two bands")
+		}
+		seen := map[string]bool{}
+		for _, b := range t.Bands {
+			if strings.TrimSpace(b) == "" || seen[b] {
+				return errors.New("bands must be unique and non-empty")
+			}
+			seen[b] = true
+		}
+		if t.Aggregation == db.MetricSum {
+			return errors.New("BAND metrics cannot be summed; use LATEST")
+		}
+	default:
+		return fmt.Errorf("unknown value type %q", t.ValueType)
+	}
+	if t.Aggregation != db.MetricSum && t.Aggregation != db.MetricLatest {
+		return fmt.Errorf("unknown aggregation %q", t.Aggregation)
+	}
+	return nil
+}
+
+// CheckEntry validates a reported value and period against its metric type
+func CheckEntry(t *db.MetricType, value *float64, band *string, start, end time.Time) error {
+	if start.After(end) {
+		return ErrInvalidPeriod
+	}
+	switch t.ValueType {
+	case db.MetricBand:
+		if band == nil || *band == "" {
+			return ErrBandRequired
+		}
+		if value != nil {
+			return errors.New("band metrics do not take a numeric value")
+		}
+		for _, b := range t.Bands {
+			if b == *band {
+				return nil
+			}
+		}
+		return fmt.Errorf("band must be one of: %s", strings.Join(t.Bands, ", "))
+	default:
+		if value == nil {
+			return ErrValueRequired
+		}
+		if band != nil && *band != "" {
+			return errors.New("numeric metrics do not take a band")
+		}
+		if *value < 0 || math.IsNaN(*value) || math.IsInf(*value, 0) {
+			return errors.New("value must be zero or more")
+		}
+		if t.ValueType == db.MetricInteger && *value != math.Trunc(*value) {
+			return errors.New("value must be a whole number")
+		}
+	}
+	return nil
+}
+
+// Summary aggregates the entries of one metric type
+type Summary struct {
+	MetricTypeID  uuid.UUID            `json:"metricTypeId"`
+	Key           string               `json:"key"`
+	Name          string               `json:"name"`
+	Unit          string               `json:"unit"`
+	ValueType     db.MetricValueType   `json:"valueType"`
+	Aggregation   db.MetricAggregation `json:"aggregation"`
+	Entries       int                  `json:"entries"`
+	Entrepreneurs int                  `json:"entrepreneurs"`
+	Total         *float64             `json:"total,omitempty"`
+	Average       *float64             `json:"average,omitempty"` // Per entrepreneur
+	Bands         map[string]int       `json:"bands,omitempty"`   // Entrepreneurs per latest band
+}
+
+// Summarize aggregates entries per metric type, in the order of types. SUM
+// metrics add up every entry; LATEST metrics use each entrepreneur's most
+// recent entry, totalled for numbers and counted per band for bands.
+func Summarize(types []db.MetricType, entries []db.MetricEntry) []Summary {
+	byType := map[uuid.UUID][]db.MetricEntry{}
+	for _, e := range entries {
+		byType[e.MetricTypeID] = append(byType[e.MetricTypeID], e)
+	}
+
+	out := make([]Summary, 0, len(types))
+	for _, t := range types {
+		list := byType[t.ID]
+		s := Summary{
+			MetricTypeID: t.ID,
+			Key:          t.Key,
+			Name:         t.Name,
+			Unit:         t.Unit,
+			ValueType:    t.ValueType,
+			Aggregation:  t.Aggregation,
+			Entries:      len(list),
+		}
+
+		latest := latestPerEntrepreneur(list)
+		s.Entrepreneurs = len(latest)
+
+		if t.ValueType == db.MetricBand {
+			s.Bands = map[string]int{}
+			for _, b := range t.Bands {
+				s.Bands[b] = 0
+			}
+			for _, e := range latest {
+				if e.Band != nil {
+					s.Bands[*e.Band]++
+				}
+			}
+			out = append(out, s)
+			continue
+		}
+
+		counted := list
+		if t.Aggregation == db.MetricLatest {
+			counted = counted[:0:0]
+			for _, e := range latest {
+				counted = append(counted, e)
+			}
+		}
+		var total float64
+		for _, e := range counted {
+			if e.Value != nil {
+				total += *e.Value
+			}
+		}
+		total = round2(total)
+		s.Total = &total
+		if s.Entrepreneurs > 0 {
+			avg := round2(total / float64(s.Entrepreneurs))
+			s.Average = &avg
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// latestPerEntrepreneur picks each entrepreneur's entry with the latest
+// period end, breaking ties by submission time
+func latestPerEntrepreneur(entries []db.MetricEntry) map[uuid.UUID]db.MetricEntry {
+	sorted := append([]db.MetricEntry{}, entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].PeriodEnd.Equal(sorted[j].PeriodEnd) {
+			return sorted[i].PeriodEnd.Before(sorted[j].PeriodEnd)
+		}
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+	latest := map[uuid.UUID]db.MetricEntry{}
+	for _, e := range sorted {
+		latest[e.EntrepreneurID] = e
+	}
+	return latest
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func day(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+func ptr[T any](v T) *T { return &v }
+
+var (
+	jobs    = db.MetricType{ID: uuid.New(), Key: "jobs_created", ValueType: db.MetricInteger, Aggregation: db.MetricSum}
+	staff   = db.MetricType{ID: uuid.New(), Key: "employees", ValueType: db.MetricInteger, Aggregation: db.MetricLatest}
+	revenue = db.MetricType{ID: uuid.New(), Key: "revenue_band", ValueType: db.MetricBand, Aggregation: db.MetricLatest,
+		Bands: db.StringArray{"<1k", "1k-10k", ">10k"}}
+)
+
+func TestCheckType(t *testing.T) {
+	for _, ok := range []db.MetricType{jobs, staff, revenue} {
+		if err := CheckType(&ok); err != nil {
+			t.Fatalf("expected %s to be valid: %v", ok.Key, err)
+		}
+	}
+	bad := []db.MetricType{
+		{ValueType: "TEXT", Aggregation: db.MetricSum},
+		{ValueType: db.MetricBand, Aggregation: db.MetricLatest, Bands: db.StringArray{"only"}},
+		{ValueType: db.MetricBand, Aggregation: db.MetricSum, Bands: db.StringArray{"a", "b"}},
+		{ValueType: db.MetricInteger, Aggregation: "AVG"},
+	}
+	for _, b := range bad {
+		if err := CheckType(&b); err == nil {
+			t.Fatalf("expected %+v to be rejected", b)
+		}
+	}
+}
+
+func TestCheckEntry(t *testing.T) {
+	if err := CheckEntry(&jobs, ptr(3.0), nil, day(1), day(31)); err != nil {
+		t.Fatalf("expected valid entry: %v", err)
+	}
+	if err := CheckEntry(&jobs, ptr(2.5), nil, day(1), day(31)); err == nil {
+		t.Fatalf("expected fractional jobs to be rejected")
+	}
+	if err := CheckEntry(&jobs, ptr(-1.0), nil, day(1), day(31)); err == nil {
+		t.Fatalf("expected negative value to be rejected")
+	}
+	if err := CheckEntry(&jobs, nil, nil, day(1), day(31)); err != ErrValueRequired {
+		t.Fatalf("expected missing value error, got %v", err)
+	}
+	if err := CheckEntry(&revenue, nil, ptr("1k-10k"), day(1), day(31)); err != nil {
+		t.Fatalf("expected valid band: %v", err)
+	}
+	if err := CheckEntry(&revenue, nil, ptr("huge"), day(1), day(31)); err == nil {
+		t.Fatalf("expected unknown band to be rejected")
+	}
+	if err := CheckEntry(&jobs, ptr(1.0), nil, day(31), day(1)); err != ErrInvalidPeriod {
+		t.Fatalf("expected period error, got %v", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	entries := []db.MetricEntry{
+		{MetricTypeID: jobs.ID, EntrepreneurID: a, Value: ptr(3.0), PeriodEnd: day(10)},
+		{MetricTypeID: jobs.ID, EntrepreneurID: a, Value: ptr(2.0), PeriodEnd: day(20)},
+		{MetricTypeID: jobs.ID, EntrepreneurID: b, Value: ptr(4.0), PeriodEnd: day(20)},
+		{MetricTypeID: staff.ID, EntrepreneurID: a, Value: ptr(5.0), PeriodEnd: day(10)},
+		{MetricTypeID: staff.ID, EntrepreneurID: a, Value: ptr(8.0), PeriodEnd: day(20)},
+		{MetricTypeID: staff.ID, EntrepreneurID: b, Value: ptr(2.0), PeriodEnd: day(15)},
+		{MetricTypeID: revenue.ID, EntrepreneurID: a, Band: ptr("<1k"), PeriodEnd: day(10)},
+		{MetricTypeID: revenue.ID, EntrepreneurID: a, Band: ptr(">10k"), PeriodEnd: day(20)},
+		{MetricTypeID: revenue.ID, EntrepreneurID: b, Band: ptr("1k-10k"), PeriodEnd: day(20)},
+	}
+	got := Summarize([]db.MetricType{jobs, staff, revenue}, entries)
+
+	if got[0].Entries != 3 || got[0].Entrepreneurs != 2 || *got[0].Total != 9 || *got[0].Average != 4.5 {
+		t.Fatalf("unexpected SUM summary %+v", got[0])
+	}
+	if *got[1].Total != 10 || got[1].Entrepreneurs != 2 {
+		t.Fatalf("expected LATEST to total the latest values, got %+v", got[1])
+	}
+	if got[2].Bands[">10k"] != 1 || got[2].Bands["1k-10k"] != 1 || got[2].Bands["<1k"] != 0 || got[2].Total != nil {
+		t.Fatalf("unexpected band summary %+v", got[2])
+	}
+
+	empty := Summarize([]db.MetricType{jobs}, nil)
+	if empty[0].Entries != 0 || *empty[0].Total != 0 || empty[0].Average != nil {
+		t.Fatalf("unexpected empty summary %+v", empty[0])
+	}
+}
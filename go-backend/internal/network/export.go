@@ -0,0 +1,191 @@
+package network
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+// GraphML and GEXF are the two formats Gephi imports natively. Both are
+// written with encoding/xml so names and descriptions are escaped properly.
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data,omitempty"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+// WriteGraphML writes the graph in GraphML format
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	doc := graphMLDoc{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+			{ID: "location", For: "node", AttrName: "location", AttrType: "string"},
+			{ID: "lat", For: "node", AttrName: "latitude", AttrType: "double"},
+			{ID: "lng", For: "node", AttrName: "longitude", AttrType: "double"},
+			{ID: "verified", For: "node", AttrName: "verified", AttrType: "boolean"},
+			{ID: "collaborationType", For: "edge", AttrName: "collaborationType", AttrType: "string"},
+		},
+		Graph: graphMLGraph{ID: "hubs", EdgeDefault: "undirected"},
+	}
+
+	for _, n := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: n.ID.String(),
+			Data: []graphMLData{
+				{Key: "name", Value: n.Name},
+				{Key: "location", Value: n.Location},
+				{Key: "lat", Value: formatFloat(n.Latitude)},
+				{Key: "lng", Value: formatFloat(n.Longitude)},
+				{Key: "verified", Value: strconv.FormatBool(n.Verified)},
+			},
+		})
+	}
+	for _, e := range g.Edges {
+		edge := graphMLEdge{ID: e.ID.String(), Source: e.Source.String(), Target: e.Target.String()}
+		if e.CollaborationType != "" {
+			edge.Data = []graphMLData{{Key: "collaborationType", Value: e.CollaborationType}}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, edge)
+	}
+
+	return writeXML(w, doc)
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	ID     string `xml:"id,attr"`
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+	Label  string `xml:"label,attr,omitempty"`
+}
+
+type gexfGraph struct {
+	Mode            string           `xml:"mode,attr"`
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfDoc struct {
+	XMLName xml.Name  `xml:"gexf"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+// WriteGEXF writes the graph in GEXF 1.2 format
+func (g *Graph) WriteGEXF(w io.Writer) error {
+	doc := gexfDoc{
+		Xmlns:   "http://www.gexf.net/1.2draft",
+		Version: "1.2",
+		Graph: gexfGraph{
+			Mode:            "static",
+			DefaultEdgeType: "undirected",
+			Attributes: []gexfAttributes{{
+				Class: "node",
+				Attributes: []gexfAttribute{
+					{ID: "location", Title: "location", Type: "string"},
+					{ID: "lat", Title: "latitude", Type: "double"},
+					{ID: "lng", Title: "longitude", Type: "double"},
+					{ID: "verified", Title: "verified", Type: "boolean"},
+				},
+			}},
+		},
+	}
+
+	for _, n := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gexfNode{
+			ID:    n.ID.String(),
+			Label: n.Name,
+			AttValues: []gexfAttValue{
+				{For: "location", Value: n.Location},
+				{For: "lat", Value: formatFloat(n.Latitude)},
+				{For: "lng", Value: formatFloat(n.Longitude)},
+				{For: "verified", Value: strconv.FormatBool(n.Verified)},
+			},
+		})
+	}
+	for _, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, gexfEdge{
+			ID:     e.ID.String(),
+			Source: e.Source.String(),
+			Target: e.Target.String(),
+			Label:  e.CollaborationType,
+		})
+	}
+
+	return writeXML(w, doc)
+}
+
+func writeXML(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
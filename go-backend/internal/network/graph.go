@@ -0,0 +1,259 @@
+package network
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// Node is a hub in the ecosystem graph
+type Node struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Location  string    `json:"location"`
+	Latitude  float64   `json:"lat"`
+	Longitude float64   `json:"lng"`
+	Verified  bool      `json:"verified"`
+}
+
+// Edge is an undirected collaboration between two hubs
+type Edge struct {
+	ID                uuid.UUID `json:"id"`
+	Source            uuid.UUID `json:"source"`
+	Target            uuid.UUID `json:"target"`
+	CollaborationType string    `json:"collaborationType,omitempty"`
+}
+
+// Graph is an undirected, simple graph of hubs and their connections
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+
+	index map[uuid.UUID]int
+	adj   [][]int // adjacency list by node index
+}
+
+// NewGraph builds a graph from nodes and edges. Edges referencing unknown
+// nodes, self-loops and duplicate pairs are dropped.
+func NewGraph(nodes []Node, edges []Edge) *Graph {
+	g := &Graph{
+		Nodes: make([]Node, len(nodes)),
+		index: make(map[uuid.UUID]int, len(nodes)),
+		adj:   make([][]int, len(nodes)),
+	}
+	copy(g.Nodes, nodes)
+	for i, n := range g.Nodes {
+		g.index[n.ID] = i
+	}
+
+	seen := make(map[[2]int]struct{}, len(edges))
+	for _, e := range edges {
+		a, okA := g.index[e.Source]
+		b, okB := g.index[e.Target]
+		if !okA || !okB || a == b {
+			continue
+		}
+		key := [2]int{a, b}
+		if a > b {
+			key = [2]int{b, a}
+		}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		g.Edges = append(g.Edges, e)
+		g.adj[a] = append(g.adj[a], b)
+		g.adj[b] = append(g.adj[b], a)
+	}
+	return g
+}
+
+// Has reports whether the graph contains the hub
+func (g *Graph) Has(id uuid.UUID) bool {
+	_, ok := g.index[id]
+	return ok
+}
+
+// Degree returns the number of connections per hub
+func (g *Graph) Degree() map[uuid.UUID]int {
+	out := make(map[uuid.UUID]int, len(g.Nodes))
+	for i, n := range g.Nodes {
+		out[n.ID] = len(g.adj[i])
+	}
+	return out
+}
+
+// Isolated returns hubs with no connections, in node order
+func (g *Graph) Isolated() []uuid.UUID {
+	out := []uuid.UUID{}
+	for i, n := range g.Nodes {
+		if len(g.adj[i]) == 0 {
+			out = append(out, n.ID)
+		}
+	}
+	return out
+}
+
+// Components returns the connected components, largest first. Hubs within a
+// component keep node order so results are stable.
+func (g *Graph) Components() [][]uuid.UUID {
+	visited := make([]bool, len(g.Nodes))
+	var comps [][]int
+	for start := range g.Nodes {
+		if visited[start] {
+			continue
+		}
+		comp := []int{}
+		queue := []int{start}
+		visited[start] = true
+		for len(queue) > 0 {
+			cur := queue[0]
+			queue = queue[1:]
+			comp = append(comp, cur)
+			for _, nb := range g.adj[cur] {
+				if !visited[nb] {
+					visited[nb] = true
+					queue = append(queue, nb)
+				}
+			}
+		}
+		sort.Ints(comp)
+		comps = append(comps, comp)
+	}
+
+	sort.SliceStable(comps, func(i, j int) bool { return len(comps[i]) > len(comps[j]) })
+
+	out := make([][]uuid.UUID, len(comps))
+	for i, comp := range comps {
+		out[i] = make([]uuid.UUID, len(comp))
+		for j, idx := range comp {
+			out[i][j] = g.Nodes[idx].ID
+		}
+	}
+	return out
+}
+
+// Bridges returns the connections whose removal would split a component
+// (Tarjan's bridge-finding algorithm, iterative to avoid deep recursion).
+func (g *Graph) Bridges() []Edge {
+	n := len(g.Nodes)
+	disc := make([]int, n)
+	low := make([]int, n)
+	for i := range disc {
+		disc[i] = -1
+	}
+
+	type frame struct {
+		node, parent, next int
+	}
+
+	bridgeSet := map[[2]int]struct{}{}
+	timer := 0
+	for root := 0; root < n; root++ {
+		if disc[root] != -1 {
+			continue
+		}
+		disc[root], low[root] = timer, timer
+		timer++
+		stack := []frame{{node: root, parent: -1}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next < len(g.adj[top.node]) {
+				nb := g.adj[top.node][top.next]
+				top.next++
+				if nb == top.parent {
+					continue
+				}
+				if disc[nb] == -1 {
+					disc[nb], low[nb] = timer, timer
+					timer++
+					stack = append(stack, frame{node: nb, parent: top.node})
+				} else if disc[nb] < low[top.node] {
+					low[top.node] = disc[nb]
+				}
+				continue
+			}
+
+			// finished exploring this node; propagate low-link to parent
+			done := *top
+			stack = stack[:len(stack)-1]
+			if done.parent >= 0 {
+				if low[done.node] < low[done.parent] {
+					low[done.parent] = low[done.node]
+				}
+				if low[done.node] > disc[done.parent] {
+					a, b := done.parent, done.node
+					if a > b {
+						a, b = b, a
+					}
+					bridgeSet[[2]int{a, b}] = struct{}{}
+				}
+			}
+		}
+	}
+
+	out := []Edge{}
+	for _, e := range g.Edges {
+		a, b := g.index[e.Source], g.index[e.Target]
+		if a > b {
+			a, b = b, a
+		}
+		if _, ok := bridgeSet[[2]int{a, b}]; ok {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// ShortestPath returns the hubs on a shortest path from one hub to another,
+// inclusive of both ends. It returns nil if either hub is unknown or no path
+// exists.
+func (g *Graph) ShortestPath(from, to uuid.UUID) []uuid.UUID {
+	src, okA := g.index[from]
+	dst, okB := g.index[to]
+	if !okA || !okB {
+		return nil
+	}
+	if src == dst {
+		return []uuid.UUID{from}
+	}
+
+	prev := make([]int, len(g.Nodes))
+	for i := range prev {
+		prev[i] = -1
+	}
+	prev[src] = src
+	queue := []int{src}
+	for len(queue) > 0 && prev[dst] == -1 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, nb := range g.adj[cur] {
+			if prev[nb] == -1 {
+				prev[nb] = cur
+				queue = append(queue, nb)
+			}
+		}
+	}
+	if prev[dst] == -1 {
+		return nil
+	}
+
+	path := []uuid.UUID{}
+	for cur := dst; cur != src; cur = prev[cur] {
+		path = append(path, g.Nodes[cur].ID)
+	}
+	path = append(path, from)
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Density is the ratio of existing connections to all possible connections
+func (g *Graph) Density() float64 {
+	n := len(g.Nodes)
+	if n < 2 {
+		return 0
+	}
+	return float64(2*len(g.Edges)) / float64(n*(n-1))
+}
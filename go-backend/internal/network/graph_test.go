@@ -0,0 +1,111 @@
+package network
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// two triangles joined by a single bridge, plus one isolated hub:
+//
+//	a - b       d - e
+//	 \ /  ----   \ /
+//	  c          f        g
+func testGraph() (*Graph, map[string]uuid.UUID) {
+	ids := map[string]uuid.UUID{}
+	nodes := []Node{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		ids[name] = uuid.New()
+		nodes = append(nodes, Node{ID: ids[name], Name: name})
+	}
+	edge := func(x, y string) Edge { return Edge{ID: uuid.New(), Source: ids[x], Target: ids[y]} }
+	edges := []Edge{
+		edge("a", "b"), edge("b", "c"), edge("c", "a"),
+		edge("d", "e"), edge("e", "f"), edge("f", "d"),
+		edge("c", "d"),
+		edge("b", "a"), // duplicate in reverse direction
+		edge("g", "g"), // self-loop
+	}
+	return NewGraph(nodes, edges), ids
+}
+
+func TestGraphDegreeAndIsolated(t *testing.T) {
+	g, ids := testGraph()
+	if len(g.Edges) != 7 {
+		t.Fatalf("expected duplicates and self-loops dropped, got %d edges", len(g.Edges))
+	}
+	deg := g.Degree()
+	if deg[ids["c"]] != 3 || deg[ids["a"]] != 2 || deg[ids["g"]] != 0 {
+		t.Fatalf("unexpected degrees: %v", deg)
+	}
+	iso := g.Isolated()
+	if len(iso) != 1 || iso[0] != ids["g"] {
+		t.Fatalf("unexpected isolated hubs: %v", iso)
+	}
+}
+
+func TestGraphComponents(t *testing.T) {
+	g, ids := testGraph()
+	comps := g.Components()
+	if len(comps) != 2 {
+		t.Fatalf("expected 2 components, got %d", len(comps))
+	}
+	if len(comps[0]) != 6 || len(comps[1]) != 1 || comps[1][0] != ids["g"] {
+		t.Fatalf("unexpected components: %v", comps)
+	}
+}
+
+func TestGraphBridges(t *testing.T) {
+	g, ids := testGraph()
+	bridges := g.Bridges()
+	if len(bridges) != 1 {
+		t.Fatalf("expected 1 bridge, got %d", len(bridges))
+	}
+	b := bridges[0]
+	if !(b.Source == ids["c"] && b.Target == ids["d"]) {
+		t.Fatalf("unexpected bridge: %+v", b)
+	}
+}
+
+func TestGraphShortestPath(t *testing.T) {
+	g, ids := testGraph()
+	path := g.ShortestPath(ids["a"], ids["e"])
+	want := []uuid.UUID{ids["a"], ids["c"], ids["d"], ids["e"]}
+	if len(path) != len(want) {
+		t.Fatalf("unexpected path length: %v", path)
+	}
+	for i := range want {
+		if path[i] != want[i] {
+			t.Fatalf("unexpected path: %v", path)
+		}
+	}
+	if g.ShortestPath(ids["a"], ids["g"]) != nil {
+		t.Fatalf("expected no path to isolated hub")
+	}
+	if p := g.ShortestPath(ids["a"], ids["a"]); len(p) != 1 {
+		t.Fatalf("expected trivial path, got %v", p)
+	}
+}
+
+func TestGraphExports(t *testing.T) {
+	g, _ := testGraph()
+	g.Nodes[0].Name = "Hub <One> & Co"
+
+	var gml bytes.Buffer
+	if err := g.WriteGraphML(&gml); err != nil {
+		t.Fatalf("graphml error: %v", err)
+	}
+	if !strings.Contains(gml.String(), "<graphml") || !strings.Contains(gml.String(), "Hub &lt;One&gt; &amp; Co") {
+		t.Fatalf("unexpected graphml output: %s", gml.String())
+	}
+
+	var gexf bytes.Buffer
+	if err := g.WriteGEXF(&gexf); err != nil {
+		t.Fatalf("gexf error: %v", err)
+	}
+	if strings.Count(gexf.String(), "<edge ") != 7 {
+		t.Fatalf("unexpected gexf output: %s", gexf.String())
+	}
+}
@@ -0,0 +1,47 @@
+package network
+
+import (
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// Load builds the hub graph from all centers and their active connections.
+// Set verifiedOnly to restrict the graph to verified hubs.
+func Load(gdb *gorm.DB, verifiedOnly bool) (*Graph, error) {
+	filters := db.CenterFilters{}
+	if verifiedOnly {
+		filters.VerificationStatus = "verified"
+	}
+	centers, err := db.ListCenters(gdb, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	connections, err := db.ListActiveConnections(gdb)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]Node, len(centers))
+	for i, center := range centers {
+		nodes[i] = Node{
+			ID:        center.ID,
+			Name:      center.Name,
+			Location:  center.Location,
+			Latitude:  center.Latitude,
+			Longitude: center.Longitude,
+			Verified:  center.Verified,
+		}
+	}
+
+	edges := make([]Edge, len(connections))
+	for i, conn := range connections {
+		edges[i] = Edge{ID: conn.ID, Source: conn.CenterAID, Target: conn.CenterBID}
+		if conn.CollaborationType != nil {
+			edges[i].CollaborationType = *conn.CollaborationType
+		}
+	}
+
+	return NewGraph(nodes, edges), nil
+}
@@ -0,0 +1,33 @@
+// Package portfolio validates entrepreneurs' showcase items.
+package portfolio
+
+import (
+	"fmt"
+	"strings"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// MaxMediaPerItem caps the files on one item
+const MaxMediaPerItem = 12
+
+// ParseKind accepts an item kind in any case; blank means a product
+func ParseKind(s string) (db.PortfolioItemKind, error) {
+	k := db.PortfolioItemKind(strings.ToUpper(strings.TrimSpace(s)))
+	switch k {
+	case "":
+		return db.PortfolioProduct, nil
+	case db.PortfolioProduct, db.PortfolioService, db.PortfolioPitchDeck, db.PortfolioOther:
+		return k, nil
+	}
+	return "", fmt.Errorf("kind must be PRODUCT, SERVICE, PITCH_DECK or OTHER, got %q", s)
+}
+
+// NormalizeCurrency upper-cases a three-letter currency code
+func NormalizeCurrency(s string) (string, error) {
+	c := strings.ToUpper(strings.TrimSpace(s))
+	if len(c) != 3 || strings.Trim(c, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("currency must be a three-letter code, got %q", s)
+	}
+	return c, nil
+}
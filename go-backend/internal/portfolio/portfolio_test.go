@@ -0,0 +1,30 @@
+package portfolio
+
+import (
+	"testing"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func TestParseKind(t *testing.T) {
+	if k, err := ParseKind(""); err != nil || k != db.PortfolioProduct {
+		t.Fatalf("blank kind: got %q, %v", k, err)
+	}
+	if k, err := ParseKind("pitch_deck"); err != nil || k != db.PortfolioPitchDeck {
+		t.Fatalf("got %q, %v", k, err)
+	}
+	if _, err := ParseKind("poster"); err == nil {
+		t.Fatal("expected unknown kind to be rejected")
+	}
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	if c, err := NormalizeCurrency(" ugx "); err != nil || c != "UGX" {
+		t.Fatalf("got %q, %v", c, err)
+	}
+	for _, bad := range []string{"US", "U$D", "EURO"} {
+		if _, err := NormalizeCurrency(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Scoring weights; they sum to 1 so a perfect candidate scores 1.0
+const (
+	WeightServices      = 0.35
+	WeightResources     = 0.25
+	WeightProximity     = 0.25
+	WeightEntrepreneurs = 0.15
+
+	// ProximityScaleKm is the distance at which the proximity score halves
+	ProximityScaleKm = 10.0
+	// SharedEntrepreneursCap is the number of shared entrepreneurs that earns the full score
+	SharedEntrepreneursCap = 5
+)
+
+// Hub is the subset of a community center used for scoring
+type Hub struct {
+	ID        uuid.UUID
+	Name      string
+	Latitude  float64
+	Longitude float64
+	Services  []string
+	Resources []string
+}
+
+// Candidate is a hub that could be recommended, with the number of
+// entrepreneurs it shares with the target hub
+type Candidate struct {
+	Hub                 Hub
+	SharedEntrepreneurs int
+}
+
+// Recommendation is a scored candidate with a human-readable explanation
+type Recommendation struct {
+	Hub                    Hub      `json:"-"`
+	Score                  float64  `json:"score"`
+	ServiceScore           float64  `json:"serviceScore"`
+	ResourceScore          float64  `json:"resourceScore"`
+	ProximityScore         float64  `json:"proximityScore"`
+	EntrepreneurScore      float64  `json:"entrepreneurScore"`
+	DistanceKm             float64  `json:"distanceKm"`
+	ComplementaryServices  []string `json:"complementaryServices"`
+	ComplementaryResources []string `json:"complementaryResources"`
+	SharedEntrepreneurs    int      `json:"sharedEntrepreneurs"`
+	Reasons                []string `json:"reasons"`
+}
+
+// Rank scores candidates against the target hub and returns them best first.
+// Candidates in exclude (typically hubs already connected) and the target
+// itself are skipped. A limit of zero or less returns every candidate.
+func Rank(target Hub, candidates []Candidate, exclude map[uuid.UUID]struct{}, limit int) []Recommendation {
+	out := make([]Recommendation, 0, len(candidates))
+	for _, cand := range candidates {
+		if cand.Hub.ID == target.ID {
+			continue
+		}
+		if _, skip := exclude[cand.Hub.ID]; skip {
+			continue
+		}
+		out = append(out, Score(target, cand))
+	}
+
+	// deterministic ordering: score, then distance, then name, then id
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.DistanceKm != b.DistanceKm {
+			return a.DistanceKm < b.DistanceKm
+		}
+		if a.Hub.Name != b.Hub.Name {
+			return a.Hub.Name < b.Hub.Name
+		}
+		return a.Hub.ID.String() < b.Hub.ID.String()
+	})
+
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
+
+// Score computes the weighted score and explanation for one candidate
+func Score(target Hub, cand Candidate) Recommendation {
+	services, serviceScore := complement(target.Services, cand.Hub.Services)
+	resources, resourceScore := complement(target.Resources, cand.Hub.Resources)
+	distance := DistanceKm(target.Latitude, target.Longitude, cand.Hub.Latitude, cand.Hub.Longitude)
+	proximityScore := ProximityScaleKm / (ProximityScaleKm + distance)
+
+	shared := cand.SharedEntrepreneurs
+	if shared < 0 {
+		shared = 0
+	}
+	entrepreneurScore := float64(min(shared, SharedEntrepreneursCap)) / SharedEntrepreneursCap
+
+	score := WeightServices*serviceScore +
+		WeightResources*resourceScore +
+		WeightProximity*proximityScore +
+		WeightEntrepreneurs*entrepreneurScore
+
+	rec := Recommendation{
+		Hub:                    cand.Hub,
+		Score:                  round(score),
+		ServiceScore:           round(serviceScore),
+		ResourceScore:          round(resourceScore),
+		ProximityScore:         round(proximityScore),
+		EntrepreneurScore:      round(entrepreneurScore),
+		DistanceKm:             math.Round(distance*10) / 10,
+		ComplementaryServices:  services,
+		ComplementaryResources: resources,
+		SharedEntrepreneurs:    shared,
+	}
+	rec.Reasons = explain(rec)
+	return rec
+}
+
+// complement returns the candidate's values the target lacks (compared
+// case-insensitively) and the share of the candidate's offering they make up
+func complement(target, candidate []string) ([]string, float64) {
+	have := make(map[string]struct{}, len(target))
+	for _, v := range target {
+		have[normalize(v)] = struct{}{}
+	}
+
+	seen := map[string]struct{}{}
+	missing := []string{}
+	total := 0
+	for _, v := range candidate {
+		key := normalize(v)
+		if key == "" {
+			continue
+		}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		total++
+		if _, ok := have[key]; !ok {
+			missing = append(missing, strings.TrimSpace(v))
+		}
+	}
+	sort.Strings(missing)
+
+	if total == 0 {
+		return missing, 0
+	}
+	return missing, float64(len(missing)) / float64(total)
+}
+
+func explain(r Recommendation) []string {
+	reasons := []string{}
+	if len(r.ComplementaryServices) > 0 {
+		reasons = append(reasons, fmt.Sprintf("Offers services you don't: %s", joinList(r.ComplementaryServices)))
+	}
+	if len(r.ComplementaryResources) > 0 {
+		reasons = append(reasons, fmt.Sprintf("Has resources you lack: %s", joinList(r.ComplementaryResources)))
+	}
+	switch {
+	case r.DistanceKm < 5:
+		reasons = append(reasons, fmt.Sprintf("Very close by (%.1f km away)", r.DistanceKm))
+	case r.DistanceKm < 25:
+		reasons = append(reasons, fmt.Sprintf("Nearby (%.1f km away)", r.DistanceKm))
+	}
+	if r.SharedEntrepreneurs == 1 {
+		reasons = append(reasons, "Already supports 1 of your entrepreneurs")
+	} else if r.SharedEntrepreneurs > 1 {
+		reasons = append(reasons, fmt.Sprintf("Already supports %d of your entrepreneurs", r.SharedEntrepreneurs))
+	}
+	return reasons
+}
+
+func joinList(items []string) string {
+	const maxShown = 3
+	if len(items) <= maxShown {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:maxShown], ", "), len(items)-maxShown)
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func round(f float64) float64 {
+	return math.Round(f*1000) / 1000
+}
+
+// DistanceKm returns the great-circle distance between two coordinates
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := func(d float64) float64 { return d * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
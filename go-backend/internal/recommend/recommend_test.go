@@ -0,0 +1,85 @@
+package recommend
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func hub(name string, lat, lng float64, services, resources []string) Hub {
+	return Hub{ID: uuid.New(), Name: name, Latitude: lat, Longitude: lng, Services: services, Resources: resources}
+}
+
+func TestDistanceKm(t *testing.T) {
+	// Kampala to Entebbe is roughly 35 km as the crow flies
+	d := DistanceKm(0.3476, 32.5825, 0.0512, 32.4637)
+	if math.Abs(d-35.4) > 1 {
+		t.Fatalf("unexpected distance: %.2f", d)
+	}
+	if DistanceKm(1, 1, 1, 1) != 0 {
+		t.Fatalf("expected zero distance for identical points")
+	}
+}
+
+func TestScoreComplementIsCaseInsensitive(t *testing.T) {
+	target := hub("target", 0.3, 32.5, []string{"Mentorship", "Training"}, []string{"Meeting Room"})
+	cand := Candidate{Hub: hub("cand", 0.3, 32.5, []string{"mentorship ", "Legal Aid"}, []string{"meeting room", "Computer Lab"})}
+	rec := Score(target, cand)
+	if len(rec.ComplementaryServices) != 1 || rec.ComplementaryServices[0] != "Legal Aid" {
+		t.Fatalf("unexpected services: %v", rec.ComplementaryServices)
+	}
+	if rec.ServiceScore != 0.5 || rec.ResourceScore != 0.5 {
+		t.Fatalf("unexpected scores: %+v", rec)
+	}
+	if rec.ProximityScore != 1 {
+		t.Fatalf("expected full proximity score, got %v", rec.ProximityScore)
+	}
+	if len(rec.Reasons) != 3 {
+		t.Fatalf("unexpected reasons: %v", rec.Reasons)
+	}
+}
+
+func TestRankOrderingAndExclusion(t *testing.T) {
+	target := hub("target", 0.30, 32.50, []string{"Training"}, []string{"Library"})
+	near := hub("near", 0.31, 32.50, []string{"Legal Aid"}, []string{"Studio"})
+	far := hub("far", 2.00, 33.50, []string{"Legal Aid"}, []string{"Studio"})
+	shared := hub("shared", 2.00, 33.50, []string{"Legal Aid"}, []string{"Studio"})
+	connected := hub("connected", 0.30, 32.50, []string{"Legal Aid"}, []string{"Studio"})
+
+	candidates := []Candidate{
+		{Hub: far},
+		{Hub: target},
+		{Hub: connected},
+		{Hub: shared, SharedEntrepreneurs: 10},
+		{Hub: near},
+	}
+	exclude := map[uuid.UUID]struct{}{connected.ID: {}}
+
+	recs := Rank(target, candidates, exclude, 0)
+	if len(recs) != 3 {
+		t.Fatalf("expected target and connected hubs excluded, got %d", len(recs))
+	}
+	if recs[0].Hub.ID != near.ID || recs[1].Hub.ID != shared.ID || recs[2].Hub.ID != far.ID {
+		t.Fatalf("unexpected order: %s, %s, %s", recs[0].Hub.Name, recs[1].Hub.Name, recs[2].Hub.Name)
+	}
+	if recs[1].EntrepreneurScore != 1 {
+		t.Fatalf("expected shared entrepreneurs capped at 1, got %v", recs[1].EntrepreneurScore)
+	}
+
+	// identical inputs must always produce identical output
+	again := Rank(target, candidates, exclude, 2)
+	if len(again) != 2 || again[0].Hub.ID != recs[0].Hub.ID || again[1].Score != recs[1].Score {
+		t.Fatalf("ranking is not deterministic")
+	}
+}
+
+func TestRankTieBreaksByName(t *testing.T) {
+	target := hub("target", 0, 0, nil, nil)
+	b := hub("Bravo", 1, 1, []string{"X"}, nil)
+	a := hub("Alpha", 1, 1, []string{"X"}, nil)
+	recs := Rank(target, []Candidate{{Hub: b}, {Hub: a}}, nil, 0)
+	if recs[0].Hub.Name != "Alpha" {
+		t.Fatalf("expected name tie-break, got %s first", recs[0].Hub.Name)
+	}
+}
@@ -0,0 +1,28 @@
+package reporting
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// WriteCSV writes the header, rows and totals as plain CSV
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, len(r.Columns))
+	for i, col := range r.Columns {
+		header[i] = col.Title
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(r.Rows); err != nil {
+		return err
+	}
+	if r.Totals != nil {
+		if err := cw.Write(r.Totals); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,129 @@
+package reporting
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleReport(rows int) *Report {
+	r := &Report{
+		Title:       "Services by Type",
+		Period:      "2024-01-01 to 2024-03-31",
+		Scope:       "Alpha Hub (Kampala)",
+		GeneratedAt: time.Date(2024, 4, 1, 9, 0, 0, 0, time.UTC),
+		Columns:     []Column{{Title: "Service Type"}, {Title: "Services", Numeric: true}},
+		Totals:      []string{"Total", strconv.Itoa(rows)},
+	}
+	for i := 0; i < rows; i++ {
+		r.Rows = append(r.Rows, []string{fmt.Sprintf("Type %d – café & <co>", i), "1"})
+	}
+	return r
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport(2).WriteCSV(&buf); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(records) != 4 || records[0][0] != "Service Type" || records[3][0] != "Total" {
+		t.Fatalf("unexpected csv %v", records)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport(3).WriteXLSX(&buf); err != nil {
+		t.Fatalf("write xlsx: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("open xlsx: %v", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(body)
+		if strings.HasSuffix(f.Name, ".xml") || strings.HasSuffix(f.Name, ".rels") {
+			dec := xml.NewDecoder(bytes.NewReader(body))
+			for {
+				if _, err := dec.Token(); err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatalf("%s is not well-formed: %v", f.Name, err)
+				}
+			}
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Fatalf("missing part %s", name)
+		}
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	if !strings.Contains(sheet, `<c r="B7"><v>1</v></c>`) {
+		t.Fatalf("expected numeric cells to be stored as numbers:\n%s", sheet)
+	}
+	if !strings.Contains(sheet, "&lt;co&gt;") {
+		t.Fatalf("expected cell text to be escaped")
+	}
+}
+
+func TestWritePDF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sampleReport(120).WritePDF(&buf); err != nil {
+		t.Fatalf("write pdf: %v", err)
+	}
+	pdf := buf.Bytes()
+	if !bytes.HasPrefix(pdf, []byte("%PDF-1.4")) || !bytes.HasSuffix(pdf, []byte("%%EOF\n")) {
+		t.Fatalf("missing PDF header or trailer")
+	}
+
+	// Every xref entry must point at the start of its object
+	m := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(pdf)
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(pdf[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point at the xref table")
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if want := fmt.Sprintf("%d 0 obj", i+1); !bytes.HasPrefix(pdf[off:], []byte(want)) {
+			t.Fatalf("xref entry %d points at %q", i+1, pdf[off:off+10])
+		}
+	}
+
+	pages := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(pdf)
+	if n, _ := strconv.Atoi(string(pages[1])); n < 2 {
+		t.Fatalf("expected 120 rows to span several pages, got %d", n)
+	}
+	if !bytes.Contains(pdf, []byte(`caf\351 & <co>`)) || !bytes.Contains(pdf, []byte(`\226`)) {
+		t.Fatalf("expected WinAnsi-encoded text")
+	}
+	if !bytes.Contains(pdf, []byte("(Total)")) {
+		t.Fatalf("expected totals on the last page")
+	}
+}
+
+func TestPDFString(t *testing.T) {
+	if got := pdfString(`a(b)\c`); got != `(a\(b\)\\c)` {
+		t.Fatalf("unexpected escape %s", got)
+	}
+	if got := pdfString("日本"); got != "(??)" {
+		t.Fatalf("expected unsupported runes to be replaced, got %s", got)
+	}
+}
@@ -0,0 +1,50 @@
+package reporting
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// Generate loads the records behind a report and builds it
+func Generate(gdb *gorm.DB, kind Kind, p Params) (*Report, error) {
+	filters := db.ReportFilters{From: p.From, To: p.To, HubIDs: p.HubIDs}
+
+	switch kind {
+	case EntrepreneursServed:
+		enrollments, err := db.ListReportEnrollments(gdb, filters)
+		if err != nil {
+			return nil, err
+		}
+		services, err := db.ListReportServices(gdb, filters, false)
+		if err != nil {
+			return nil, err
+		}
+		return BuildEntrepreneursServed(p, enrollments, services), nil
+	case ServicesByType:
+		services, err := db.ListReportServices(gdb, filters, false)
+		if err != nil {
+			return nil, err
+		}
+		return BuildServicesByType(p, services), nil
+	case Collaborations:
+		services, err := db.ListReportServices(gdb, filters, true)
+		if err != nil {
+			return nil, err
+		}
+		activities, err := db.ListReportActivities(gdb, filters, db.ActivityCollaboration)
+		if err != nil {
+			return nil, err
+		}
+		return BuildCollaborations(p, services, activities), nil
+	case InvestorLinkages:
+		services, err := db.ListReportServices(gdb, filters, false)
+		if err != nil {
+			return nil, err
+		}
+		return BuildInvestorLinkages(p, services), nil
+	}
+	return nil, fmt.Errorf("unknown report %q", kind)
+}
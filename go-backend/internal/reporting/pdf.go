@@ -0,0 +1,259 @@
+package reporting
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The PDF is written by hand rather than through a library: landscape A4
+// pages holding one table, set in the standard Helvetica fonts every reader
+// ships with, so nothing needs embedding. Text is WinAnsi encoded; runes
+// outside that set print as '?'.
+
+const (
+	pageWidth   = 842.0
+	pageHeight  = 595.0
+	pageMargin  = 36.0
+	titleSize   = 16.0
+	metaSize    = 9.0
+	tableSize   = 8.0
+	rowHeight   = 14.0
+	cellPadding = 3.0
+	footerSpace = 24.0
+)
+
+// WritePDF writes the report as a paginated PDF table with the header row
+// repeated on every page
+func (r *Report) WritePDF(w io.Writer) error {
+	widths := r.pdfColumnWidths()
+	rows := r.Rows
+	if len(rows) == 0 {
+		rows = [][]string{{"No records for this period."}}
+	}
+
+	// Paginate: the first page loses room to the title block
+	firstTop := pageHeight - pageMargin - titleSize - 4*metaSize*1.5 - rowHeight
+	laterTop := pageHeight - pageMargin - metaSize*1.5 - rowHeight
+	bottom := pageMargin + footerSpace
+	perPage := func(top float64) int { return int((top-bottom)/rowHeight) - 2 } // Less the header and totals rows
+	var pages [][][]string
+	for first := true; len(rows) > 0 || first; first = false {
+		top := laterTop
+		if first {
+			top = firstTop
+		}
+		n := perPage(top)
+		if n > len(rows) {
+			n = len(rows)
+		}
+		pages = append(pages, rows[:n])
+		rows = rows[n:]
+	}
+
+	streams := make([]string, len(pages))
+	for i, pageRows := range pages {
+		var s pdfContent
+		top := laterTop
+		if i == 0 {
+			top = firstTop
+			y := pageHeight - pageMargin - titleSize
+			s.text("F2", titleSize, pageMargin, y, r.Title)
+			for _, line := range []string{
+				"Period: " + r.Period,
+				"Hubs: " + r.Scope,
+				"Generated: " + r.GeneratedAt.Format("2006-01-02 15:04 MST"),
+			} {
+				y -= metaSize * 1.5
+				s.text("F1", metaSize, pageMargin, y, line)
+			}
+		} else {
+			s.text("F2", metaSize, pageMargin, pageHeight-pageMargin-metaSize, r.Title+" ("+r.Period+")")
+		}
+
+		y := top
+		header := make([]string, len(r.Columns))
+		for j, col := range r.Columns {
+			header[j] = col.Title
+		}
+		r.pdfRow(&s, "F2", header, widths, y, false)
+		s.line(pageMargin, y-4, pageWidth-pageMargin, y-4)
+		for _, cells := range pageRows {
+			y -= rowHeight
+			r.pdfRow(&s, "F1", cells, widths, y, true)
+		}
+		if i == len(pages)-1 && r.Totals != nil {
+			y -= rowHeight
+			s.line(pageMargin, y+rowHeight-4, pageWidth-pageMargin, y+rowHeight-4)
+			r.pdfRow(&s, "F2", r.Totals, widths, y, true)
+		}
+
+		footer := fmt.Sprintf("Page %d of %d", i+1, len(pages))
+		s.text("F1", tableSize, pageWidth-pageMargin-textWidth(footer, tableSize), pageMargin, footer)
+		streams[i] = s.String()
+	}
+
+	return writePDFDocument(w, r.Title, r.GeneratedAt.Format("20060102150405Z"), streams)
+}
+
+// pdfRow writes one table row; numeric cells are right-aligned
+func (r *Report) pdfRow(s *pdfContent, font string, cells []string, widths []float64, y float64, alignNumbers bool) {
+	x := pageMargin
+	for i, width := range widths {
+		if i < len(cells) && cells[i] != "" {
+			value := truncate(cells[i], width-2*cellPadding, tableSize)
+			tx := x + cellPadding
+			if alignNumbers && r.Columns[i].Numeric {
+				tx = x + width - cellPadding - textWidth(value, tableSize)
+			}
+			s.text(font, tableSize, tx, y, value)
+		}
+		x += width
+	}
+}
+
+// pdfColumnWidths shares the printable width among columns in proportion to
+// their content, with a floor so short numeric columns stay legible
+func (r *Report) pdfColumnWidths() []float64 {
+	chars := r.columnWidths(40)
+	total := 0
+	for i := range chars {
+		if chars[i] < 6 {
+			chars[i] = 6
+		}
+		total += chars[i]
+	}
+	widths := make([]float64, len(chars))
+	for i, n := range chars {
+		widths[i] = (pageWidth - 2*pageMargin) * float64(n) / float64(total)
+	}
+	return widths
+}
+
+// truncate shortens s with an ellipsis until it fits width points
+func truncate(s string, width, size float64) string {
+	if textWidth(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && textWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// textWidth approximates a Helvetica string's width in points using the
+// font's widths for digits, capitals and narrow glyphs
+func textWidth(s string, size float64) float64 {
+	units := 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			units += 556
+		case r >= 'A' && r <= 'Z':
+			units += 667
+		case strings.ContainsRune(" .,:;'!|iljtfI()[]-/", r):
+			units += 278
+		case r == 'm' || r == 'w' || r == 'M' || r == 'W':
+			units += 833
+		default:
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// pdfContent accumulates a page's content stream operators
+type pdfContent struct {
+	strings.Builder
+}
+
+func (s *pdfContent) text(font string, size, x, y float64, value string) {
+	fmt.Fprintf(s, "BT /%s %.1f Tf %.2f %.2f Td %s Tj ET\n", font, size, x, y, pdfString(value))
+}
+
+func (s *pdfContent) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(s, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// winAnsi maps the typographic runes WinAnsiEncoding places in 0x80-0x9F
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// pdfString encodes a literal string operand in WinAnsi, escaping
+// delimiters and writing non-ASCII bytes as octal
+func pdfString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7F:
+			b.WriteRune(r)
+		case r < 0x20 || r == 0x7F:
+			b.WriteByte(' ')
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			if c, ok := winAnsi[r]; ok {
+				fmt.Fprintf(&b, "\\%03o", c)
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// writePDFDocument assembles the catalog, fonts, pages and cross-reference
+// table around the given page content streams
+func writePDFDocument(w io.Writer, title, created string, streams []string) error {
+	const firstPage = 6 // Objects 1-5 are the catalog, page tree, two fonts and info
+	kids := make([]string, len(streams))
+	for i := range streams {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(streams)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Title %s /Producer (Community Centres Platform) /CreationDate (D:%s) >>", pdfString(title), created),
+	}
+	for i, stream := range streams {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				pageWidth, pageHeight, firstPage+2*i+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(stream), stream),
+		)
+	}
+
+	bw := bufio.NewWriter(w)
+	offset := 0
+	write := func(s string) {
+		n, _ := bw.WriteString(s)
+		offset += n
+	}
+
+	write("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(objects))
+	for i, body := range objects {
+		offsets[i] = offset
+		write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", i+1, body))
+	}
+
+	xref := offset
+	write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(objects)+1))
+	for _, off := range offsets {
+		write(fmt.Sprintf("%010d 00000 n \n", off))
+	}
+	write(fmt.Sprintf("trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref))
+	return bw.Flush()
+}
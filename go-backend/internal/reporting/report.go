@@ -0,0 +1,359 @@
+package reporting
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// Kind names a parameterized funder report
+type Kind string
+
+const (
+	EntrepreneursServed Kind = "entrepreneurs-served"
+	ServicesByType      Kind = "services-by-type"
+	Collaborations      Kind = "collaborations"
+	InvestorLinkages    Kind = "investor-linkages"
+)
+
+// Definition describes a report for clients choosing what to run
+type Definition struct {
+	Kind        Kind   `json:"kind"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// Definitions lists the available reports in display order
+var Definitions = []Definition{
+	{EntrepreneursServed, "Entrepreneurs Served", "Entrepreneurs enrolled at, or receiving services from, each hub during the period"},
+	{ServicesByType, "Services by Type", "Service provisions running during the period, broken down by type and status"},
+	{Collaborations, "Hub Collaborations", "Services co-delivered by partner hubs and collaboration activity posted during the period"},
+	{InvestorLinkages, "Investor Linkages", "Entrepreneurs linked to investors through service provisions during the period"},
+}
+
+// Lookup returns the definition of a report kind
+func Lookup(kind Kind) (Definition, bool) {
+	for _, d := range Definitions {
+		if d.Kind == kind {
+			return d, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Params are the inputs every report takes
+type Params struct {
+	From     time.Time // Inclusive date
+	To       time.Time // Inclusive date
+	HubIDs   []uuid.UUID
+	HubNames []string // Labels for HubIDs, shown in the report scope
+}
+
+// Scope describes which hubs the report covers
+func (p Params) Scope() string {
+	if len(p.HubIDs) == 0 {
+		return "All hubs"
+	}
+	if len(p.HubNames) > 0 {
+		return strings.Join(p.HubNames, ", ")
+	}
+	return fmt.Sprintf("%d hubs", len(p.HubIDs))
+}
+
+// Period formats the report's date range
+func (p Params) Period() string {
+	return p.From.Format(dateLayout) + " to " + p.To.Format(dateLayout)
+}
+
+// LastQuarter returns the most recent complete calendar quarter before now
+func LastQuarter(now time.Time) (time.Time, time.Time) {
+	start := time.Date(now.Year(), time.Month((int(now.Month())-1)/3*3+1), 1, 0, 0, 0, 0, now.Location())
+	from := start.AddDate(0, -3, 0)
+	return from, start.AddDate(0, 0, -1)
+}
+
+// Column is a report column; numeric columns are written as numbers where
+// the export format supports it
+type Column struct {
+	Title   string `json:"title"`
+	Numeric bool   `json:"numeric"`
+}
+
+// Report is a rendered table ready for export
+type Report struct {
+	Kind        Kind       `json:"kind"`
+	Title       string     `json:"title"`
+	Period      string     `json:"period"`
+	Scope       string     `json:"scope"`
+	GeneratedAt time.Time  `json:"generatedAt"`
+	Columns     []Column   `json:"columns"`
+	Rows        [][]string `json:"rows"`
+	Totals      []string   `json:"totals,omitempty"`
+}
+
+const dateLayout = "2006-01-02"
+
+func newReport(kind Kind, p Params, columns ...Column) *Report {
+	def, _ := Lookup(kind)
+	return &Report{
+		Kind:        kind,
+		Title:       def.Title,
+		Period:      p.Period(),
+		Scope:       p.Scope(),
+		GeneratedAt: time.Now().UTC(),
+		Columns:     columns,
+		Rows:        [][]string{},
+	}
+}
+
+func text(title string) Column   { return Column{Title: title} }
+func number(title string) Column { return Column{Title: title, Numeric: true} }
+
+func itoa(n int) string { return strconv.Itoa(n) }
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+// BuildEntrepreneursServed lists each hub's entrepreneurs with their
+// enrollment and the number of services the hub led for them
+func BuildEntrepreneursServed(p Params, enrollments []db.HubEnrollment, services []db.ServiceProvision) *Report {
+	r := newReport(EntrepreneursServed, p,
+		text("Hub"), text("Business"), text("Business Type"), text("Enrollment Status"), text("Enrolled On"), number("Services"))
+
+	type key struct{ hub, entrepreneur uuid.UUID }
+	type line struct {
+		hub, business, businessType, status, enrolled string
+		services                                      int
+	}
+	lines := map[key]*line{}
+	get := func(hub *db.CommunityCenter, e *db.Entrepreneur) *line {
+		k := key{hub.ID, e.ID}
+		if lines[k] == nil {
+			lines[k] = &line{hub: hub.Name, business: e.BusinessName, businessType: e.BusinessType}
+		}
+		return lines[k]
+	}
+
+	for i := range enrollments {
+		e := &enrollments[i]
+		l := get(&e.Hub, &e.Entrepreneur)
+		l.status = string(e.Status)
+		l.enrolled = formatDate(e.EnrollmentDate)
+	}
+	for i := range services {
+		s := &services[i]
+		if s.Status == db.ServiceCancelled {
+			continue
+		}
+		get(&s.Hub, &s.Entrepreneur).services++
+	}
+
+	sorted := make([]*line, 0, len(lines))
+	entrepreneurs := map[uuid.UUID]bool{}
+	total := 0
+	for k, l := range lines {
+		sorted = append(sorted, l)
+		entrepreneurs[k.entrepreneur] = true
+		total += l.services
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].hub != sorted[j].hub {
+			return sorted[i].hub < sorted[j].hub
+		}
+		return sorted[i].business < sorted[j].business
+	})
+	for _, l := range sorted {
+		r.Rows = append(r.Rows, []string{l.hub, l.business, l.businessType, l.status, l.enrolled, itoa(l.services)})
+	}
+	r.Totals = []string{"Total", fmt.Sprintf("%d entrepreneurs", len(entrepreneurs)), "", "", "", itoa(total)}
+	return r
+}
+
+// BuildServicesByType counts services per type and status
+func BuildServicesByType(p Params, services []db.ServiceProvision) *Report {
+	r := newReport(ServicesByType, p,
+		text("Service Type"), number("Services"), number("Completed"), number("Active"), number("Pending"), number("Cancelled"), number("Entrepreneurs"))
+
+	type line struct {
+		name          string
+		byStatus      map[db.ServiceProvisionStatus]int
+		total         int
+		entrepreneurs map[uuid.UUID]bool
+	}
+	lines := map[string]*line{}
+	allEntrepreneurs := map[uuid.UUID]bool{}
+	totals := map[db.ServiceProvisionStatus]int{}
+	for _, s := range services {
+		k := strings.ToLower(strings.TrimSpace(s.ServiceType))
+		if lines[k] == nil {
+			lines[k] = &line{name: strings.TrimSpace(s.ServiceType), byStatus: map[db.ServiceProvisionStatus]int{}, entrepreneurs: map[uuid.UUID]bool{}}
+		}
+		l := lines[k]
+		l.total++
+		l.byStatus[s.Status]++
+		l.entrepreneurs[s.EntrepreneurID] = true
+		allEntrepreneurs[s.EntrepreneurID] = true
+		totals[s.Status]++
+	}
+
+	sorted := make([]*line, 0, len(lines))
+	for _, l := range lines {
+		sorted = append(sorted, l)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].total != sorted[j].total {
+			return sorted[i].total > sorted[j].total
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	for _, l := range sorted {
+		r.Rows = append(r.Rows, []string{l.name, itoa(l.total),
+			itoa(l.byStatus[db.ServiceCompleted]), itoa(l.byStatus[db.ServiceActive]),
+			itoa(l.byStatus[db.ServicePending]), itoa(l.byStatus[db.ServiceCancelled]),
+			itoa(len(l.entrepreneurs))})
+	}
+	r.Totals = []string{"Total", itoa(len(services)),
+		itoa(totals[db.ServiceCompleted]), itoa(totals[db.ServiceActive]),
+		itoa(totals[db.ServicePending]), itoa(totals[db.ServiceCancelled]),
+		itoa(len(allEntrepreneurs))}
+	return r
+}
+
+// BuildCollaborations pairs each lead hub with its partners, counting the
+// services they co-delivered and the collaboration activity they posted
+func BuildCollaborations(p Params, services []db.ServiceProvision, activities []db.HubActivity) *Report {
+	r := newReport(Collaborations, p,
+		text("Lead Hub"), text("Partner Hub"), number("Joint Services"), number("Completed"), number("Entrepreneurs"), number("Activities"))
+
+	type key struct{ lead, partner uuid.UUID }
+	type line struct {
+		lead, partner       string
+		services, completed int
+		activities          int
+		entrepreneurs       map[uuid.UUID]bool
+	}
+	lines := map[key]*line{}
+	get := func(lead *db.CommunityCenter, partner *db.CommunityCenter) *line {
+		k := key{lead.ID, partner.ID}
+		if lines[k] == nil {
+			lines[k] = &line{lead: lead.Name, partner: partner.Name, entrepreneurs: map[uuid.UUID]bool{}}
+		}
+		return lines[k]
+	}
+
+	for i := range services {
+		s := &services[i]
+		if s.CollaboratingHub == nil || s.Status == db.ServiceCancelled {
+			continue
+		}
+		l := get(&s.Hub, s.CollaboratingHub)
+		l.services++
+		if s.Status == db.ServiceCompleted {
+			l.completed++
+		}
+		l.entrepreneurs[s.EntrepreneurID] = true
+	}
+	for i := range activities {
+		a := &activities[i]
+		if a.CollaboratingHub == nil {
+			continue
+		}
+		get(&a.Hub, a.CollaboratingHub).activities++
+	}
+
+	sorted := make([]*line, 0, len(lines))
+	var servicesTotal, completedTotal, activitiesTotal int
+	entrepreneurs := map[uuid.UUID]bool{}
+	for _, l := range lines {
+		sorted = append(sorted, l)
+		servicesTotal += l.services
+		completedTotal += l.completed
+		activitiesTotal += l.activities
+		for id := range l.entrepreneurs {
+			entrepreneurs[id] = true
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].lead != sorted[j].lead {
+			return sorted[i].lead < sorted[j].lead
+		}
+		return sorted[i].partner < sorted[j].partner
+	})
+	for _, l := range sorted {
+		r.Rows = append(r.Rows, []string{l.lead, l.partner, itoa(l.services), itoa(l.completed), itoa(len(l.entrepreneurs)), itoa(l.activities)})
+	}
+	r.Totals = []string{"Total", fmt.Sprintf("%d partnerships", len(sorted)),
+		itoa(servicesTotal), itoa(completedTotal), itoa(len(entrepreneurs)), itoa(activitiesTotal)}
+	return r
+}
+
+// BuildInvestorLinkages groups services naming an investor by investor.
+// Names are matched case-insensitively; the first spelling seen is shown.
+func BuildInvestorLinkages(p Params, services []db.ServiceProvision) *Report {
+	r := newReport(InvestorLinkages, p,
+		text("Investor"), number("Linkages"), number("Completed"), number("Entrepreneurs"), number("Hubs"), text("Latest Linkage"))
+
+	type line struct {
+		name                string
+		linkages, completed int
+		entrepreneurs, hubs map[uuid.UUID]bool
+		latest              time.Time
+	}
+	lines := map[string]*line{}
+	entrepreneurs := map[uuid.UUID]bool{}
+	var linkages, completed int
+	for _, s := range services {
+		if s.InvestorName == nil || strings.TrimSpace(*s.InvestorName) == "" || s.Status == db.ServiceCancelled {
+			continue
+		}
+		name := strings.Join(strings.Fields(*s.InvestorName), " ")
+		k := strings.ToLower(name)
+		if lines[k] == nil {
+			lines[k] = &line{name: name, entrepreneurs: map[uuid.UUID]bool{}, hubs: map[uuid.UUID]bool{}}
+		}
+		l := lines[k]
+		l.linkages++
+		linkages++
+		if s.Status == db.ServiceCompleted {
+			l.completed++
+			completed++
+		}
+		l.entrepreneurs[s.EntrepreneurID] = true
+		entrepreneurs[s.EntrepreneurID] = true
+		l.hubs[s.HubID] = true
+		when := s.CreatedAt
+		if s.StartDate != nil {
+			when = *s.StartDate
+		}
+		if when.After(l.latest) {
+			l.latest = when
+		}
+	}
+
+	sorted := make([]*line, 0, len(lines))
+	for _, l := range lines {
+		sorted = append(sorted, l)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].linkages != sorted[j].linkages {
+			return sorted[i].linkages > sorted[j].linkages
+		}
+		return sorted[i].name < sorted[j].name
+	})
+	for _, l := range sorted {
+		r.Rows = append(r.Rows, []string{l.name, itoa(l.linkages), itoa(l.completed),
+			itoa(len(l.entrepreneurs)), itoa(len(l.hubs)), formatDate(&l.latest)})
+	}
+	r.Totals = []string{fmt.Sprintf("%d investors", len(sorted)), itoa(linkages), itoa(completed), itoa(len(entrepreneurs)), "", ""}
+	return r
+}
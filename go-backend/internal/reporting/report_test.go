@@ -0,0 +1,134 @@
+package reporting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func strPtr(s string) *string { return &s }
+
+type fixture struct {
+	hubA, hubB db.CommunityCenter
+	ann, bob   db.Entrepreneur
+	params     Params
+}
+
+func newFixture() fixture {
+	return fixture{
+		hubA:   db.CommunityCenter{ID: uuid.New(), Name: "Alpha Hub"},
+		hubB:   db.CommunityCenter{ID: uuid.New(), Name: "Beta Hub"},
+		ann:    db.Entrepreneur{ID: uuid.New(), BusinessName: "Ann's Bakery", BusinessType: "Food"},
+		bob:    db.Entrepreneur{ID: uuid.New(), BusinessName: "Bob Tech", BusinessType: "Tech"},
+		params: Params{From: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func (f fixture) service(hub db.CommunityCenter, e db.Entrepreneur, serviceType string, status db.ServiceProvisionStatus) db.ServiceProvision {
+	return db.ServiceProvision{ID: uuid.New(), HubID: hub.ID, Hub: hub, EntrepreneurID: e.ID, Entrepreneur: e,
+		ServiceType: serviceType, Status: status, CreatedAt: f.params.From}
+}
+
+func TestLastQuarter(t *testing.T) {
+	from, to := LastQuarter(time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC))
+	if from.Format(dateLayout) != "2024-01-01" || to.Format(dateLayout) != "2024-03-31" {
+		t.Fatalf("unexpected quarter %s - %s", from, to)
+	}
+	from, to = LastQuarter(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	if from.Format(dateLayout) != "2023-10-01" || to.Format(dateLayout) != "2023-12-31" {
+		t.Fatalf("unexpected quarter across years %s - %s", from, to)
+	}
+}
+
+func TestBuildEntrepreneursServed(t *testing.T) {
+	f := newFixture()
+	enrolled := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	enrollments := []db.HubEnrollment{
+		{HubID: f.hubA.ID, Hub: f.hubA, EntrepreneurID: f.ann.ID, Entrepreneur: f.ann, Status: db.EnrollmentActive, EnrollmentDate: &enrolled},
+	}
+	services := []db.ServiceProvision{
+		f.service(f.hubA, f.ann, "Mentorship", db.ServiceActive),
+		f.service(f.hubA, f.ann, "Training", db.ServiceCompleted),
+		f.service(f.hubB, f.bob, "Training", db.ServicePending),
+		f.service(f.hubB, f.bob, "Training", db.ServiceCancelled),
+	}
+
+	r := BuildEntrepreneursServed(f.params, enrollments, services)
+	if len(r.Rows) != 2 {
+		t.Fatalf("expected one row per hub and entrepreneur, got %v", r.Rows)
+	}
+	if got := r.Rows[0]; got[0] != "Alpha Hub" || got[3] != "ACTIVE" || got[4] != "2024-01-15" || got[5] != "2" {
+		t.Fatalf("unexpected enrolled row %v", got)
+	}
+	if got := r.Rows[1]; got[1] != "Bob Tech" || got[3] != "" || got[5] != "1" {
+		t.Fatalf("expected service-only row without cancelled services, got %v", got)
+	}
+	if r.Totals[1] != "2 entrepreneurs" || r.Totals[5] != "3" {
+		t.Fatalf("unexpected totals %v", r.Totals)
+	}
+}
+
+func TestBuildServicesByType(t *testing.T) {
+	f := newFixture()
+	services := []db.ServiceProvision{
+		f.service(f.hubA, f.ann, "Training", db.ServiceCompleted),
+		f.service(f.hubA, f.bob, " training", db.ServiceActive),
+		f.service(f.hubA, f.ann, "Mentorship", db.ServiceCancelled),
+	}
+
+	r := BuildServicesByType(f.params, services)
+	want := [][]string{
+		{"Training", "2", "1", "1", "0", "0", "2"},
+		{"Mentorship", "1", "0", "0", "0", "1", "1"},
+	}
+	for i := range want {
+		for j := range want[i] {
+			if r.Rows[i][j] != want[i][j] {
+				t.Fatalf("row %d: got %v, want %v", i, r.Rows[i], want[i])
+			}
+		}
+	}
+	if r.Totals[1] != "3" || r.Totals[6] != "2" {
+		t.Fatalf("unexpected totals %v", r.Totals)
+	}
+}
+
+func TestBuildCollaborations(t *testing.T) {
+	f := newFixture()
+	joint := f.service(f.hubA, f.ann, "Training", db.ServiceCompleted)
+	joint.CollaboratingHubID, joint.CollaboratingHub = &f.hubB.ID, &f.hubB
+	solo := f.service(f.hubA, f.bob, "Training", db.ServiceActive)
+	activities := []db.HubActivity{
+		{HubID: f.hubA.ID, Hub: f.hubA, CollaboratingHubID: &f.hubB.ID, CollaboratingHub: &f.hubB, Type: db.ActivityCollaboration},
+		{HubID: f.hubB.ID, Hub: f.hubB, Type: db.ActivityCollaboration},
+	}
+
+	r := BuildCollaborations(f.params, []db.ServiceProvision{joint, solo}, activities)
+	if len(r.Rows) != 1 {
+		t.Fatalf("expected a single partnership, got %v", r.Rows)
+	}
+	if got := r.Rows[0]; got[0] != "Alpha Hub" || got[1] != "Beta Hub" || got[2] != "1" || got[3] != "1" || got[5] != "1" {
+		t.Fatalf("unexpected partnership row %v", got)
+	}
+}
+
+func TestBuildInvestorLinkages(t *testing.T) {
+	f := newFixture()
+	a := f.service(f.hubA, f.ann, "Investment", db.ServiceCompleted)
+	a.InvestorName = strPtr("Kampala  Angels")
+	b := f.service(f.hubB, f.bob, "Investment", db.ServiceActive)
+	b.InvestorName = strPtr("kampala angels")
+	c := f.service(f.hubB, f.bob, "Investment", db.ServiceActive)
+	c.InvestorName = strPtr("  ")
+
+	r := BuildInvestorLinkages(f.params, []db.ServiceProvision{a, b, c})
+	if len(r.Rows) != 1 {
+		t.Fatalf("expected investor names to be merged, got %v", r.Rows)
+	}
+	if got := r.Rows[0]; got[0] != "Kampala Angels" || got[1] != "2" || got[2] != "1" || got[3] != "2" || got[4] != "2" {
+		t.Fatalf("unexpected linkage row %v", got)
+	}
+}
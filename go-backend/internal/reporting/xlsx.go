@@ -0,0 +1,198 @@
+package reporting
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The workbook is the smallest SpreadsheetML package Excel, LibreOffice and
+// Google Sheets open without repair: one sheet with inline strings and a
+// style sheet holding a bold font for the title, header and totals.
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+
+	xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`
+)
+
+const (
+	styleNormal = 0
+	styleBold   = 1
+)
+
+// WriteXLSX writes the report as a single-sheet Excel workbook: title, period
+// and scope lines above the table, numeric columns stored as numbers
+func (r *Report) WriteXLSX(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	parts := []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", r.xlsxWorkbook()},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", r.xlsxSheet()},
+	}
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func (r *Report) xlsxWorkbook() string {
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="` + xmlEscape(sheetName(r.Title)) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`
+}
+
+func (r *Report) xlsxSheet() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+
+	b.WriteString("<cols>")
+	for i, width := range r.columnWidths(60) {
+		n := strconv.Itoa(i + 1)
+		b.WriteString(`<col min="` + n + `" max="` + n + `" width="` + strconv.Itoa(width+2) + `" customWidth="1"/>`)
+	}
+	b.WriteString("</cols><sheetData>")
+
+	row := 0
+	writeRow := func(cells []string, style int, numeric bool) {
+		row++
+		b.WriteString(`<row r="` + strconv.Itoa(row) + `">`)
+		for i, value := range cells {
+			if value == "" {
+				continue
+			}
+			ref := columnName(i) + strconv.Itoa(row)
+			attrs := `r="` + ref + `"`
+			if style != styleNormal {
+				attrs += ` s="` + strconv.Itoa(style) + `"`
+			}
+			if numeric && i < len(r.Columns) && r.Columns[i].Numeric {
+				if _, err := strconv.ParseFloat(value, 64); err == nil {
+					b.WriteString(`<c ` + attrs + `><v>` + value + `</v></c>`)
+					continue
+				}
+			}
+			b.WriteString(`<c ` + attrs + ` t="inlineStr"><is><t xml:space="preserve">` + xmlEscape(value) + `</t></is></c>`)
+		}
+		b.WriteString("</row>")
+	}
+
+	writeRow([]string{r.Title}, styleBold, false)
+	writeRow([]string{"Period", r.Period}, styleNormal, false)
+	writeRow([]string{"Hubs", r.Scope}, styleNormal, false)
+	writeRow([]string{"Generated", r.GeneratedAt.Format("2006-01-02 15:04 MST")}, styleNormal, false)
+	row++ // Blank line before the table
+
+	header := make([]string, len(r.Columns))
+	for i, col := range r.Columns {
+		header[i] = col.Title
+	}
+	writeRow(header, styleBold, false)
+	for _, cells := range r.Rows {
+		writeRow(cells, styleNormal, true)
+	}
+	if r.Totals != nil {
+		writeRow(r.Totals, styleBold, true)
+	}
+
+	b.WriteString("</sheetData></worksheet>")
+	return b.String()
+}
+
+// columnWidths returns each column's widest cell in characters, capped at max
+func (r *Report) columnWidths(max int) []int {
+	widths := make([]int, len(r.Columns))
+	measure := func(cells []string) {
+		for i, value := range cells {
+			if i < len(widths) {
+				if n := len([]rune(value)); n > widths[i] {
+					widths[i] = n
+				}
+			}
+		}
+	}
+	for i, col := range r.Columns {
+		widths[i] = len([]rune(col.Title))
+	}
+	for _, cells := range r.Rows {
+		measure(cells)
+	}
+	measure(r.Totals)
+	for i := range widths {
+		if widths[i] > max {
+			widths[i] = max
+		}
+	}
+	return widths
+}
+
+// columnName converts a zero-based index to a spreadsheet column (A, B, ... AA)
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// sheetName trims a title to Excel's 31 characters without forbidden symbols
+func sheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return ' '
+		}
+		return r
+	}, title)
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if strings.TrimSpace(name) == "" {
+		return "Report"
+	}
+	return name
+}
+
+func xmlEscape(s string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
@@ -0,0 +1,81 @@
+// Package storage keeps uploaded files out of the database. Objects live in
+// a Blob under slash-separated keys; the database records the key alongside
+// whatever metadata the feature needs.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object describes a stored object
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+}
+
+// Blob is a flat object store
+type Blob interface {
+	// Put stores size bytes from r under key, replacing any existing object
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens an object; the caller closes the reader. Missing objects
+	// return ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, Object, error)
+	// Delete removes an object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selects and configures a backend
+type Config struct {
+	Driver   string // "local" (default) or "s3"
+	LocalDir string
+	S3       S3Config
+}
+
+// New builds the backend named by cfg.Driver
+func New(cfg Config) (Blob, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Driver)) {
+	case "", "local":
+		return NewLocal(cfg.LocalDir)
+	case "s3":
+		return NewS3(cfg.S3)
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+}
+
+// NewKey returns a fresh key under prefix that keeps the file's extension,
+// e.g. "portfolio/<item>/<uuid>.pdf". The original name is not used
+// otherwise, so keys never carry user input.
+func NewKey(prefix, filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if len(ext) > 10 || strings.ContainsAny(ext, `/\ `) {
+		ext = ""
+	}
+	return path.Join(prefix, uuid.NewString()+ext)
+}
+
+// ValidKey rejects keys that could escape a backend's namespace
+func ValidKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package taxonomy
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+// Entry is a taxonomy category as seen by the resolver
+type Entry struct {
+	ID       uuid.UUID
+	Kind     db.TaxonomyKind
+	Slug     string
+	Name     string
+	Synonyms []string
+	ParentID *uuid.UUID
+}
+
+// Index resolves free-text service and resource values onto taxonomy entries
+type Index struct {
+	entries map[uuid.UUID]Entry
+	lookup  map[db.TaxonomyKind]map[string]uuid.UUID
+}
+
+// NewIndex builds an index; lookups match slug, name and synonyms after
+// normalizing case, punctuation and whitespace
+func NewIndex(entries []Entry) *Index {
+	idx := &Index{
+		entries: make(map[uuid.UUID]Entry, len(entries)),
+		lookup:  map[db.TaxonomyKind]map[string]uuid.UUID{},
+	}
+	for _, e := range entries {
+		idx.entries[e.ID] = e
+		if idx.lookup[e.Kind] == nil {
+			idx.lookup[e.Kind] = map[string]uuid.UUID{}
+		}
+		keys := append([]string{e.Slug, e.Name}, e.Synonyms...)
+		for _, k := range keys {
+			key := Slugify(k)
+			if key == "" {
+				continue
+			}
+			// first entry wins so synonyms never shadow another entry's slug
+			if _, taken := idx.lookup[e.Kind][key]; !taken {
+				idx.lookup[e.Kind][key] = e.ID
+			}
+		}
+	}
+	return idx
+}
+
+// Load builds an index from every category in the database
+func Load(gdb *gorm.DB) (*Index, error) {
+	categories, err := db.ListServiceCategories(gdb, "")
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, len(categories))
+	for i, cat := range categories {
+		entries[i] = FromModel(&cat)
+	}
+	return NewIndex(entries), nil
+}
+
+// FromModel converts a stored category into an index entry
+func FromModel(cat *db.ServiceCategory) Entry {
+	return Entry{
+		ID:       cat.ID,
+		Kind:     cat.Kind,
+		Slug:     cat.Slug,
+		Name:     cat.Name,
+		Synonyms: cat.Synonyms,
+		ParentID: cat.ParentID,
+	}
+}
+
+// Empty reports whether the taxonomy has no entries of this kind. Validation
+// is skipped for kinds that have not been set up yet.
+func (idx *Index) Empty(kind db.TaxonomyKind) bool {
+	return len(idx.lookup[kind]) == 0
+}
+
+// Resolve finds the entry matching a free-text value
+func (idx *Index) Resolve(kind db.TaxonomyKind, value string) (Entry, bool) {
+	id, ok := idx.lookup[kind][Slugify(value)]
+	if !ok {
+		return Entry{}, false
+	}
+	return idx.entries[id], true
+}
+
+// Canonicalize maps values onto entry names, dropping duplicates and keeping
+// the original order. Values that match no entry are returned separately.
+func (idx *Index) Canonicalize(kind db.TaxonomyKind, values []string) (canonical []string, unmapped []string) {
+	canonical = []string{}
+	unmapped = []string{}
+	seen := map[string]struct{}{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		name := v
+		if entry, ok := idx.Resolve(kind, v); ok {
+			name = entry.Name
+		} else {
+			unmapped = append(unmapped, v)
+		}
+		if _, dup := seen[name]; dup {
+			continue
+		}
+		seen[name] = struct{}{}
+		canonical = append(canonical, name)
+	}
+	return canonical, unmapped
+}
+
+// Ancestors returns the chain of parent IDs for an entry, nearest first. It
+// stops if it meets a cycle.
+func (idx *Index) Ancestors(id uuid.UUID) []uuid.UUID {
+	out := []uuid.UUID{}
+	seen := map[uuid.UUID]struct{}{id: {}}
+	cur, ok := idx.entries[id]
+	for ok && cur.ParentID != nil {
+		if _, loop := seen[*cur.ParentID]; loop {
+			break
+		}
+		seen[*cur.ParentID] = struct{}{}
+		out = append(out, *cur.ParentID)
+		cur, ok = idx.entries[*cur.ParentID]
+	}
+	return out
+}
+
+// Slugify lower-cases a value and joins its alphanumeric words with dashes,
+// e.g. "Sports & Recreation" becomes "sports-recreation"
+func Slugify(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(s)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
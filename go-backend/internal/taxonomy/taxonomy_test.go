@@ -0,0 +1,53 @@
+package taxonomy
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"communitycentresplatform/go-backend/internal/db"
+)
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"Sports & Recreation": "sports-recreation",
+		"  Mentorship ":       "mentorship",
+		"ICT/Computer Lab":    "ict-computer-lab",
+		"":                    "",
+	}
+	for in, want := range cases {
+		if got := Slugify(in); got != want {
+			t.Fatalf("Slugify(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestIndexResolveAndCanonicalize(t *testing.T) {
+	business := Entry{ID: uuid.New(), Kind: db.TaxonomyService, Slug: "business-support", Name: "Business Support"}
+	mentorship := Entry{ID: uuid.New(), Kind: db.TaxonomyService, Slug: "mentorship", Name: "Mentorship",
+		Synonyms: []string{"mentoring", "Mentor sessions"}, ParentID: &business.ID}
+	lab := Entry{ID: uuid.New(), Kind: db.TaxonomyResource, Slug: "computer-lab", Name: "Computer Lab"}
+	idx := NewIndex([]Entry{business, mentorship, lab})
+
+	if e, ok := idx.Resolve(db.TaxonomyService, "MENTORING"); !ok || e.ID != mentorship.ID {
+		t.Fatalf("expected synonym to resolve to mentorship, got %+v", e)
+	}
+	if _, ok := idx.Resolve(db.TaxonomyService, "computer lab"); ok {
+		t.Fatalf("resource entries must not resolve as services")
+	}
+
+	canonical, unmapped := idx.Canonicalize(db.TaxonomyService, []string{"mentoring", "Mentorship", "Legal Aid", " "})
+	if len(canonical) != 2 || canonical[0] != "Mentorship" || canonical[1] != "Legal Aid" {
+		t.Fatalf("unexpected canonical values: %v", canonical)
+	}
+	if len(unmapped) != 1 || unmapped[0] != "Legal Aid" {
+		t.Fatalf("unexpected unmapped values: %v", unmapped)
+	}
+
+	if anc := idx.Ancestors(mentorship.ID); len(anc) != 1 || anc[0] != business.ID {
+		t.Fatalf("unexpected ancestors: %v", anc)
+	}
+	if idx.Empty(db.TaxonomyResource) || !NewIndex(nil).Empty(db.TaxonomyService) {
+		t.Fatalf("unexpected Empty result")
+	}
+}